@@ -0,0 +1,188 @@
+package main
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// CellState is the state of a Brian's Brain cell
+type CellState uint8
+
+// CellState constants
+const (
+	StateOff   CellState = iota // Resting, can fire
+	StateDying                  // Refractory, cannot fire
+	StateOn                     // Firing
+)
+
+// BriansBrain is a three-state cellular automaton. Each generation an off
+// cell turns on if exactly two of its eight neighbours are on, an on cell
+// starts dying, and a dying cell turns off.
+type BriansBrain struct {
+	mu         sync.RWMutex
+	rows       int
+	cols       int
+	boundary   BoundaryType
+	density    float64
+	current    [][]CellState
+	next       [][]CellState
+	generation int
+	population int // Number of on cells
+	dying      int // Number of dying cells
+	rng        *rand.Rand
+}
+
+// NewBriansBrain creates a grid seeded with on cells at the given density
+func NewBriansBrain(rows, cols int, boundary BoundaryType, density float64) *BriansBrain {
+	// Use time-based seeding for randomization (not cryptographic)
+	// #nosec G115 - Conversion is safe for our use case
+	seed := uint64(time.Now().UnixNano())
+
+	bb := &BriansBrain{
+		density: density,
+		// #nosec G404 - Using math/rand for simulation, not cryptography
+		rng: rand.New(rand.NewPCG(seed, seed)),
+	}
+	bb.Reset(rows, cols, boundary)
+	return bb
+}
+
+// Reset resizes the grid, sets the boundary and reseeds it randomly
+func (bb *BriansBrain) Reset(rows, cols int, boundary BoundaryType) {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+
+	bb.rows = max(rows, 1)
+	bb.cols = max(cols, 1)
+	bb.boundary = boundary
+	bb.current = newGrid(bb.rows, bb.cols)
+	bb.next = newGrid(bb.rows, bb.cols)
+	bb.generation = 0
+	bb.dying = 0
+	bb.population = 0
+	for i := range bb.current {
+		for j := range bb.current[i] {
+			if bb.rng.Float64() < bb.density {
+				bb.current[i][j] = StateOn
+				bb.population++
+			}
+		}
+	}
+}
+
+// SetCells replaces the grid contents, for tests and fixed patterns. The
+// cells must match the grid size.
+func (bb *BriansBrain) SetCells(cells [][]CellState) {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+
+	bb.population, bb.dying = 0, 0
+	for i := range bb.current {
+		copy(bb.current[i], cells[i])
+		for _, state := range bb.current[i] {
+			switch state {
+			case StateOn:
+				bb.population++
+			case StateDying:
+				bb.dying++
+			}
+		}
+	}
+}
+
+// newGrid allocates a rows×cols grid of off cells
+func newGrid(rows, cols int) [][]CellState {
+	grid := make([][]CellState, rows)
+	for i := range grid {
+		grid[i] = make([]CellState, cols)
+	}
+	return grid
+}
+
+// Step advances the automaton by one generation
+func (bb *BriansBrain) Step() {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+
+	bb.population, bb.dying = 0, 0
+	for i := range bb.current {
+		for j, state := range bb.current[i] {
+			var next CellState
+			switch state {
+			case StateOn:
+				next = StateDying
+				bb.dying++
+			case StateDying:
+				next = StateOff
+			default:
+				if bb.countOnNeighbors(i, j) == 2 {
+					next = StateOn
+					bb.population++
+				}
+			}
+			bb.next[i][j] = next
+		}
+	}
+
+	bb.current, bb.next = bb.next, bb.current
+	bb.generation++
+}
+
+// countOnNeighbors counts the on cells among the eight neighbours of (row, col)
+func (bb *BriansBrain) countOnNeighbors(row, col int) int {
+	count := 0
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			if dr == 0 && dc == 0 {
+				continue
+			}
+			r, c := row+dr, col+dc
+			if bb.boundary == BoundaryPeriodic {
+				r = (r + bb.rows) % bb.rows
+				c = (c + bb.cols) % bb.cols
+			} else if r < 0 || r >= bb.rows || c < 0 || c >= bb.cols {
+				continue
+			}
+			if bb.current[r][c] == StateOn {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// GetGrid returns the current cell states
+func (bb *BriansBrain) GetGrid() [][]CellState {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	return bb.current
+}
+
+// GetGeneration returns the number of generations since the last reset
+func (bb *BriansBrain) GetGeneration() int {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	return bb.generation
+}
+
+// GetPopulation returns the number of on cells
+func (bb *BriansBrain) GetPopulation() int {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	return bb.population
+}
+
+// GetDying returns the number of dying cells
+func (bb *BriansBrain) GetDying() int {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	return bb.dying
+}
+
+// GetBoundary returns the boundary type
+func (bb *BriansBrain) GetBoundary() BoundaryType {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	return bb.boundary
+}
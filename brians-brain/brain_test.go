@@ -0,0 +1,132 @@
+package main
+
+import "testing"
+
+// gridWith returns a rows×cols grid with the given cells on
+func gridWith(rows, cols int, on ...[2]int) [][]CellState {
+	grid := newGrid(rows, cols)
+	for _, p := range on {
+		grid[p[0]][p[1]] = StateOn
+	}
+	return grid
+}
+
+// Test the on → dying → off cycle of a lone cell
+func TestLoneCellCycle(t *testing.T) {
+	bb := NewBriansBrain(5, 5, BoundaryFixed, DefaultDensity)
+	bb.SetCells(gridWith(5, 5, [2]int{2, 2}))
+	if bb.GetPopulation() != 1 {
+		t.Fatalf("Expected population 1, got %d", bb.GetPopulation())
+	}
+
+	bb.Step()
+	if state := bb.GetGrid()[2][2]; state != StateDying {
+		t.Errorf("Expected the cell to be dying, got %d", state)
+	}
+	if bb.GetPopulation() != 0 || bb.GetDying() != 1 {
+		t.Errorf("Expected 0 on and 1 dying, got %d and %d", bb.GetPopulation(), bb.GetDying())
+	}
+
+	bb.Step()
+	if state := bb.GetGrid()[2][2]; state != StateOff {
+		t.Errorf("Expected the cell to be off, got %d", state)
+	}
+	if bb.GetGeneration() != 2 {
+		t.Errorf("Expected generation 2, got %d", bb.GetGeneration())
+	}
+}
+
+// Test that off cells with exactly two on neighbours fire
+func TestBirth(t *testing.T) {
+	bb := NewBriansBrain(6, 6, BoundaryFixed, DefaultDensity)
+	bb.SetCells(gridWith(6, 6, [2]int{2, 2}, [2]int{2, 3}))
+	bb.Step()
+
+	grid := bb.GetGrid()
+	for _, p := range [][2]int{{1, 2}, {1, 3}, {3, 2}, {3, 3}} {
+		if grid[p[0]][p[1]] != StateOn {
+			t.Errorf("Expected (%d,%d) to fire", p[0], p[1])
+		}
+	}
+	for _, p := range [][2]int{{2, 2}, {2, 3}} {
+		if grid[p[0]][p[1]] != StateDying {
+			t.Errorf("Expected (%d,%d) to be dying", p[0], p[1])
+		}
+	}
+	if bb.GetPopulation() != 4 || bb.GetDying() != 2 {
+		t.Errorf("Expected 4 on and 2 dying, got %d and %d", bb.GetPopulation(), bb.GetDying())
+	}
+}
+
+// Test that three on neighbours do not make a cell fire
+func TestNoBirthWithThreeNeighbors(t *testing.T) {
+	bb := NewBriansBrain(5, 5, BoundaryFixed, DefaultDensity)
+	bb.SetCells(gridWith(5, 5, [2]int{1, 1}, [2]int{1, 2}, [2]int{1, 3}))
+	bb.Step()
+	if state := bb.GetGrid()[2][2]; state != StateOff {
+		t.Errorf("Expected (2,2) with three on neighbours to stay off, got %d", state)
+	}
+}
+
+// Test periodic and fixed boundaries
+func TestBoundaries(t *testing.T) {
+	cells := gridWith(4, 4, [2]int{0, 1}, [2]int{0, 2})
+
+	periodic := NewBriansBrain(4, 4, BoundaryPeriodic, DefaultDensity)
+	periodic.SetCells(cells)
+	periodic.Step()
+	if state := periodic.GetGrid()[3][1]; state != StateOn {
+		t.Errorf("Expected (3,1) to fire across the periodic edge, got %d", state)
+	}
+
+	fixed := NewBriansBrain(4, 4, BoundaryFixed, DefaultDensity)
+	fixed.SetCells(cells)
+	fixed.Step()
+	if state := fixed.GetGrid()[3][1]; state != StateOff {
+		t.Errorf("Expected (3,1) to stay off with a fixed edge, got %d", state)
+	}
+}
+
+// Test that Reset resizes and reseeds the grid
+func TestReset(t *testing.T) {
+	bb := NewBriansBrain(10, 10, BoundaryPeriodic, 1)
+	if bb.GetPopulation() != 100 {
+		t.Errorf("Expected a full grid at density 1, got %d", bb.GetPopulation())
+	}
+	bb.Step()
+
+	bb.Reset(4, 6, BoundaryFixed)
+	grid := bb.GetGrid()
+	if len(grid) != 4 || len(grid[0]) != 6 {
+		t.Errorf("Expected a 4x6 grid, got %dx%d", len(grid), len(grid[0]))
+	}
+	if bb.GetGeneration() != 0 {
+		t.Errorf("Expected generation 0 after reset, got %d", bb.GetGeneration())
+	}
+	if bb.GetBoundary() != BoundaryFixed {
+		t.Error("Expected fixed boundary after reset")
+	}
+}
+
+// Test configuration validation
+func TestConfigCheck(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.OnColor = "white"
+	cfg.DyingChar = "ab"
+	cfg.Density = 1.5
+	cfg.Check()
+	if cfg.OnColor != DefaultOnColor {
+		t.Errorf("Expected default on color, got %s", cfg.OnColor)
+	}
+	if cfg.DyingChar != DefaultDyingChar {
+		t.Errorf("Expected default dying char, got %s", cfg.DyingChar)
+	}
+	if cfg.Density != DefaultDensity {
+		t.Errorf("Expected default density, got %v", cfg.Density)
+	}
+
+	cfg.SetBoundary("FIXED")
+	if cfg.Boundary != BoundaryFixed {
+		t.Error("Expected fixed boundary")
+	}
+}
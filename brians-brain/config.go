@@ -0,0 +1,160 @@
+// Package main implements a terminal-based Brian's Brain cellular automaton.
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/telepair/go-playground/pkg"
+)
+
+// BoundaryType represents how the grid edges are treated
+type BoundaryType int
+
+// BoundaryType constants
+const (
+	BoundaryPeriodic BoundaryType = iota // Periodic boundary (wrapping, default)
+	BoundaryFixed                        // Fixed boundary (off cells outside)
+)
+
+// ToString returns the string representation of boundary type
+func (bt BoundaryType) ToString(language Language) string {
+	switch bt {
+	case BoundaryFixed:
+		if language == Chinese {
+			return "固定"
+		}
+		return "Fixed"
+	default:
+		if language == Chinese {
+			return "周期"
+		}
+		return "Periodic"
+	}
+}
+
+// Language represents the supported languages
+type Language int
+
+// Language constants
+const (
+	English Language = iota
+	Chinese
+)
+
+// ToString returns the string representation of language
+func (l Language) ToString(language Language) string {
+	switch l {
+	case English:
+		if language == Chinese {
+			return "英文"
+		}
+		return "en"
+	case Chinese:
+		if language == Chinese {
+			return "中文"
+		}
+		return "cn"
+	}
+	if language == Chinese {
+		return "英文"
+	}
+	return "en"
+}
+
+// Application constants
+const (
+	// Grid and display constants
+	DefaultRows = 30 // Default window rows
+	DefaultCols = 80 // Default window columns
+
+	DefaultLanguage    = English                // Default language
+	DefaultRefreshRate = 100 * time.Millisecond // Default refresh rate
+	MinRefreshRate     = 10 * time.Millisecond  // Minimum refresh rate
+	DefaultBoundary    = BoundaryPeriodic       // Default boundary type
+	DefaultDensity     = 0.2                    // Default fraction of cells initially on
+
+	// Colors
+	DefaultOnColor    = "#FFFFFF" // Firing cell color (white)
+	DefaultDyingColor = "#1E90FF" // Refractory cell color (blue)
+	DefaultOffColor   = "#000000" // Resting cell color (black)
+
+	// Characters
+	DefaultOnChar    = "█" // Firing cell character
+	DefaultDyingChar = "▒" // Refractory cell character
+	DefaultOffChar   = " " // Resting cell character
+
+	// Default values
+	DefaultLogFile         = "debug.log"     // Default log file path
+	DefaultProfileInterval = 5 * time.Second // Default profile information output interval
+	DefaultProfilePort     = 6060            // Default profile server port
+)
+
+// DefaultConfig is the default configuration
+var DefaultConfig = Config{
+	OnColor:    DefaultOnColor,
+	DyingColor: DefaultDyingColor,
+	OffColor:   DefaultOffColor,
+	OnChar:     DefaultOnChar,
+	DyingChar:  DefaultDyingChar,
+	OffChar:    DefaultOffChar,
+	Density:    DefaultDensity,
+	Boundary:   DefaultBoundary,
+	Language:   DefaultLanguage,
+}
+
+// Config holds all application configuration
+type Config struct {
+	OnColor    string
+	DyingColor string
+	OffColor   string
+	OnChar     string
+	DyingChar  string
+	OffChar    string
+	Density    float64 // Fraction of cells initially on
+	Boundary   BoundaryType
+	Language   Language
+}
+
+// SetLanguage sets the language
+func (c *Config) SetLanguage(lang string) {
+	langLower := strings.ToLower(lang)
+	if langLower == "cn" || langLower == "zh" {
+		c.Language = Chinese
+	} else {
+		c.Language = English
+	}
+}
+
+// SetBoundary sets the boundary type from its name
+func (c *Config) SetBoundary(boundary string) {
+	if strings.ToLower(boundary) == "fixed" {
+		c.Boundary = BoundaryFixed
+	} else {
+		c.Boundary = BoundaryPeriodic
+	}
+}
+
+// Check validates the configuration
+func (c *Config) Check() {
+	for _, msg := range pkg.Validate(
+		pkg.HexColor("on", &c.OnColor, DefaultOnColor),
+		pkg.HexColor("dying", &c.DyingColor, DefaultDyingColor),
+		pkg.HexColor("off", &c.OffColor, DefaultOffColor),
+		pkg.Char("on", &c.OnChar, DefaultOnChar),
+		pkg.Char("dying", &c.DyingChar, DefaultDyingChar),
+		pkg.Char("off", &c.OffChar, DefaultOffChar),
+		pkg.Language(&c.Language, DefaultLanguage),
+	) {
+		fmt.Println(msg)
+	}
+	if c.Density <= 0 || c.Density > 1 {
+		fmt.Printf("invalid density %v, must be in (0, 1], using default %v\n", c.Density, DefaultDensity)
+		c.Density = DefaultDensity
+	}
+	if c.Boundary != BoundaryPeriodic && c.Boundary != BoundaryFixed {
+		fmt.Printf("invalid boundary %d, using default %s\n", c.Boundary, DefaultBoundary.ToString(c.Language))
+		c.Boundary = DefaultBoundary
+	}
+}
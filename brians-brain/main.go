@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	_ "net/http/pprof" //nolint:gosec
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/telepair/go-playground/pkg"
+)
+
+func main() {
+	// Custom usage function
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Brian's Brain - A Terminal User Interface implementation of the Brian's Brain cellular automaton\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s                              # Run with default settings\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -density 0.05 -boundary fixed # Sparse start inside fixed walls\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -dying-color '#FF4500'        # Orange refractory cells\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -lang cn                     # Run in Chinese\n", os.Args[0])
+	}
+
+	// Parse command line flags
+	var onColor = flag.String("on-color", DefaultOnColor, "Firing cell color (hex)")
+	var dyingColor = flag.String("dying-color", DefaultDyingColor, "Dying cell color (hex)")
+	var offColor = flag.String("off-color", DefaultOffColor, "Off cell color (hex)")
+	var onChar = flag.String("on-char", DefaultOnChar, "Firing cell character")
+	var dyingChar = flag.String("dying-char", DefaultDyingChar, "Dying cell character")
+	var offChar = flag.String("off-char", DefaultOffChar, "Off cell character")
+	var density = flag.Float64("density", DefaultDensity, "Fraction of cells initially firing (0-1]")
+	var boundary = flag.String("boundary", "periodic", "Boundary type (periodic/fixed)")
+	var lang = flag.String("lang", DefaultLanguage.ToString(DefaultLanguage), "Language (en/cn)")
+	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
+	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
+	var profileInterval = flag.Duration("profile-interval", DefaultProfileInterval, "Profile information output interval")
+	var logFile = flag.String("log-file", DefaultLogFile, "Log file path")
+
+	flag.Parse()
+
+	if *logFile != "" {
+		_, _ = pkg.InitLog("debug", "text", *logFile)
+	}
+	slog.Debug("Brian's Brain starting")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize monitoring if enabled
+	if *enableProfiling {
+		go pkg.StartProfile(ctx, *profilePort)
+		go pkg.StartWatchdog(ctx, *profileInterval)
+	}
+
+	// Create and configure application
+	config := Config{
+		OnColor:    *onColor,
+		DyingColor: *dyingColor,
+		OffColor:   *offColor,
+		OnChar:     *onChar,
+		DyingChar:  *dyingChar,
+		OffChar:    *offChar,
+		Density:    *density,
+	}
+	config.SetBoundary(*boundary)
+	config.SetLanguage(*lang)
+	config.Check()
+
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
+	// Create initial model
+	initialModel := NewModel(config)
+
+	// Run the application
+	p := tea.NewProgram(initialModel, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		os.Exit(1)
+	}
+
+	slog.Debug("Brian's Brain finished")
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
+)
+
+// UI styles
+var (
+	// Header styles
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#1E3A8A")).
+			Padding(0, 2).
+			MarginBottom(1).
+			Align(lipgloss.Center)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#4A5568")).
+			Padding(0, 1).
+			Bold(true)
+
+	tableBuilder strings.Builder
+)
+
+// UI text constants
+const (
+	// Header Line
+	HeaderCN = "🧠 布莱恩的大脑 🧠"
+	HeaderEN = "🧠 Brian's Brain 🧠"
+
+	// Status Line
+	GenerationLabelCN = "🔢 代数: %d"
+	GenerationLabelEN = "🔢 Generation: %d"
+
+	PopulationLabelCN = "⚡ 激发: %d"
+	PopulationLabelEN = "⚡ Firing: %d"
+
+	DyingLabelCN = "💤 衰减: %d"
+	DyingLabelEN = "💤 Dying: %d"
+
+	BoundaryLabelCN = "🔒 边界: %s"
+	BoundaryLabelEN = "🔒 Boundary: %s"
+
+	SpeedLabelCN = "🔄 刷新: %s"
+	SpeedLabelEN = "🔄 Speed: %s"
+
+	SizeLabelCN = "📐 尺寸: %d×%d"
+	SizeLabelEN = "📐 Size: %d×%d"
+
+	StatusLabelPlayingCN = "▶️ 运行中"
+	StatusLabelPlayingEN = "▶️ Running"
+	StatusLabelPausedCN  = "⏸️ 已暂停"
+	StatusLabelPausedEN  = "⏸️ Paused"
+
+	// Control Line
+	SelectBoundaryLabelCN = "B 切换边界"
+	SelectBoundaryLabelEN = "B Toggle Boundary"
+
+	SpeedControlLabelCN = "+/- 加速/减速"
+	SpeedControlLabelEN = "+/- Speed Up/Down"
+
+	LanguageLabelCN = "L 切换语言"
+	LanguageLabelEN = "L Switch Language"
+
+	SpaceControlLabelCN = "Space 暂停"
+	SpaceControlLabelEN = "Space Pause"
+
+	ResetLabelCN = "R 重新播种"
+	ResetLabelEN = "R Reseed"
+
+	QuitLabelCN = "Q 退出"
+	QuitLabelEN = "Q Quit"
+)
+
+// RenderOptions contains rendering configuration with cached styles
+type RenderOptions struct {
+	stateStyled [3]string // Cached styled cells, indexed by CellState
+}
+
+// NewRenderOptions pre-computes the styled string for each cell state
+func NewRenderOptions(cfg Config) RenderOptions {
+	var ro RenderOptions
+	ro.stateStyled[StateOff] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(cfg.OffColor)).Render(cfg.OffChar)
+	ro.stateStyled[StateDying] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(cfg.DyingColor)).Render(cfg.DyingChar)
+	ro.stateStyled[StateOn] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(cfg.OnColor)).Render(cfg.OnChar)
+	return ro
+}
+
+// HeaderLineView returns the header display string
+func (m Model) HeaderLineView() string {
+	style := headerStyle.Width(m.width)
+	if m.language == Chinese {
+		return style.Render(HeaderCN)
+	}
+	return style.Render(HeaderEN)
+}
+
+// StatusLineView returns the status display string
+func (m Model) StatusLineView() string {
+	var status, generationLabel, populationLabel, dyingLabel, boundaryLabel, speedLabel, sizeLabel string
+
+	if m.language == Chinese {
+		status = StatusLabelPlayingCN
+		if m.paused {
+			status = StatusLabelPausedCN
+		}
+		generationLabel = GenerationLabelCN
+		populationLabel = PopulationLabelCN
+		dyingLabel = DyingLabelCN
+		boundaryLabel = BoundaryLabelCN
+		speedLabel = SpeedLabelCN
+		sizeLabel = SizeLabelCN
+	} else {
+		status = StatusLabelPlayingEN
+		if m.paused {
+			status = StatusLabelPausedEN
+		}
+		generationLabel = GenerationLabelEN
+		populationLabel = PopulationLabelEN
+		dyingLabel = DyingLabelEN
+		boundaryLabel = BoundaryLabelEN
+		speedLabel = SpeedLabelEN
+		sizeLabel = SizeLabelEN
+	}
+
+	tableBuilder.Reset()
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(generationLabel, m.brain.GetGeneration())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(populationLabel, m.brain.GetPopulation())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(dyingLabel, m.brain.GetDying())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(boundaryLabel, m.boundary.ToString(m.language))))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(speedLabel, m.refreshRate.String())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sizeLabel, m.gridHeight, m.gridWidth)))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(status))
+
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
+}
+
+// ControlLineView returns the control display string
+func (m Model) ControlLineView() string {
+	var selectBoundary, speedControl, language, space, reset, quit string
+	if m.language == Chinese {
+		selectBoundary = SelectBoundaryLabelCN
+		speedControl = SpeedControlLabelCN
+		language = LanguageLabelCN
+		space = SpaceControlLabelCN
+		reset = ResetLabelCN
+		quit = QuitLabelCN
+	} else {
+		selectBoundary = SelectBoundaryLabelEN
+		speedControl = SpeedControlLabelEN
+		language = LanguageLabelEN
+		space = SpaceControlLabelEN
+		reset = ResetLabelEN
+		quit = QuitLabelEN
+	}
+
+	tableBuilder.Reset()
+	tableBuilder.WriteString(labelStyle.Render(selectBoundary))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(speedControl))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(language))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(space))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(reset))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(quit))
+
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
+}
@@ -9,6 +9,8 @@ type GridRingBuffer struct {
 	writeIndex int
 	startIndex int
 	cols       int // Store column count for consistency checks
+
+	patterns [][]uint8 // Neighbourhood index of each cell, allocated by the first AddPatternRow
 }
 
 // NewGridRingBuffer creates a new ring buffer for grid history with validation
@@ -57,6 +59,13 @@ func (grb *GridRingBuffer) AddRow(row []bool) {
 		grb.buffer[grb.writeIndex][i] = false
 	}
 
+	// Rows added without neighbourhoods have none to show
+	if grb.patterns != nil {
+		for i := range grb.patterns[grb.writeIndex] {
+			grb.patterns[grb.writeIndex][i] = NoPattern
+		}
+	}
+
 	// Update ring buffer indices
 	grb.writeIndex = (grb.writeIndex + 1) % grb.capacity
 	if grb.size < grb.capacity {
@@ -66,6 +75,30 @@ func (grb *GridRingBuffer) AddRow(row []bool) {
 	}
 }
 
+// AddPatternRow adds a new row to the ring buffer together with the
+// neighbourhood index that produced each cell, as from GetCurrentPatterns
+func (grb *GridRingBuffer) AddPatternRow(row []bool, patterns []uint8) {
+	if len(row) == 0 || grb == nil {
+		return
+	}
+
+	idx := grb.writeIndex
+	if idx >= grb.capacity {
+		idx = 0
+	}
+	if grb.patterns == nil {
+		grb.patterns = make([][]uint8, grb.capacity)
+		for i := range grb.patterns {
+			grb.patterns[i] = make([]uint8, grb.cols)
+			for j := range grb.patterns[i] {
+				grb.patterns[i][j] = NoPattern
+			}
+		}
+	}
+	grb.AddRow(row)
+	copy(grb.patterns[idx], patterns)
+}
+
 // GetRows returns all rows in chronological order with safe copying
 func (grb *GridRingBuffer) GetRows() [][]bool {
 	if grb == nil || grb.size == 0 {
@@ -84,6 +117,44 @@ func (grb *GridRingBuffer) GetRows() [][]bool {
 	return result
 }
 
+// GetRow returns a copy of the i-th oldest row (0 = oldest), or nil if i is out of range
+func (grb *GridRingBuffer) GetRow(i int) []bool {
+	if grb == nil || i < 0 || i >= grb.size {
+		return nil
+	}
+
+	idx := (grb.startIndex + i) % grb.capacity
+	if idx >= len(grb.buffer) || grb.buffer[idx] == nil {
+		return nil
+	}
+
+	// Create defensive copy to prevent data races
+	row := make([]bool, len(grb.buffer[idx]))
+	copy(row, grb.buffer[idx])
+	return row
+}
+
+// GetPatternRow returns a copy of the neighbourhoods of the i-th oldest row
+// (0 = oldest), or nil if i is out of range or no neighbourhoods were added
+func (grb *GridRingBuffer) GetPatternRow(i int) []uint8 {
+	if grb == nil || grb.patterns == nil || i < 0 || i >= grb.size {
+		return nil
+	}
+
+	idx := (grb.startIndex + i) % grb.capacity
+	patterns := make([]uint8, len(grb.patterns[idx]))
+	copy(patterns, grb.patterns[idx])
+	return patterns
+}
+
+// Len returns the number of rows currently stored
+func (grb *GridRingBuffer) Len() int {
+	if grb == nil {
+		return 0
+	}
+	return grb.size
+}
+
 // Clear resets the ring buffer to empty state
 func (grb *GridRingBuffer) Clear() {
 	if grb == nil {
@@ -216,6 +216,64 @@ func TestGridRingBuffer_GetRows(t *testing.T) {
 	}
 }
 
+// Test GetRow and Len before and after wraparound
+func TestGridRingBuffer_GetRow(t *testing.T) {
+	grb := NewGridRingBuffer(10, 20)
+	if grb.Len() != 0 || grb.GetRow(0) != nil {
+		t.Error("Expected empty buffer to have no rows")
+	}
+
+	// rowFor marks column n so each row is distinguishable
+	rowFor := func(n int) []bool {
+		row := make([]bool, 20)
+		row[n] = true
+		return row
+	}
+
+	for n := range 4 {
+		grb.AddRow(rowFor(n))
+	}
+	if grb.Len() != 4 {
+		t.Errorf("Expected length 4, got %d", grb.Len())
+	}
+	for i := range 4 {
+		if row := grb.GetRow(i); row == nil || !row[i] {
+			t.Errorf("Expected row %d to be row %d before wraparound", i, i)
+		}
+	}
+
+	// Overflow the capacity: rows 0-4 are dropped, 5-14 remain
+	for n := 4; n < 15; n++ {
+		grb.AddRow(rowFor(n))
+	}
+	if grb.Len() != 10 {
+		t.Errorf("Expected length 10, got %d", grb.Len())
+	}
+	for i := range 10 {
+		row := grb.GetRow(i)
+		if row == nil || !row[i+5] {
+			t.Errorf("Expected row %d to be row %d after wraparound", i, i+5)
+		}
+	}
+
+	for _, i := range []int{-1, 10, 100} {
+		if grb.GetRow(i) != nil {
+			t.Errorf("Expected nil for out-of-range index %d", i)
+		}
+	}
+
+	// Returned rows are copies
+	grb.GetRow(0)[0] = true
+	if grb.GetRow(0)[0] {
+		t.Error("Modifying returned row should not affect the buffer")
+	}
+
+	var nilBuffer *GridRingBuffer
+	if nilBuffer.GetRow(0) != nil || nilBuffer.Len() != 0 {
+		t.Error("Expected nil buffer to have no rows")
+	}
+}
+
 // Test GetRows with nil buffer
 func TestGridRingBuffer_GetRowsNilBuffer(t *testing.T) {
 	var grb *GridRingBuffer
@@ -352,3 +410,50 @@ func BenchmarkGridRingBuffer_AddRowOverflow(b *testing.B) {
 		grb.AddRow(row)
 	}
 }
+
+// Test that neighbourhoods are stored alongside their rows
+func TestGridRingBuffer_PatternRows(t *testing.T) {
+	grb := NewGridRingBuffer(10, 20)
+	row := make([]bool, 20)
+
+	grb.AddRow(row)
+	if grb.GetPatternRow(0) != nil {
+		t.Error("Expected no neighbourhoods before AddPatternRow")
+	}
+
+	patterns := make([]uint8, 20)
+	for i := range patterns {
+		patterns[i] = uint8(i % NoPattern)
+	}
+	grb.AddPatternRow(row, patterns)
+	grb.AddRow(row)
+
+	for i, p := range grb.GetPatternRow(0) {
+		if p != NoPattern {
+			t.Fatalf("Row 0 cell %d: expected NoPattern, got %d", i, p)
+		}
+	}
+	for i, p := range grb.GetPatternRow(1) {
+		if p != patterns[i] {
+			t.Fatalf("Row 1 cell %d: expected %d, got %d", i, patterns[i], p)
+		}
+	}
+	for i, p := range grb.GetPatternRow(2) {
+		if p != NoPattern {
+			t.Fatalf("Row 2 cell %d: expected NoPattern, got %d", i, p)
+		}
+	}
+	if grb.GetPatternRow(3) != nil {
+		t.Error("Expected nil for an out of range row")
+	}
+
+	// Wrapping around reuses the slot of the dropped row
+	for range 10 {
+		grb.AddRow(row)
+	}
+	for i, p := range grb.GetPatternRow(9) {
+		if p != NoPattern {
+			t.Fatalf("Wrapped row cell %d: expected NoPattern, got %d", i, p)
+		}
+	}
+}
@@ -1,6 +1,17 @@
 package main
 
-import "log/slog"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/telepair/go-playground/pkg"
+)
 
 // CellularAutomaton represents a 1D cellular automaton
 type CellularAutomaton struct {
@@ -11,76 +22,134 @@ type CellularAutomaton struct {
 	cols       int
 	boundary   BoundaryType // Boundary condition type
 	ruleTable  [8]bool      // Pre-computed rule table for better performance
+	patterns   []uint8      // Neighbourhood (0-7) that produced each current cell, or NoPattern
+
+	// Totalistic rules (used unless states == 2 and rng == 1)
+	states     int     // Number of cell states
+	rng        int     // Neighbourhood radius
+	cells      []uint8 // Current cell states; currentRow mirrors state != 0
+	nextCells  []uint8
+	totalTable []uint8 // Next state indexed by neighbourhood sum
+
+	// Second-order reversible mode
+	reversible bool    // Combine the rule output with the previous generation
+	prevRow    []bool  // Previous generation (elementary)
+	prevCells  []uint8 // Previous generation (totalistic)
+
+	// Initial row
+	seed        SeedMode
+	seedDensity float64    // Probability of a live cell for SeedRandom
+	seedPattern []bool     // Cells placed at the center for SeedFromString
+	random      *rand.Rand // Random source for SeedRandom, created on first use
+
+	// Spacetime diagram export
+	history    [][]bool // Copies of the most recent rows, at most MaxHistoryRows, as a ring once full
+	historyPos int      // Slot of history overwritten next once it is full, holding the oldest row
+	aliveColor string   // Hex color of live cells in exported images
+	deadColor  string   // Hex color of dead cells in exported images
+
+	// Statistics, updated by Step without rescanning the history
+	population     int   // Live cells in the current row
+	prevPopulation int   // Live cells in the previous row, for Reverse
+	recentLive     []int // Live cell counts of up to EntropyWindow recent generations, as a ring
+	recentPos      int   // Slot of recentLive overwritten next once it is full
+	recentSum      int   // Sum of recentLive
 }
 
-// NewCellularAutomaton creates a new cellular automaton instance
+// NewCellularAutomaton creates a new elementary cellular automaton instance
 func NewCellularAutomaton(rule, cols int, boundary BoundaryType) *CellularAutomaton {
-	slog.Debug("NewCellularAutomaton", "rule", rule, "cols", cols, "boundary", boundary)
-	ca := &CellularAutomaton{}
+	return NewTotalisticAutomaton(rule, DefaultStates, DefaultRange, cols, boundary)
+}
+
+// NewTotalisticAutomaton creates a cellular automaton with the given number of
+// states and neighbourhood radius. Two states with radius 1 is the elementary
+// automaton; anything else uses a totalistic rule.
+func NewTotalisticAutomaton(rule, states, rng, cols int, boundary BoundaryType) *CellularAutomaton {
+	slog.Debug("NewCellularAutomaton", "rule", rule, "states", states, "range", rng, "cols", cols, "boundary", boundary)
+	if states < MinStates || states > MaxStates {
+		states = DefaultStates
+	}
+	if rng < MinRange || rng > MaxRange {
+		rng = DefaultRange
+	}
+	ca := &CellularAutomaton{states: states, rng: rng}
 	ca.Reset(rule, cols, boundary)
 	return ca
 }
 
-// computeRuleTable pre-computes the rule lookup table for better performance
+// isElementary reports whether the automaton uses the elementary fast path
+func (ca *CellularAutomaton) isElementary() bool {
+	return ca.states == DefaultStates && ca.rng == DefaultRange
+}
+
+// computeRuleTable pre-computes the rule lookup table for better performance.
+// Elementary rules map each of the 8 neighbourhoods to a bit of the rule
+// number. Totalistic rules map each neighbourhood sum s to the s-th base-k
+// digit of the rule number.
 func (ca *CellularAutomaton) computeRuleTable() {
-	for i := range 8 {
-		ca.ruleTable[i] = (ca.rule & (1 << i)) != 0
+	if ca.isElementary() {
+		for i := range 8 {
+			ca.ruleTable[i] = (ca.rule & (1 << i)) != 0
+		}
+		return
+	}
+
+	ca.totalTable = make([]uint8, maxSum(ca.states, ca.rng)+1)
+	code := ca.rule
+	for i := range ca.totalTable {
+		ca.totalTable[i] = uint8(code % ca.states) // #nosec G115 - states is at most MaxStates
+		code /= ca.states
 	}
 }
 
 // getNeighbors returns the left and right neighbors for a given cell index
-// Optimized with pre-computed indices to reduce branching
 func (ca *CellularAutomaton) getNeighbors(idx int) (left, right bool) {
 	// Input validation to prevent index out of bounds
 	if idx < 0 || idx >= ca.cols || ca.currentRow == nil {
 		return false, false
 	}
 
+	left, right = ca.edgeNeighbors()
+	if idx > 0 {
+		left = ca.currentRow[idx-1]
+	}
+	if idx < ca.cols-1 {
+		right = ca.currentRow[idx+1]
+	}
+	return left, right
+}
+
+// edgeNeighbors returns the cells the boundary places just beyond the row:
+// the left neighbor of the first cell and the right neighbor of the last
+func (ca *CellularAutomaton) edgeNeighbors() (beforeFirst, afterLast bool) {
 	switch ca.boundary {
 	case BoundaryPeriodic:
-		// Optimized periodic boundary without modulo
-		leftIdx := idx - 1
-		if leftIdx < 0 {
-			leftIdx = ca.cols - 1
-		}
-
-		rightIdx := idx + 1
-		if rightIdx >= ca.cols {
-			rightIdx = 0
-		}
-
-		return ca.currentRow[leftIdx], ca.currentRow[rightIdx]
-
+		return ca.currentRow[ca.cols-1], ca.currentRow[0]
 	case BoundaryReflect:
-		// Reflective boundary: mirror the grid at boundaries
-		leftIdx := idx - 1
-		if leftIdx < 0 {
-			// For left boundary, reflect to position 1 (mirror of -1 around 0)
-			leftIdx = 1
-			if leftIdx >= ca.cols {
-				leftIdx = ca.cols - 1 // Fallback for very small grids
-			}
-		}
-
-		rightIdx := idx + 1
-		if rightIdx >= ca.cols {
-			// For right boundary, reflect to position cols-2 (mirror of cols around cols-1)
-			rightIdx = ca.cols - 2
-			if rightIdx < 0 {
-				rightIdx = 0 // Fallback for very small grids
-			}
-		}
-
-		return ca.currentRow[leftIdx], ca.currentRow[rightIdx]
-
+		// Mirror around the edge cells, falling back to the cell itself on
+		// single-cell rows
+		return ca.currentRow[min(1, ca.cols-1)], ca.currentRow[max(ca.cols-2, 0)]
 	default: // BoundaryFixed
-		// Fixed boundary: return false for out-of-bounds
-		left = idx > 0 && ca.currentRow[idx-1]
-		right = idx < ca.cols-1 && ca.currentRow[idx+1]
-		return left, right
+		return false, false
 	}
 }
 
+// patternOf packs a neighbourhood into its rule table index, from 0 (000) to
+// 7 (111)
+func patternOf(left, center, right bool) uint8 {
+	var pattern uint8
+	if left {
+		pattern |= 4 // Left bit (most significant)
+	}
+	if center {
+		pattern |= 2 // Center bit
+	}
+	if right {
+		pattern |= 1 // Right bit (least significant)
+	}
+	return pattern
+}
+
 // getRuleBit returns the next state for a cell based on its neighborhood
 func (ca *CellularAutomaton) getRuleBit(idx int) bool {
 	// Input validation
@@ -88,78 +157,362 @@ func (ca *CellularAutomaton) getRuleBit(idx int) bool {
 		return false
 	}
 
-	// Get neighbors using the optimized neighbor function
+	// Use pre-computed rule table for better performance
+	return ca.ruleTable[ca.getPattern(idx)]
+}
+
+// getPattern returns the neighbourhood of a cell as an index from 0 (000) to
+// 7 (111), with the left neighbour as the most significant bit
+func (ca *CellularAutomaton) getPattern(idx int) int {
+	if idx < 0 || idx >= ca.cols {
+		return 0
+	}
+
 	left, right := ca.getNeighbors(idx)
-	center := ca.currentRow[idx]
+	return int(patternOf(left, ca.currentRow[idx], right))
+}
 
-	// Convert boolean triplet to integer pattern (000 to 111) using bit operations
-	pattern := 0
-	if left {
-		pattern |= 4 // Left bit (most significant)
+// cellAt returns the state of the cell at idx, which may lie outside the row,
+// according to the boundary condition
+func (ca *CellularAutomaton) cellAt(idx int) uint8 {
+	if idx >= 0 && idx < ca.cols {
+		return ca.cells[idx]
 	}
-	if center {
-		pattern |= 2 // Center bit
+
+	switch ca.boundary {
+	case BoundaryPeriodic:
+		return ca.cells[((idx%ca.cols)+ca.cols)%ca.cols]
+	case BoundaryReflect:
+		// Mirror around the edge cells, as in getNeighbors
+		if idx < 0 {
+			idx = -idx
+		} else {
+			idx = 2*(ca.cols-1) - idx
+		}
+		return ca.cells[max(min(idx, ca.cols-1), 0)]
+	default: // BoundaryFixed
+		return 0
 	}
-	if right {
-		pattern |= 1 // Right bit (least significant)
+}
+
+// getTotalisticState returns the next state for a cell from the sum of the
+// states within radius rng, including the cell itself
+func (ca *CellularAutomaton) getTotalisticState(idx int) uint8 {
+	if idx < 0 || idx >= ca.cols || ca.cells == nil {
+		return 0
 	}
 
-	// Use pre-computed rule table for better performance
-	return ca.ruleTable[pattern]
+	sum := 0
+	for d := -ca.rng; d <= ca.rng; d++ {
+		sum += int(ca.cellAt(idx + d))
+	}
+	return ca.totalTable[sum]
 }
 
-// Step advances the cellular automaton by one generation
-func (ca *CellularAutomaton) Step() bool {
-	// Optimized step with reduced getRuleBit calls
-	// Pre-cache boundary handling for first and last cells
-
-	// Handle first cell
-	ca.nextRow[0] = ca.getRuleBit(0)
-
-	// Handle middle cells with direct neighbor access for better performance
-	for i := 1; i < ca.cols-1; i++ {
-		// For middle cells, we can directly access neighbors without boundary checks
-		left := ca.currentRow[i-1]
-		center := ca.currentRow[i]
-		right := ca.currentRow[i+1]
-
-		// Convert boolean triplet to pattern using bit operations
-		pattern := 0
-		if left {
-			pattern |= 4
-		}
-		if center {
-			pattern |= 2
+// stepTotalistic advances a totalistic automaton by one generation
+func (ca *CellularAutomaton) stepTotalistic() {
+	for i := range ca.cols {
+		ca.nextCells[i] = ca.getTotalisticState(i)
+	}
+	if ca.reversible {
+		// x(t+1) = f(x(t)) - x(t-1) mod k, so x(t-1) = f(x(t)) - x(t+1) mod k
+		k := uint8(ca.states) // #nosec G115 - states is at most MaxStates
+		for i := range ca.cols {
+			ca.nextCells[i] = (ca.nextCells[i] + k - ca.prevCells[i]) % k
 		}
-		if right {
-			pattern |= 1
+		ca.prevCells, ca.cells, ca.nextCells = ca.cells, ca.nextCells, ca.prevCells
+	} else {
+		ca.cells, ca.nextCells = ca.nextCells, ca.cells
+	}
+	live := 0
+	for i, state := range ca.cells {
+		ca.currentRow[i] = state != 0
+		if state != 0 {
+			live++
 		}
+	}
+	ca.setPopulation(live)
+}
 
+// stepElementary computes the next row of an elementary rule into nextRow,
+// recording the neighbourhood of each cell, and returns its live cell count.
+// The edge cells take their outer neighbor from the boundary; every other
+// cell reads both neighbors directly.
+func (ca *CellularAutomaton) stepElementary() int {
+	row, last := ca.currentRow, ca.cols-1
+	beforeFirst, afterLast := ca.edgeNeighbors()
+
+	live := 0
+	apply := func(i int, pattern uint8) {
+		ca.patterns[i] = pattern
 		ca.nextRow[i] = ca.ruleTable[pattern]
+		if ca.nextRow[i] {
+			live++
+		}
 	}
+	if last == 0 {
+		apply(0, patternOf(beforeFirst, row[0], afterLast))
+		return live
+	}
+	apply(0, patternOf(beforeFirst, row[0], row[1]))
+	for i := 1; i < last; i++ {
+		apply(i, patternOf(row[i-1], row[i], row[i+1]))
+	}
+	apply(last, patternOf(row[last-1], row[last], afterLast))
+	return live
+}
 
-	// Handle last cell
-	if ca.cols > 1 {
-		ca.nextRow[ca.cols-1] = ca.getRuleBit(ca.cols - 1)
+// Step advances the cellular automaton by one generation
+func (ca *CellularAutomaton) Step() bool {
+	if !ca.isElementary() {
+		ca.stepTotalistic()
+		ca.generation++
+		ca.recordRow()
+		ca.recordStats()
+		return true
 	}
 
-	// Swap current and next rows for next iteration (more efficient than copying)
-	ca.currentRow, ca.nextRow = ca.nextRow, ca.currentRow
+	live := ca.stepElementary()
+
+	if ca.reversible {
+		// Second-order rule: XOR with the previous generation, then rotate rows
+		live = 0
+		for i := range ca.cols {
+			ca.nextRow[i] = ca.nextRow[i] != ca.prevRow[i]
+			if ca.nextRow[i] {
+				live++
+			}
+		}
+		ca.prevRow, ca.currentRow, ca.nextRow = ca.currentRow, ca.nextRow, ca.prevRow
+	} else {
+		// Swap current and next rows for next iteration (more efficient than copying)
+		ca.currentRow, ca.nextRow = ca.nextRow, ca.currentRow
+	}
 
 	ca.generation++ // Increment generation counter after computing
+	ca.setPopulation(live)
+	ca.recordRow()
+	ca.recordStats()
 	return true
 }
 
+// SetReversible turns the second-order reversible mode on or off and
+// reinitializes the automaton
+func (ca *CellularAutomaton) SetReversible(reversible bool) {
+	ca.reversible = reversible
+	ca.Reset(ca.rule, ca.cols, ca.boundary)
+}
+
+// IsReversible reports whether the second-order reversible mode is on
+func (ca *CellularAutomaton) IsReversible() bool {
+	return ca.reversible
+}
+
+// Reverse swaps the current and previous generations, so that subsequent
+// steps of a reversible automaton run backwards in time
+func (ca *CellularAutomaton) Reverse() {
+	if !ca.reversible {
+		return
+	}
+	ca.population, ca.prevPopulation = ca.prevPopulation, ca.population
+	if ca.isElementary() {
+		ca.prevRow, ca.currentRow = ca.currentRow, ca.prevRow
+		// The neighbourhoods that produced the restored row are not kept
+		ca.clearPatterns()
+		return
+	}
+	ca.prevCells, ca.cells = ca.cells, ca.prevCells
+	for i, state := range ca.cells {
+		ca.currentRow[i] = state != 0
+	}
+}
+
 // GetCurrentRow returns the current row of the cellular automaton
 func (ca *CellularAutomaton) GetCurrentRow() []bool {
 	return ca.currentRow
 }
 
+// GetCurrentStates returns the state of each cell in the current row.
+// It is nil for elementary automata, whose states are GetCurrentRow.
+func (ca *CellularAutomaton) GetCurrentStates() []uint8 {
+	return ca.cells
+}
+
+// GetCurrentPatterns returns, for each cell in the current row, the
+// neighbourhood index (0-7) that produced it in the last step, or NoPattern
+// for cells of the seed row. It is nil for totalistic automata.
+func (ca *CellularAutomaton) GetCurrentPatterns() []uint8 {
+	return ca.patterns
+}
+
+// clearPatterns marks every cell of the current row as not produced by a step
+func (ca *CellularAutomaton) clearPatterns() {
+	for i := range ca.patterns {
+		ca.patterns[i] = NoPattern
+	}
+}
+
+// GetRule returns the active rule number
+func (ca *CellularAutomaton) GetRule() int {
+	return ca.rule
+}
+
+// GetRuleTable returns the next state of the middle cell for each
+// neighbourhood from 0 (000) to 7 (111), and false for totalistic automata,
+// which have no such table
+func (ca *CellularAutomaton) GetRuleTable() ([8]bool, bool) {
+	return ca.ruleTable, ca.isElementary()
+}
+
 // GetGeneration returns the current generation number
 func (ca *CellularAutomaton) GetGeneration() int {
 	return ca.generation
 }
 
+// Describe returns the current state as structured data
+func (ca *CellularAutomaton) Describe() map[string]any {
+	return map[string]any{
+		"generation": ca.generation,
+		"population": ca.population,
+		"density":    ca.Density(),
+		"entropy":    ca.Entropy(),
+		"cols":       ca.cols,
+		"rule":       ca.rule,
+		"states":     ca.states,
+		"range":      ca.rng,
+		"boundary":   ca.boundary.ToString(English),
+		"reversible": ca.reversible,
+	}
+}
+
+// recordRow appends a copy of the current row to the history. Once
+// MaxHistoryRows is reached, the oldest row is overwritten in place instead.
+func (ca *CellularAutomaton) recordRow() {
+	if len(ca.history) < MaxHistoryRows {
+		row := make([]bool, len(ca.currentRow))
+		copy(row, ca.currentRow)
+		ca.history = append(ca.history, row)
+		return
+	}
+
+	row := ca.history[ca.historyPos]
+	if len(row) != len(ca.currentRow) {
+		row = make([]bool, len(ca.currentRow))
+		ca.history[ca.historyPos] = row
+	}
+	copy(row, ca.currentRow)
+	ca.historyPos = (ca.historyPos + 1) % len(ca.history)
+}
+
+// historyRow returns the i-th oldest recorded row (0 = oldest)
+func (ca *CellularAutomaton) historyRow(i int) []bool {
+	return ca.history[(ca.historyPos+i)%len(ca.history)]
+}
+
+// GetHistory returns the recorded rows, oldest first
+func (ca *CellularAutomaton) GetHistory() [][]bool {
+	rows := make([][]bool, len(ca.history))
+	for i := range rows {
+		rows[i] = ca.historyRow(i)
+	}
+	return rows
+}
+
+// SetColors sets the live and dead cell colors used by SaveImage
+func (ca *CellularAutomaton) SetColors(aliveColor, deadColor string) {
+	ca.aliveColor = aliveColor
+	ca.deadColor = deadColor
+}
+
+// ImageFileName returns the default file name for SaveImage, such as
+// "rule30-periodic.ppm"
+func (ca *CellularAutomaton) ImageFileName() string {
+	return fmt.Sprintf("rule%d-%s.ppm", ca.rule, strings.ToLower(ca.boundary.ToString(English)))
+}
+
+// SaveImage writes the recorded generations as a binary PPM image, one
+// generation per image row
+func (ca *CellularAutomaton) SaveImage(path string) error {
+	f, err := os.Create(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to create image file: %w", err)
+	}
+
+	if err := ca.writePPM(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write image %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// writePPM encodes the recorded generations as a binary (P6) PPM image
+func (ca *CellularAutomaton) writePPM(w io.Writer) error {
+	palette := newPalette(ca.aliveColor, ca.deadColor)
+	alive, dead := palette.RGB(pkg.RoleAlive), palette.RGB(pkg.RoleDead)
+
+	bw := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(bw, "P6\n%d %d\n255\n", ca.cols, len(ca.history)); err != nil {
+		return err
+	}
+	for i := range ca.history {
+		for _, cell := range ca.historyRow(i) {
+			pixel := dead
+			if cell {
+				pixel = alive
+			}
+			if _, err := bw.Write(pixel[:]); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// SetSeed selects how the initial row is filled and reinitializes the
+// automaton. The pattern is a binary string such as "0010100" and is only
+// used with SeedFromString.
+func (ca *CellularAutomaton) SetSeed(mode SeedMode, density float64, pattern string) {
+	ca.seed = mode
+	ca.seedDensity = density
+	ca.seedPattern = make([]bool, len(pattern))
+	for i, c := range pattern {
+		ca.seedPattern[i] = c == '1'
+	}
+	ca.Reset(ca.rule, ca.cols, ca.boundary)
+}
+
+// SetRandom sets the random source used by SeedRandom, for reproducible runs
+func (ca *CellularAutomaton) SetRandom(random *rand.Rand) {
+	ca.random = random
+}
+
+// seedRow fills the freshly allocated current row according to the seed mode
+func (ca *CellularAutomaton) seedRow() {
+	switch ca.seed {
+	case SeedRandom:
+		if ca.random == nil {
+			// Use time-based seeding for simulation randomization (not cryptographic)
+			// #nosec G115 - Conversion is safe for our use case
+			seed := uint64(time.Now().UnixNano())
+			// #nosec G404 - Using math/rand for simulation, not cryptography
+			ca.random = rand.New(rand.NewPCG(seed, seed))
+		}
+		for i := range ca.cols {
+			ca.currentRow[i] = ca.random.Float64() < ca.seedDensity
+		}
+	case SeedFromString:
+		// Center the pattern, cropping both ends if it is wider than the row
+		offset := (ca.cols - len(ca.seedPattern)) / 2
+		for i, cell := range ca.seedPattern {
+			if col := offset + i; col >= 0 && col < ca.cols {
+				ca.currentRow[col] = cell
+			}
+		}
+	default: // SeedSingleCenter
+		ca.currentRow[ca.cols/2] = true
+	}
+}
+
 // Reset resets the cellular automaton to its initial state
 func (ca *CellularAutomaton) Reset(rule, cols int, boundary BoundaryType) {
 	slog.Debug("CellularAutomaton Reset", "rule", rule, "cols", cols, "boundary", boundary)
@@ -167,8 +520,8 @@ func (ca *CellularAutomaton) Reset(rule, cols int, boundary BoundaryType) {
 	if cols <= MinCols {
 		cols = DefaultCols
 	}
-	if rule < MinRule || rule > MaxRule {
-		rule = DefaultRule
+	if rule < MinRule || rule > MaxRuleFor(ca.states, ca.rng) {
+		rule = min(DefaultRule, MaxRuleFor(ca.states, ca.rng))
 	}
 
 	ca.rule = rule
@@ -176,9 +529,30 @@ func (ca *CellularAutomaton) Reset(rule, cols int, boundary BoundaryType) {
 	ca.boundary = boundary
 	ca.currentRow = make([]bool, ca.cols)
 	ca.nextRow = make([]bool, ca.cols)
+	ca.prevRow = make([]bool, ca.cols)
 	ca.generation = 0
+	ca.history = ca.history[:0]
+	ca.historyPos = 0
 	ca.computeRuleTable()
 
-	// Initialize with center cell alive
-	ca.currentRow[ca.cols/2] = true
+	// Initialize the first row according to the seed mode
+	ca.seedRow()
+	ca.cells, ca.nextCells, ca.prevCells = nil, nil, nil
+	ca.patterns = nil
+	if ca.isElementary() {
+		ca.patterns = make([]uint8, ca.cols)
+		ca.clearPatterns()
+	} else {
+		ca.cells = make([]uint8, ca.cols)
+		ca.nextCells = make([]uint8, ca.cols)
+		ca.prevCells = make([]uint8, ca.cols)
+		for i, cell := range ca.currentRow {
+			if cell {
+				ca.cells[i] = 1
+			}
+		}
+	}
+	ca.resetStats()
+	ca.recordRow()
+	ca.recordStats()
 }
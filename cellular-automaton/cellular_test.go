@@ -1,7 +1,13 @@
 package main
 
 import (
+	"bytes"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/telepair/go-playground/pkg"
 )
 
 // Test NewCellularAutomaton creation
@@ -273,6 +279,40 @@ func TestCellularAutomaton_GetRuleBit(t *testing.T) {
 	}
 }
 
+// Test that Step records the neighbourhood getRuleBit looked up for each cell
+func TestCellularAutomaton_CurrentPatterns(t *testing.T) {
+	for _, boundary := range []BoundaryType{BoundaryPeriodic, BoundaryFixed, BoundaryReflect} {
+		ca := NewCellularAutomaton(110, 40, boundary)
+		for _, p := range ca.GetCurrentPatterns() {
+			if p != NoPattern {
+				t.Fatalf("Expected no neighbourhoods for the seed row, got %d", p)
+			}
+		}
+
+		for range 10 {
+			expected := make([]uint8, ca.cols)
+			bits := make([]bool, ca.cols)
+			for i := range ca.cols {
+				expected[i] = uint8(ca.getPattern(i))
+				bits[i] = ca.getRuleBit(i)
+			}
+			ca.Step()
+			for i, p := range ca.GetCurrentPatterns() {
+				if p != expected[i] {
+					t.Fatalf("%s: cell %d expected pattern %d, got %d", boundary.ToString(English), i, expected[i], p)
+				}
+				if ca.ruleTable[p] != bits[i] || ca.currentRow[i] != bits[i] {
+					t.Fatalf("%s: cell %d pattern %d does not match getRuleBit", boundary.ToString(English), i, p)
+				}
+			}
+		}
+	}
+
+	if patterns := NewTotalisticAutomaton(10, 3, 1, 40, BoundaryPeriodic).GetCurrentPatterns(); patterns != nil {
+		t.Errorf("Expected no neighbourhoods for a totalistic automaton, got %v", patterns)
+	}
+}
+
 // Test Step functionality
 func TestCellularAutomaton_Step(t *testing.T) {
 	ca := NewCellularAutomaton(30, 5, BoundaryPeriodic)
@@ -429,6 +469,389 @@ func TestCellularAutomaton_InvalidInput(t *testing.T) {
 	}
 }
 
+// Test the rule number range for elementary and totalistic automata
+func TestMaxRuleFor(t *testing.T) {
+	tests := []struct {
+		states, rng, expected int
+	}{
+		{2, 1, 255},  // Elementary
+		{2, 2, 63},   // 6 sums, 2^6 codes
+		{3, 1, 2186}, // 7 sums, 3^7 codes
+		{4, 3, 1<<44 - 1},
+	}
+	for _, tt := range tests {
+		if got := MaxRuleFor(tt.states, tt.rng); got != tt.expected {
+			t.Errorf("MaxRuleFor(%d, %d) = %d, expected %d", tt.states, tt.rng, got, tt.expected)
+		}
+	}
+}
+
+// Test the totalistic rule table holds the base-k digits of the rule
+func TestCellularAutomaton_ComputeTotalisticTable(t *testing.T) {
+	ca := NewTotalisticAutomaton(1635, 3, 1, 80, BoundaryPeriodic)
+	if ca.GetRule() != 1635 {
+		t.Fatalf("Expected rule 1635, got %d", ca.GetRule())
+	}
+
+	// 1635 in base 3 is 2020120
+	expected := []uint8{0, 2, 1, 0, 2, 0, 2}
+	if len(ca.totalTable) != len(expected) {
+		t.Fatalf("Expected table length %d, got %d", len(expected), len(ca.totalTable))
+	}
+	for i, want := range expected {
+		if ca.totalTable[i] != want {
+			t.Errorf("Expected totalTable[%d] = %d, got %d", i, want, ca.totalTable[i])
+		}
+	}
+
+	// Elementary automata keep the fast path
+	elementary := NewCellularAutomaton(30, 80, BoundaryPeriodic)
+	if elementary.GetCurrentStates() != nil || elementary.totalTable != nil {
+		t.Error("Expected elementary automaton to use the boolean rule table only")
+	}
+}
+
+// Test stepping a totalistic rule with a wider neighbourhood
+func TestCellularAutomaton_TotalisticStep(t *testing.T) {
+	// Radius 2 parity rule: alive when the neighbourhood sum is odd (bits 1, 3, 5)
+	ca := NewTotalisticAutomaton(42, 2, 2, 80, BoundaryPeriodic)
+	ca.Step()
+
+	for i, cell := range ca.GetCurrentRow() {
+		expected := i >= 38 && i <= 42
+		if cell != expected {
+			t.Errorf("Cell %d: expected %v, got %v", i, expected, cell)
+		}
+		if (ca.GetCurrentStates()[i] != 0) != cell {
+			t.Errorf("Cell %d: boolean row does not match state %d", i, ca.GetCurrentStates()[i])
+		}
+	}
+
+	// 3-state rule where a sum of 1 gives state 2
+	multi := NewTotalisticAutomaton(1635, 3, 1, 80, BoundaryPeriodic)
+	multi.Step()
+	states := multi.GetCurrentStates()
+	for _, i := range []int{39, 40, 41} {
+		if states[i] != 2 {
+			t.Errorf("Cell %d: expected state 2, got %d", i, states[i])
+		}
+	}
+	if states[38] != 0 || states[42] != 0 {
+		t.Error("Expected cells outside the neighbourhood to stay in state 0")
+	}
+}
+
+// Test totalistic neighbourhood sums at the row edges
+func TestCellularAutomaton_TotalisticBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		boundary BoundaryType
+		expected int // Neighbourhood sum of cell 0 with cells 0 and cols-1 alive
+	}{
+		{"Periodic", BoundaryPeriodic, 2},
+		{"Fixed", BoundaryFixed, 1},
+		{"Reflect", BoundaryReflect, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Rule 2^s maps exactly the sum s to 1 (radius 2 has sums 0-5)
+			ca := NewTotalisticAutomaton(1<<tt.expected, 2, 2, 80, tt.boundary)
+			for i := range ca.cells {
+				ca.cells[i] = 0
+			}
+			ca.cells[0] = 1
+			ca.cells[79] = 1
+			if ca.getTotalisticState(0) != 1 {
+				t.Errorf("Expected neighbourhood sum %d at cell 0", tt.expected)
+			}
+		})
+	}
+
+	// Reflection mirrors cell 1 and 2 onto the left of cell 0
+	ca := NewTotalisticAutomaton(1<<4, 2, 2, 80, BoundaryReflect)
+	ca.cells[40] = 0
+	ca.cells[1] = 1
+	ca.cells[2] = 1
+	if ca.getTotalisticState(0) != 1 {
+		t.Error("Expected reflected neighbourhood sum 4 at cell 0")
+	}
+}
+
+// Test validation of states, range, and totalistic rule numbers
+func TestConfig_CheckTotalistic(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.States = 3
+	cfg.Rule = 1635
+	cfg.Check()
+	if cfg.Rule != 1635 {
+		t.Errorf("Expected valid totalistic rule to be kept, got %d", cfg.Rule)
+	}
+
+	cfg = DefaultConfig
+	cfg.States = 9
+	cfg.Range = 0
+	cfg.Rule = 1000
+	cfg.Check()
+	if cfg.States != DefaultStates || cfg.Range != DefaultRange || cfg.Rule != DefaultRule {
+		t.Errorf("Expected defaults, got states %d, range %d, rule %d", cfg.States, cfg.Range, cfg.Rule)
+	}
+
+	// Radius 2 binary rules only go up to 63
+	cfg = DefaultConfig
+	cfg.Range = 2
+	cfg.Rule = 90
+	cfg.Check()
+	if cfg.Rule != DefaultRule {
+		t.Errorf("Expected rule %d, got %d", DefaultRule, cfg.Rule)
+	}
+}
+
+// Test random seeding with a seeded random source for determinism
+func TestCellularAutomaton_SeedRandom(t *testing.T) {
+	newSeeded := func() *CellularAutomaton {
+		ca := NewCellularAutomaton(30, 1000, BoundaryPeriodic)
+		ca.SetRandom(rand.New(rand.NewPCG(1, 2)))
+		ca.SetSeed(SeedRandom, 0.3, "")
+		return ca
+	}
+
+	ca := newSeeded()
+	alive := 0
+	for _, cell := range ca.GetCurrentRow() {
+		if cell {
+			alive++
+		}
+	}
+	if alive < 250 || alive > 350 {
+		t.Errorf("Expected about 300 live cells at density 0.3, got %d", alive)
+	}
+
+	// The same random source produces the same row
+	other := newSeeded()
+	for i, cell := range ca.GetCurrentRow() {
+		if other.GetCurrentRow()[i] != cell {
+			t.Fatalf("Cell %d differs between identically seeded automata", i)
+		}
+	}
+
+	// Density bounds
+	for _, density := range []float64{0, 1} {
+		ca.SetSeed(SeedRandom, density, "")
+		for i, cell := range ca.GetCurrentRow() {
+			if cell != (density == 1) {
+				t.Fatalf("Density %g: unexpected cell %d = %v", density, i, cell)
+			}
+		}
+	}
+}
+
+// Test seeding from a binary string centered on the row
+func TestCellularAutomaton_SeedFromString(t *testing.T) {
+	ca := NewCellularAutomaton(90, 80, BoundaryPeriodic)
+	ca.SetSeed(SeedFromString, 0, "0010100")
+
+	// 7 cells centered on 80 columns start at column 36
+	for i, cell := range ca.GetCurrentRow() {
+		expected := i == 38 || i == 40
+		if cell != expected {
+			t.Errorf("Cell %d: expected %v, got %v", i, expected, cell)
+		}
+	}
+
+	// Reset keeps the seed
+	ca.Step()
+	ca.Reset(90, 80, BoundaryFixed)
+	if !ca.GetCurrentRow()[38] || !ca.GetCurrentRow()[40] || ca.GetCurrentRow()[39] {
+		t.Error("Expected Reset to reapply the seed pattern")
+	}
+
+	// Totalistic automata start from the same cells in state 1
+	multi := NewTotalisticAutomaton(1635, 3, 1, 80, BoundaryPeriodic)
+	multi.SetSeed(SeedFromString, 0, "101")
+	if multi.GetCurrentStates()[38] != 1 || multi.GetCurrentStates()[39] != 0 || multi.GetCurrentStates()[40] != 1 {
+		t.Error("Expected totalistic states to follow the seed pattern")
+	}
+}
+
+// Test seed option parsing and validation
+func TestConfig_Seed(t *testing.T) {
+	tests := []struct {
+		input    string
+		density  float64
+		mode     SeedMode
+		pattern  string
+		expected float64
+	}{
+		{"center", 0.5, SeedSingleCenter, "", 0.5},
+		{"Random", 0.2, SeedRandom, "", 0.2},
+		{"random", 1.5, SeedRandom, "", DefaultSeedDensity},
+		{"0010100", 0.5, SeedFromString, "0010100", 0.5},
+		{"0012", 0.5, SeedSingleCenter, "", 0.5},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig
+		cfg.SeedDensity = tt.density
+		cfg.SetSeed(tt.input)
+		cfg.Check()
+		if cfg.Seed != tt.mode || cfg.SeedPattern != tt.pattern || cfg.SeedDensity != tt.expected {
+			t.Errorf("Seed %q: got mode %v, pattern %q, density %g", tt.input, cfg.Seed, cfg.SeedPattern, cfg.SeedDensity)
+		}
+	}
+}
+
+// Test exporting the generation history as a PPM image
+func TestCellularAutomaton_SaveImage(t *testing.T) {
+	ca := NewCellularAutomaton(90, 80, BoundaryFixed)
+	ca.SetColors("#FF8000", "#000010")
+	for range 4 {
+		ca.Step()
+	}
+	if len(ca.GetHistory()) != 5 {
+		t.Fatalf("Expected 5 recorded rows, got %d", len(ca.GetHistory()))
+	}
+	if ca.ImageFileName() != "rule90-fixed.ppm" {
+		t.Errorf("Unexpected image file name %s", ca.ImageFileName())
+	}
+
+	path := filepath.Join(t.TempDir(), ca.ImageFileName())
+	if err := ca.SaveImage(path); err != nil {
+		t.Fatalf("SaveImage failed: %v", err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		t.Fatalf("Failed to read image: %v", err)
+	}
+
+	header := "P6\n80 5\n255\n"
+	if !bytes.HasPrefix(data, []byte(header)) {
+		t.Fatalf("Unexpected PPM header %q", data[:min(len(data), 20)])
+	}
+	pixels := data[len(header):]
+	if len(pixels) != 80*5*3 {
+		t.Fatalf("Expected %d bytes of pixel data, got %d", 80*5*3, len(pixels))
+	}
+	// First row has only the center cell alive
+	if !bytes.Equal(pixels[40*3:40*3+3], []byte{0xFF, 0x80, 0x00}) {
+		t.Errorf("Expected alive color at center, got %v", pixels[40*3:40*3+3])
+	}
+	if !bytes.Equal(pixels[0:3], []byte{0x00, 0x00, 0x10}) {
+		t.Errorf("Expected dead color at edge, got %v", pixels[0:3])
+	}
+
+	if err := ca.SaveImage(filepath.Join(t.TempDir(), "missing", "image.ppm")); err == nil {
+		t.Error("Expected error when saving to a missing directory")
+	}
+}
+
+// Test the history keeps only the most recent rows and clears on reset
+func TestCellularAutomaton_HistoryLimit(t *testing.T) {
+	ca := NewCellularAutomaton(30, 80, BoundaryPeriodic)
+	rows := [][]bool{append([]bool(nil), ca.GetCurrentRow()...)}
+	for range MaxHistoryRows + 10 {
+		ca.Step()
+		rows = append(rows, append([]bool(nil), ca.GetCurrentRow()...))
+	}
+	history := ca.GetHistory()
+	if len(history) != MaxHistoryRows {
+		t.Fatalf("Expected %d rows, got %d", MaxHistoryRows, len(history))
+	}
+	rows = rows[len(rows)-MaxHistoryRows:]
+	for y := range history {
+		for x := range history[y] {
+			if history[y][x] != rows[y][x] {
+				t.Fatalf("Expected history row %d to be generation %d", y, y+11)
+			}
+		}
+	}
+
+	ca.Reset(30, 80, BoundaryPeriodic)
+	if len(ca.GetHistory()) != 1 {
+		t.Errorf("Expected only the initial row after reset, got %d", len(ca.GetHistory()))
+	}
+}
+
+// Test that reversible automata return to their seed when run backwards
+func TestCellularAutomaton_Reversible(t *testing.T) {
+	tests := []struct {
+		name   string
+		ca     *CellularAutomaton
+		states int
+	}{
+		{"Elementary rule 30", NewCellularAutomaton(30, 80, BoundaryPeriodic), 2},
+		{"Elementary rule 110 fixed", NewCellularAutomaton(110, 80, BoundaryFixed), 2},
+		{"Totalistic 3-state", NewTotalisticAutomaton(1635, 3, 1, 80, BoundaryReflect), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := tt.ca
+			ca.SetSeed(SeedFromString, 0, "0110100111")
+			ca.SetReversible(true)
+			if !ca.IsReversible() {
+				t.Fatal("Expected reversible mode to be on")
+			}
+
+			seed := make([]bool, len(ca.GetCurrentRow()))
+			copy(seed, ca.GetCurrentRow())
+
+			const steps = 50
+			for range steps {
+				ca.Step()
+			}
+			ca.Reverse()
+			for range steps {
+				ca.Step()
+			}
+			ca.Reverse()
+
+			for i, cell := range ca.GetCurrentRow() {
+				if cell != seed[i] {
+					t.Fatalf("Cell %d: expected %v after running backwards, got %v", i, seed[i], cell)
+				}
+			}
+			if tt.states > 2 {
+				for i, state := range ca.GetCurrentStates() {
+					if (state != 0) != seed[i] || state > 1 {
+						t.Fatalf("Cell %d: expected seed state, got %d", i, state)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Test that reversible mode differs from the plain rule
+func TestCellularAutomaton_ReversibleStep(t *testing.T) {
+	ca := NewCellularAutomaton(90, 80, BoundaryPeriodic)
+	ca.SetReversible(true)
+
+	// Gen 1 matches rule 90 since the previous generation is empty
+	ca.Step()
+	row := ca.GetCurrentRow()
+	if !row[39] || row[40] || !row[41] {
+		t.Error("Expected first reversible step to match rule 90")
+	}
+
+	// Gen 2: rule 90 gives 38 and 42; XOR with gen 0 revives the center
+	ca.Step()
+	row = ca.GetCurrentRow()
+	if !row[38] || row[39] || !row[40] || row[41] || !row[42] {
+		t.Errorf("Expected cells 38, 40 and 42 alive, got %v", row[38:43])
+	}
+
+	// Turning the mode off reinitializes the automaton
+	ca.SetReversible(false)
+	if ca.GetGeneration() != 0 || ca.IsReversible() {
+		t.Error("Expected SetReversible to reset the automaton")
+	}
+	ca.Reverse() // No-op outside reversible mode
+	if !ca.GetCurrentRow()[40] {
+		t.Error("Reverse should not change a non-reversible automaton")
+	}
+}
+
 // Benchmark tests
 func BenchmarkNewCellularAutomaton(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -509,6 +932,24 @@ func BenchmarkCellularAutomaton_StepReflect(b *testing.B) {
 	}
 }
 
+// BenchmarkCellularAutomaton_StepElementarySmall computes the next row of the
+// narrowest automaton, where the two edge cells are the largest share of the
+// row. Step itself also records history and statistics, which dominate at
+// this size.
+func BenchmarkCellularAutomaton_StepElementarySmall(b *testing.B) {
+	for _, boundary := range []BoundaryType{BoundaryPeriodic, BoundaryFixed, BoundaryReflect} {
+		b.Run(boundary.ToString(English), func(b *testing.B) {
+			ca := NewCellularAutomaton(30, MinCols, boundary)
+			ca.SetSeed(SeedRandom, 0.5, "")
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ca.stepElementary()
+			}
+		})
+	}
+}
+
 // Benchmark different rules
 func BenchmarkCellularAutomaton_StepRule30(b *testing.B) {
 	ca := NewCellularAutomaton(30, 80, BoundaryPeriodic)
@@ -527,3 +968,34 @@ func BenchmarkCellularAutomaton_StepRule110(b *testing.B) {
 		ca.Step()
 	}
 }
+
+func BenchmarkCellularAutomaton_StepTotalistic(b *testing.B) {
+	ca := NewTotalisticAutomaton(1635, 3, 2, 1000, BoundaryPeriodic)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ca.Step()
+	}
+}
+
+// Test the structured state snapshot
+func TestCellularAutomaton_Describe(t *testing.T) {
+	var d pkg.Describable = NewCellularAutomaton(90, 41, BoundaryFixed)
+	ca := d.(*CellularAutomaton)
+	ca.Step()
+
+	desc := d.Describe()
+	want := map[string]any{
+		"generation": 1,
+		"population": 2, // Rule 90 splits the center cell in two
+		"cols":       41,
+		"rule":       90,
+		"boundary":   "Fixed",
+		"reversible": false,
+	}
+	for key, value := range want {
+		if desc[key] != value {
+			t.Errorf("Describe()[%q] = %v, want %v", key, desc[key], value)
+		}
+	}
+}
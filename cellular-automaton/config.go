@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/telepair/go-playground/pkg"
 )
 
 // BoundaryType represents the boundary type of the cellular automaton
@@ -40,6 +43,36 @@ func (bt BoundaryType) ToString(language Language) string {
 	return "Periodic"
 }
 
+// SeedMode represents how the initial row is filled
+type SeedMode int
+
+// SeedMode constants
+const (
+	SeedSingleCenter SeedMode = iota // Single live cell at the center (default)
+	SeedRandom                       // Each cell alive with probability SeedDensity
+	SeedFromString                   // Binary string such as "0010100" centered on the row
+)
+
+// ToString returns the string representation of seed mode
+func (sm SeedMode) ToString(language Language) string {
+	switch sm {
+	case SeedRandom:
+		if language == Chinese {
+			return "随机"
+		}
+		return "random"
+	case SeedFromString:
+		if language == Chinese {
+			return "自定义"
+		}
+		return "string"
+	}
+	if language == Chinese {
+		return "中心"
+	}
+	return "center"
+}
+
 // Language represents the supported languages
 type Language int
 
@@ -79,15 +112,38 @@ const (
 	// Rule validation
 	DefaultRule = 30  // Default cellular automaton rule
 	MinRule     = 0   // Minimum rule number
-	MaxRule     = 255 // Maximum rule number
+	MaxRule     = 255 // Maximum rule number (elementary rules)
+
+	// Totalistic rule validation
+	DefaultStates = 2 // Default number of cell states (elementary)
+	MinStates     = 2 // Minimum number of cell states
+	MaxStates     = 4 // Maximum number of cell states
+	DefaultRange  = 1 // Default neighbourhood radius (elementary)
+	MinRange      = 1 // Minimum neighbourhood radius
+	MaxRange      = 3 // Maximum neighbourhood radius
+
+	// Image export
+	MaxHistoryRows = 4096 // Maximum generations kept for SaveImage
+
+	// Scrollback
+	DefaultScrollback = 500            // Default generations kept for scrolling back
+	MaxScrollback     = MaxHistoryRows // Maximum generations kept for scrolling back
+
+	// Statistics
+	EntropyWindow = 64 // Generations averaged by the rolling entropy estimate
+
+	// Neighbourhood coloring
+	NoPattern = 8 // Neighbourhood index of cells not produced by an elementary rule, such as the seed row
 
 	// Timing constants
 	DefaultRefreshRate = 200 * time.Millisecond // Default refresh rate in milliseconds
 	MinRefreshRate     = 10 * time.Millisecond  // Minimum refresh rate in milliseconds
 
 	// Default values
-	DefaultLanguage = English          // Default language
-	DefaultBoundary = BoundaryPeriodic // Default boundary type
+	DefaultLanguage    = English          // Default language
+	DefaultBoundary    = BoundaryPeriodic // Default boundary type
+	DefaultSeed        = SeedSingleCenter // Default initial row
+	DefaultSeedDensity = 0.5              // Default live cell probability for random seeds
 
 	// Colors
 	DefaultAliveColor = "#FFFFFF" // Default alive cell color
@@ -105,22 +161,35 @@ const (
 
 // DefaultConfig is the default configuration
 var DefaultConfig = Config{
-	Rule:       DefaultRule,
-	AliveColor: DefaultAliveColor,
-	DeadColor:  DefaultDeadColor,
-	AliveChar:  DefaultAliveChar,
-	DeadChar:   DefaultDeadChar,
-	Language:   DefaultLanguage,
+	Rule:        DefaultRule,
+	States:      DefaultStates,
+	Range:       DefaultRange,
+	Seed:        DefaultSeed,
+	SeedDensity: DefaultSeedDensity,
+	AliveColor:  DefaultAliveColor,
+	DeadColor:   DefaultDeadColor,
+	AliveChar:   DefaultAliveChar,
+	DeadChar:    DefaultDeadChar,
+	Language:    DefaultLanguage,
+	Scrollback:  DefaultScrollback,
 }
 
 // Config holds all application configuration
 type Config struct {
-	Rule       int
-	AliveColor string
-	DeadColor  string
-	AliveChar  string
-	DeadChar   string
-	Language   Language
+	Rule        int
+	States      int // Number of cell states; more than 2 selects a totalistic rule
+	Range       int // Neighbourhood radius; more than 1 selects a totalistic rule
+	Seed        SeedMode
+	SeedDensity float64 // Live cell probability for SeedRandom
+	SeedPattern string  // Binary string for SeedFromString
+	Reversible  bool    // Second-order reversible mode
+	AliveColor  string
+	DeadColor   string
+	AliveChar   string
+	DeadChar    string
+	Rules       []RulePreset // User rules, merged into the built-in T key cycle
+	Language    Language
+	Scrollback  int // Generations kept for scrolling back; the visible rows are always kept
 }
 
 // SetLang sets the language
@@ -132,42 +201,87 @@ func (c *Config) SetLang(lang string) {
 	}
 }
 
+// SetSeed sets the seed mode from "center", "random", or a binary string
+func (c *Config) SetSeed(seed string) {
+	switch strings.ToLower(seed) {
+	case "", "center":
+		c.Seed = SeedSingleCenter
+	case "random":
+		c.Seed = SeedRandom
+	default:
+		c.Seed = SeedFromString
+		c.SeedPattern = seed
+	}
+}
+
 // Check validates the configuration
 func (c *Config) Check() {
-	if c.Rule < MinRule || c.Rule > MaxRule {
-		fmt.Printf("invalid rule %d, must be between %d and %d, using default rule %d\n", c.Rule, MinRule, MaxRule, DefaultRule)
-		c.Rule = DefaultRule
+	if c.States < MinStates || c.States > MaxStates {
+		fmt.Printf("invalid states %d, must be between %d and %d, using default states %d\n", c.States, MinStates, MaxStates, DefaultStates)
+		c.States = DefaultStates
+	}
+	if c.Range < MinRange || c.Range > MaxRange {
+		fmt.Printf("invalid range %d, must be between %d and %d, using default range %d\n", c.Range, MinRange, MaxRange, DefaultRange)
+		c.Range = DefaultRange
+	}
+	if maxRule := MaxRuleFor(c.States, c.Range); c.Rule < MinRule || c.Rule > maxRule {
+		fmt.Printf("invalid rule %d, must be between %d and %d, using default rule %d\n", c.Rule, MinRule, maxRule, min(DefaultRule, maxRule))
+		c.Rule = min(DefaultRule, maxRule)
 	}
 
-	if c.Language != English && c.Language != Chinese {
-		fmt.Printf("invalid language %s, must be en or cn, using default language %s\n", c.Language.ToString(c.Language), DefaultLanguage.ToString(c.Language))
-		c.Language = DefaultLanguage
+	if c.Seed == SeedRandom && (c.SeedDensity <= 0 || c.SeedDensity > 1) {
+		fmt.Printf("invalid seed density %g, must be in (0, 1], using default density %g\n", c.SeedDensity, DefaultSeedDensity)
+		c.SeedDensity = DefaultSeedDensity
 	}
-	if !isValidHexColor(c.AliveColor) {
-		fmt.Printf("invalid alive color format: %s, using default\n", c.AliveColor)
-		c.AliveColor = DefaultAliveColor
+	if c.Seed == SeedFromString && !isValidSeedPattern(c.SeedPattern) {
+		fmt.Printf("invalid seed %q, must be center, random, or a string of 0s and 1s, using default seed %s\n", c.SeedPattern, DefaultSeed.ToString(English))
+		c.Seed = DefaultSeed
+		c.SeedPattern = ""
 	}
-	if !isValidHexColor(c.DeadColor) {
-		fmt.Printf("invalid dead color format: %s, using default\n", c.DeadColor)
-		c.DeadColor = DefaultDeadColor
+
+	if c.Scrollback < 0 || c.Scrollback > MaxScrollback {
+		fmt.Printf("invalid scrollback %d, must be between 0 and %d, using default scrollback %d\n", c.Scrollback, MaxScrollback, DefaultScrollback)
+		c.Scrollback = DefaultScrollback
+	}
+
+	for _, msg := range pkg.Validate(
+		pkg.HexColor("alive", &c.AliveColor, DefaultAliveColor),
+		pkg.HexColor("dead", &c.DeadColor, DefaultDeadColor),
+		pkg.Char("alive", &c.AliveChar, DefaultAliveChar),
+		pkg.Char("dead", &c.DeadChar, DefaultDeadChar),
+		pkg.Language(&c.Language, DefaultLanguage),
+	) {
+		fmt.Println(msg)
 	}
-	if len([]rune(c.AliveChar)) != 1 {
-		fmt.Printf("invalid alive character format: %s, using default\n", c.AliveChar)
-		c.AliveChar = DefaultAliveChar
+}
+
+// maxSum returns the largest neighbourhood sum of a totalistic rule: 2r+1
+// cells, each in a state up to k-1
+func maxSum(states, rng int) int {
+	return (2*rng + 1) * (states - 1)
+}
+
+// MaxRuleFor returns the largest rule number for the given number of states
+// and neighbourhood radius. Elementary rules (2 states, radius 1) go up to
+// 255; totalistic rules have one base-k digit per neighbourhood sum.
+func MaxRuleFor(states, rng int) int {
+	if states == DefaultStates && rng == DefaultRange {
+		return MaxRule
 	}
-	if len([]rune(c.DeadChar)) != 1 {
-		fmt.Printf("invalid dead character format: %s, using default\n", c.DeadChar)
-		c.DeadChar = DefaultDeadChar
+	n := 1
+	for range maxSum(states, rng) + 1 {
+		n *= states
 	}
+	return n - 1
 }
 
-// isValidHexColor checks if a string is a valid hex color
-func isValidHexColor(color string) bool {
-	if len(color) != 7 || color[0] != '#' {
+// isValidSeedPattern checks if a string is a non-empty string of 0s and 1s
+func isValidSeedPattern(pattern string) bool {
+	if pattern == "" {
 		return false
 	}
-	for _, c := range color[1:] {
-		if (c < '0' || c > '9') && (c < 'A' || c > 'F') && (c < 'a' || c > 'f') {
+	for _, c := range pattern {
+		if c != '0' && c != '1' {
 			return false
 		}
 	}
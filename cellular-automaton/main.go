@@ -22,15 +22,24 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -rule 30                         # Run Rule 30 (Random) with auto-detected size\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -rule 90                         # Run Rule 90 (Sierpinski Triangle) with auto-detected size\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -rule 110                        # Run Rule 110 (Turing Machine) with auto-detected size\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -rule 110 -seed random             # Run Rule 110 from a random initial row\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -rule 1635 -states 3              # Run 3-state totalistic code 1635\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -rule 184 -alive-char '🚗'        # Run Rule 184 (Traffic Simulation) with custom alive character\n", os.Args[0])
 	}
 
 	// Parse command line flags
-	var rule = flag.Int("rule", DefaultRule, "Cellular automaton rule number (0-255)")
+	var rule = flag.Int("rule", DefaultRule, "Cellular automaton rule number (0-255, or a totalistic code with -states/-range)")
+	var states = flag.Int("states", DefaultStates, "Number of cell states (2-4); more than 2 uses a totalistic rule")
+	var rng = flag.Int("range", DefaultRange, "Neighbourhood radius (1-3); more than 1 uses a totalistic rule")
+	var seed = flag.String("seed", DefaultSeed.ToString(English), "Initial row: center, random, or a binary string such as 0010100")
+	var seedDensity = flag.Float64("seed-density", DefaultSeedDensity, "Live cell probability for -seed random (0-1)")
+	var reversible = flag.Bool("reversible", false, "Second-order reversible mode: combine each cell with its previous generation")
+	var scrollback = flag.Int("scrollback", DefaultScrollback, "Generations kept for scrolling back with PgUp/PgDn")
 	var aliveColor = flag.String("alive-color", DefaultAliveColor, "Alive cell color (hex)")
 	var deadColor = flag.String("dead-color", DefaultDeadColor, "Dead cell color (hex)")
 	var aliveChar = flag.String("alive-char", DefaultAliveChar, "Alive cell character")
 	var deadChar = flag.String("dead-char", DefaultDeadChar, "Dead cell character")
+	var rulesFile = flag.String("rules-file", "", "JSON file with rules to cycle with T, each with optional name, colors and characters")
 	var lang = flag.String("lang", DefaultLanguage.ToString(DefaultLanguage), "Language (en/cn)")
 	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
 	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
@@ -40,7 +49,7 @@ func main() {
 	flag.Parse()
 
 	if *logFile != "" {
-		_ = pkg.InitLog("debug", "text", *logFile)
+		_, _ = pkg.InitLog("debug", "text", *logFile)
 	}
 	slog.Debug("Cellular Automaton starting")
 
@@ -54,15 +63,31 @@ func main() {
 
 	// Create and configure application
 	config := Config{
-		Rule:       *rule,
-		AliveColor: *aliveColor,
-		DeadColor:  *deadColor,
-		AliveChar:  *aliveChar,
-		DeadChar:   *deadChar,
+		Rule:        *rule,
+		States:      *states,
+		Range:       *rng,
+		SeedDensity: *seedDensity,
+		Reversible:  *reversible,
+		AliveColor:  *aliveColor,
+		DeadColor:   *deadColor,
+		AliveChar:   *aliveChar,
+		DeadChar:    *deadChar,
+		Scrollback:  *scrollback,
+	}
+	if *rulesFile != "" {
+		rules, err := LoadRulePresets(*rulesFile)
+		if err != nil {
+			fmt.Printf("failed to load rules: %v, using built-in rules only\n", err)
+		}
+		config.Rules = rules
 	}
 	config.SetLang(*lang)
+	config.SetSeed(*seed)
 	config.Check()
 
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
 	// Create initial model
 	initialModel := NewModel(config)
 
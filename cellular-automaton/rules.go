@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/telepair/go-playground/pkg"
+)
+
+// RulePreset is a rule in the T key cycle, with optional appearance that
+// overrides the configured colors and characters while the rule is active
+type RulePreset struct {
+	Rule       int    `json:"rule"`
+	Name       string `json:"name,omitempty"`
+	AliveColor string `json:"aliveColor,omitempty"`
+	DeadColor  string `json:"deadColor,omitempty"`
+	AliveChar  string `json:"aliveChar,omitempty"`
+	DeadChar   string `json:"deadChar,omitempty"`
+}
+
+// builtinRules are the rules cycled by the T key when no rules file is loaded
+var builtinRules = []RulePreset{
+	{Rule: 30},
+	{Rule: 90},
+	{Rule: 110},
+	{Rule: 154},
+	{Rule: 184},
+}
+
+// LoadRulePresets reads rules from a JSON file holding an array of objects
+// with a rule number and optional name, aliveColor, deadColor, aliveChar and
+// deadChar fields. Entries without a valid rule are skipped and invalid
+// colors or characters are dropped, both with a logged warning; an error is
+// returned only if the file cannot be read or is not a JSON array.
+func LoadRulePresets(path string) ([]RulePreset, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse rules %s: %w", path, err)
+	}
+
+	rules := make([]RulePreset, 0, len(entries))
+	for i, entry := range entries {
+		var required struct {
+			Rule *int `json:"rule"`
+		}
+		var r RulePreset
+		if err := json.Unmarshal(entry, &r); err != nil {
+			slog.Warn("Skipping malformed rule", "file", path, "index", i, "error", err)
+			continue
+		}
+		if err := json.Unmarshal(entry, &required); err != nil || required.Rule == nil {
+			slog.Warn("Skipping rule without a rule number", "file", path, "index", i)
+			continue
+		}
+		if r.Rule < MinRule {
+			slog.Warn("Skipping invalid rule", "file", path, "index", i, "rule", r.Rule)
+			continue
+		}
+		for _, err := range r.sanitize() {
+			slog.Warn("Ignoring invalid rule field", "file", path, "index", i, "error", err)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// sanitize clears invalid colors and characters, so the configured ones are
+// used instead, and returns the problems found
+func (r *RulePreset) sanitize() []error {
+	var errs []error
+	for _, color := range []*string{&r.AliveColor, &r.DeadColor} {
+		if *color != "" && !pkg.IsValidHexColor(*color) {
+			errs = append(errs, fmt.Errorf("rule %d: invalid color %q", r.Rule, *color))
+			*color = ""
+		}
+	}
+	for _, char := range []*string{&r.AliveChar, &r.DeadChar} {
+		if *char != "" && len([]rune(*char)) != 1 {
+			errs = append(errs, fmt.Errorf("rule %d: invalid character %q", r.Rule, *char))
+			*char = ""
+		}
+	}
+	return errs
+}
+
+// withDefaults fills the appearance fields left empty from base
+func (r RulePreset) withDefaults(base RulePreset) RulePreset {
+	if r.AliveColor == "" {
+		r.AliveColor = base.AliveColor
+	}
+	if r.DeadColor == "" {
+		r.DeadColor = base.DeadColor
+	}
+	if r.AliveChar == "" {
+		r.AliveChar = base.AliveChar
+	}
+	if r.DeadChar == "" {
+		r.DeadChar = base.DeadChar
+	}
+	return r
+}
+
+// mergeRules returns the built-in rules with user rules applied: a user rule
+// with the number of a built-in one replaces it in place, the others are
+// appended in file order
+func mergeRules(builtin, user []RulePreset) []RulePreset {
+	rules := append([]RulePreset(nil), builtin...)
+	for _, u := range user {
+		if i := findRule(rules, u.Rule); i >= 0 {
+			rules[i] = u
+		} else {
+			rules = append(rules, u)
+		}
+	}
+	return rules
+}
+
+// findRule returns the index of the rule in rules, or -1
+func findRule(rules []RulePreset, rule int) int {
+	for i, r := range rules {
+		if r.Rule == rule {
+			return i
+		}
+	}
+	return -1
+}
+
+// nextRule returns the rule after the given one in the cycle, skipping rules
+// above maxRule. A rule outside the cycle is followed by the first one.
+func nextRule(rules []RulePreset, rule, maxRule int) int {
+	start := findRule(rules, rule)
+	for i := 1; i <= len(rules); i++ {
+		if r := rules[(start+i)%len(rules)]; r.Rule <= maxRule {
+			return r.Rule
+		}
+	}
+	return min(DefaultRule, maxRule)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// writeRules writes content to a rules file in a temporary directory
+func writeRules(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+	return path
+}
+
+// Test that valid entries are loaded, invalid fields dropped and bad entries skipped
+func TestLoadRulePresets(t *testing.T) {
+	path := writeRules(t, `[
+		{"rule": 30, "name": "Chaos", "aliveColor": "#FF8800", "aliveChar": "●"},
+		{"rule": 45, "deadColor": "orange", "deadChar": "ab"},
+		{"rule": 0},
+		{"name": "No Rule"},
+		{"rule": -1},
+		{"rule": "ninety"},
+		42
+	]`)
+
+	rules, err := LoadRulePresets(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []RulePreset{
+		{Rule: 30, Name: "Chaos", AliveColor: "#FF8800", AliveChar: "●"},
+		{Rule: 45},
+		{Rule: 0},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("Expected rules %v, got %v", expected, rules)
+	}
+}
+
+// Test that unreadable or non-array files are reported
+func TestLoadRulePresetsErrors(t *testing.T) {
+	if _, err := LoadRulePresets(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+	if _, err := LoadRulePresets(writeRules(t, `{"rule": 30}`)); err == nil {
+		t.Error("Expected error for a file that is not a JSON array")
+	}
+}
+
+// Test that user rules replace built-in ones with the same number and extend the cycle
+func TestMergeRulesAndNextRule(t *testing.T) {
+	rules := mergeRules(builtinRules, []RulePreset{{Rule: 90, Name: "Sierpinski"}, {Rule: 1635}, {Rule: 60}})
+	numbers := make([]int, len(rules))
+	for i, r := range rules {
+		numbers[i] = r.Rule
+	}
+	if want := []int{30, 90, 110, 154, 184, 1635, 60}; !reflect.DeepEqual(numbers, want) {
+		t.Fatalf("Expected cycle %v, got %v", want, numbers)
+	}
+	if rules[1].Name != "Sierpinski" {
+		t.Errorf("Expected rule 90 to be replaced, got %v", rules[1])
+	}
+
+	tests := []struct {
+		rule, maxRule, expected int
+	}{
+		{30, MaxRule, 90},
+		{184, MaxRule, 60}, // 1635 is outside the elementary rule space
+		{184, 2186, 1635},  // but inside the 3-state one
+		{60, MaxRule, 30},  // wraps around
+		{77, MaxRule, 30},  // a rule outside the cycle starts it over
+	}
+	for _, tt := range tests {
+		if got := nextRule(rules, tt.rule, tt.maxRule); got != tt.expected {
+			t.Errorf("nextRule(%d, %d): expected %d, got %d", tt.rule, tt.maxRule, tt.expected, got)
+		}
+	}
+}
+
+// Test that cycling to a rule applies its appearance, falling back to the configured one
+func TestModel_RuleStyle(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.AliveChar = "#"
+	cfg.Rules = []RulePreset{{Rule: 90, Name: "Sierpinski", AliveChar: "▲", AliveColor: "#00FF00"}}
+	m := NewModel(cfg)
+
+	if style := m.ruleStyle(); style.AliveChar != "#" || style.AliveColor != DefaultAliveColor {
+		t.Errorf("Expected the configured style for rule %d, got %v", m.rule, style)
+	}
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = model.(Model)
+	if m.rule != 90 {
+		t.Fatalf("Expected rule 90, got %d", m.rule)
+	}
+	style := m.ruleStyle()
+	expected := RulePreset{Rule: 90, Name: "Sierpinski", AliveColor: "#00FF00", DeadColor: DefaultDeadColor, AliveChar: "▲", DeadChar: DefaultDeadChar}
+	if style != expected {
+		t.Errorf("Expected style %v, got %v", expected, style)
+	}
+	if m.renderOptions != NewRenderOptions(style.AliveColor, style.DeadColor, style.AliveChar, style.DeadChar) {
+		t.Error("Expected the render options to follow the rule style")
+	}
+}
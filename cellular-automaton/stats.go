@@ -0,0 +1,65 @@
+package main
+
+import "math"
+
+// setPopulation records the live cell count of a newly computed row
+func (ca *CellularAutomaton) setPopulation(live int) {
+	ca.prevPopulation = ca.population
+	ca.population = live
+}
+
+// resetStats counts the live cells of the initial row and clears the
+// rolling window
+func (ca *CellularAutomaton) resetStats() {
+	ca.population = 0
+	for _, cell := range ca.currentRow {
+		if cell {
+			ca.population++
+		}
+	}
+	ca.prevPopulation = 0
+	ca.recentLive = ca.recentLive[:0]
+	ca.recentPos = 0
+	ca.recentSum = 0
+}
+
+// recordStats adds the current population to the rolling window, dropping the
+// oldest generation once EntropyWindow generations are kept
+func (ca *CellularAutomaton) recordStats() {
+	if len(ca.recentLive) < EntropyWindow {
+		ca.recentLive = append(ca.recentLive, ca.population)
+		ca.recentSum += ca.population
+		return
+	}
+	ca.recentSum += ca.population - ca.recentLive[ca.recentPos]
+	ca.recentLive[ca.recentPos] = ca.population
+	ca.recentPos = (ca.recentPos + 1) % EntropyWindow
+}
+
+// GetPopulation returns the number of live cells in the current row
+func (ca *CellularAutomaton) GetPopulation() int {
+	return ca.population
+}
+
+// Density returns the fraction of live cells in the current row
+func (ca *CellularAutomaton) Density() float64 {
+	if ca.cols == 0 {
+		return 0
+	}
+	return float64(ca.population) / float64(ca.cols)
+}
+
+// Entropy returns the Shannon entropy, in bits per cell, of the live cell
+// fraction over the last EntropyWindow generations. It is 0 when every cell
+// is dead or every cell is alive and 1 when half of them are alive.
+func (ca *CellularAutomaton) Entropy() float64 {
+	cells := len(ca.recentLive) * ca.cols
+	if cells == 0 {
+		return 0
+	}
+	p := float64(ca.recentSum) / float64(cells)
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// countLive counts the live cells of a row by scanning it
+func countLive(row []bool) int {
+	count := 0
+	for _, cell := range row {
+		if cell {
+			count++
+		}
+	}
+	return count
+}
+
+// Test that Rule 0 dies out and Rule 255 fills the row
+func TestCellularAutomaton_DensityTrends(t *testing.T) {
+	tests := []struct {
+		rule    int
+		density float64
+	}{
+		{rule: 0, density: 0},
+		{rule: 255, density: 1},
+	}
+
+	for _, tt := range tests {
+		ca := NewCellularAutomaton(tt.rule, 50, BoundaryPeriodic)
+		for range EntropyWindow {
+			ca.Step()
+		}
+		if got := ca.Density(); got != tt.density {
+			t.Errorf("Rule %d: expected density %v, got %v", tt.rule, tt.density, got)
+		}
+		if got := ca.Entropy(); got != 0 {
+			t.Errorf("Rule %d: expected entropy 0 once the window settles, got %v", tt.rule, got)
+		}
+	}
+}
+
+// Test that the incrementally maintained population matches a rescan
+func TestCellularAutomaton_PopulationTracking(t *testing.T) {
+	tests := []struct {
+		name       string
+		states     int
+		rng        int
+		rule       int
+		reversible bool
+	}{
+		{name: "elementary", states: 2, rng: 1, rule: 30},
+		{name: "elementary reversible", states: 2, rng: 1, rule: 90, reversible: true},
+		{name: "totalistic", states: 3, rng: 1, rule: 1635},
+		{name: "totalistic reversible", states: 3, rng: 2, rule: 912, reversible: true},
+	}
+
+	for _, tt := range tests {
+		ca := NewTotalisticAutomaton(tt.rule, tt.states, tt.rng, 60, BoundaryFixed)
+		ca.SetRandom(rand.New(rand.NewPCG(1, 2)))
+		ca.SetSeed(SeedRandom, 0.5, "")
+		ca.SetReversible(tt.reversible)
+
+		for range 100 {
+			ca.Step()
+			if got, want := ca.GetPopulation(), countLive(ca.GetCurrentRow()); got != want {
+				t.Fatalf("%s: generation %d: expected population %d, got %d", tt.name, ca.GetGeneration(), want, got)
+			}
+		}
+		if tt.reversible {
+			ca.Reverse()
+			if got, want := ca.GetPopulation(), countLive(ca.GetCurrentRow()); got != want {
+				t.Errorf("%s: expected population %d after Reverse, got %d", tt.name, want, got)
+			}
+		}
+	}
+}
+
+// Test that a chaotic rule has high entropy
+func TestCellularAutomaton_Entropy(t *testing.T) {
+	ca := NewCellularAutomaton(30, 200, BoundaryPeriodic)
+	if got := ca.Entropy(); got <= 0 {
+		t.Errorf("Expected positive entropy for a single live cell, got %v", got)
+	}
+
+	ca.SetRandom(rand.New(rand.NewPCG(1, 2)))
+	ca.SetSeed(SeedRandom, 0.5, "")
+	for range 2 * EntropyWindow {
+		ca.Step()
+	}
+	if got := ca.Entropy(); got < 0.9 || got > 1 {
+		t.Errorf("Expected Rule 30 entropy close to 1, got %v", got)
+	}
+}
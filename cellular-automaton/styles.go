@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 // Enhanced UI styles for better visual appearance
@@ -24,6 +25,9 @@ var (
 			Padding(0, 1).
 			Bold(true)
 
+	// Rule legend text, on the label background so dead-colored digits stay visible
+	legendStyle = labelStyle.Padding(0)
+
 	tableBuilder strings.Builder
 )
 
@@ -37,6 +41,18 @@ const (
 	RuleLabelCN = "🧬 规则: %d"
 	RuleLabelEN = "🧬 Rule: %d"
 
+	NamedRuleLabelCN = "🧬 规则: %d (%s)"
+	NamedRuleLabelEN = "🧬 Rule: %d (%s)"
+
+	CompareRuleLabelCN = "🧬 规则: %d │ %d"
+	CompareRuleLabelEN = "🧬 Rule: %d │ %d"
+
+	RuleInputLabelCN = "⌨️ 输入规则: %s_"
+	RuleInputLabelEN = "⌨️ Enter Rule: %s_"
+
+	InvalidRuleLabelCN = "⚠️ 无效规则: %s (0-%d)"
+	InvalidRuleLabelEN = "⚠️ Invalid Rule: %s (0-%d)"
+
 	GenerationLabelCN = "⚡ 代数: %d"
 	GenerationLabelEN = "⚡ Gen: %d"
 
@@ -49,6 +65,26 @@ const (
 	BoundaryLabelCN = "🔒 边界: %s"
 	BoundaryLabelEN = "🔒 Boundary: %s"
 
+	DensityLabelCN = "📊 密度: %.2f"
+	DensityLabelEN = "📊 Density: %.2f"
+
+	EntropyLabelCN = "🎲 熵: %.2f"
+	EntropyLabelEN = "🎲 Entropy: %.2f"
+
+	ReversibleLabelCN = "🔁 可逆"
+	ReversibleLabelEN = "🔁 Reversible"
+
+	ScrolledBackLabelCN = "📜 回看: -%d"
+	ScrolledBackLabelEN = "📜 Back: -%d"
+
+	SavedLabelCN      = "💾 已保存: %s"
+	SavedLabelEN      = "💾 Saved: %s"
+	SaveFailedLabelCN = "⚠️ 保存失败: %v"
+	SaveFailedLabelEN = "⚠️ Save failed: %v"
+
+	RuleLegendLabelCN = "📖 规则 %d:"
+	RuleLegendLabelEN = "📖 Rule %d:"
+
 	StatusLabelPlayingCN = "▶️ 运行中"
 	StatusLabelPlayingEN = "▶️ Running"
 	StatusLabelPausedCN  = "⏸️ 已暂停"
@@ -58,12 +94,36 @@ const (
 	SelectRuleLabelCN = "T 选择规则"
 	SelectRuleLabelEN = "T Select Rule"
 
+	EnterRuleLabelCN = "G 输入规则"
+	EnterRuleLabelEN = "G Enter Rule"
+
+	PatternColoringLabelCN = "W 邻域着色"
+	PatternColoringLabelEN = "W Neighbourhood Colors"
+
+	ReversibleToggleLabelCN = "V 可逆模式"
+	ReversibleToggleLabelEN = "V Reversible"
+
+	CompareLabelCN = "C 对比规则"
+	CompareLabelEN = "C Compare"
+
+	CompareRuleSelectLabelCN = "Y 右侧规则"
+	CompareRuleSelectLabelEN = "Y Right Rule"
+
+	SaveImageLabelCN = "S 保存图像"
+	SaveImageLabelEN = "S Save Image"
+
+	LegendToggleLabelCN = "E 规则图例"
+	LegendToggleLabelEN = "E Rule Legend"
+
 	SelectBoundaryLabelCN = "B 选择边界"
 	SelectBoundaryLabelEN = "B Select Boundary"
 
 	SpeedControlLabelCN = "+/- 加速/减速"
 	SpeedControlLabelEN = "+/- Speed Up/Down"
 
+	ScrollLabelCN = "PgUp/PgDn 回看"
+	ScrollLabelEN = "PgUp/PgDn Scroll"
+
 	LanguageLabelCN = "L 切换语言"
 	LanguageLabelEN = "L Switch Language"
 
@@ -75,20 +135,60 @@ const (
 
 	QuitLabelCN = "Q 退出"
 	QuitLabelEN = "Q Quit"
+
+	// CompareDivider separates the two automata in comparison mode
+	CompareDivider = " │ "
 )
 
 // RenderOptions contains rendering configuration with cached styles
 type RenderOptions struct {
-	aliveStyled string // Cached styled alive cell
-	deadStyled  string // Cached styled dead cell
+	aliveStyled   string // Cached styled alive cell
+	deadStyled    string // Cached styled dead cell
+	dividerStyled string // Cached styled comparison divider
+
+	patternStyled [NoPattern]string // Cached styled alive cell for each neighbourhood, in PatternColors
+
+	legendBits [2]string // Cached legend digits 0 and 1, in the dead and alive colors
+}
+
+// PatternColors are the colors of live cells produced by each neighbourhood,
+// from 000 to 111, when neighbourhood coloring is on
+var PatternColors = [NoPattern]string{
+	"#E6194B", // 000
+	"#3CB44B", // 001
+	"#FFE119", // 010
+	"#4363D8", // 011
+	"#F58231", // 100
+	"#911EB4", // 101
+	"#42D4F4", // 110
+	"#F032E6", // 111
+}
+
+// newPalette builds the alive and dead cell colors, falling back to the
+// defaults for invalid colors
+func newPalette(aliveColor, deadColor string) *pkg.Palette {
+	palette := pkg.NewPalette()
+	_ = palette.Set(pkg.RoleAlive, aliveColor, DefaultAliveColor)
+	_ = palette.Set(pkg.RoleDead, deadColor, DefaultDeadColor)
+	return palette
 }
 
 // NewRenderOptions creates optimized render options with pre-computed styles
 func NewRenderOptions(aliveColor, deadColor, aliveChar, deadChar string) RenderOptions {
-	return RenderOptions{
-		aliveStyled: lipgloss.NewStyle().Foreground(lipgloss.Color(aliveColor)).Render(aliveChar),
-		deadStyled:  lipgloss.NewStyle().Foreground(lipgloss.Color(deadColor)).Render(deadChar),
+	palette := newPalette(aliveColor, deadColor)
+	ro := RenderOptions{
+		aliveStyled:   palette.Style(pkg.RoleAlive).Render(aliveChar),
+		deadStyled:    palette.Style(pkg.RoleDead).Render(deadChar),
+		dividerStyled: palette.Style(pkg.RoleAlive).Render(CompareDivider),
+		legendBits: [2]string{
+			legendStyle.Foreground(palette.Style(pkg.RoleDead).GetForeground()).Render("0"),
+			legendStyle.Foreground(palette.Style(pkg.RoleAlive).GetForeground()).Render("1"),
+		},
 	}
+	for i, color := range PatternColors {
+		ro.patternStyled[i] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(color)).Render(aliveChar)
+	}
+	return ro
 }
 
 // HeaderLineView returns the header display string
@@ -103,7 +203,7 @@ func (m Model) HeaderLineView() string {
 
 // StatusLineView returns the status display string for the first row
 func (m Model) StatusLineView() string {
-	var status, ruleLabel, generationLabel, speedLabel, boundaryLabel, sizeLabel string
+	var status, reversibleLabel, scrolledBackLabel, ruleLabel, namedRuleLabel, compareRuleLabel, ruleInputLabel, invalidRuleLabel, generationLabel, speedLabel, boundaryLabel, sizeLabel, densityLabel, entropyLabel string
 
 	if m.language == Chinese {
 		status = StatusLabelPlayingCN
@@ -111,24 +211,52 @@ func (m Model) StatusLineView() string {
 			status = StatusLabelPausedCN
 		}
 		ruleLabel = RuleLabelCN
+		namedRuleLabel = NamedRuleLabelCN
+		compareRuleLabel = CompareRuleLabelCN
+		ruleInputLabel = RuleInputLabelCN
+		reversibleLabel = ReversibleLabelCN
+		scrolledBackLabel = ScrolledBackLabelCN
+		invalidRuleLabel = InvalidRuleLabelCN
 		generationLabel = GenerationLabelCN
 		speedLabel = SpeedLabelCN
 		boundaryLabel = BoundaryLabelCN
 		sizeLabel = SizeLabelCN
+		densityLabel = DensityLabelCN
+		entropyLabel = EntropyLabelCN
 	} else {
 		status = StatusLabelPlayingEN
 		if m.paused {
 			status = StatusLabelPausedEN
 		}
 		ruleLabel = RuleLabelEN
+		namedRuleLabel = NamedRuleLabelEN
+		compareRuleLabel = CompareRuleLabelEN
+		ruleInputLabel = RuleInputLabelEN
+		reversibleLabel = ReversibleLabelEN
+		scrolledBackLabel = ScrolledBackLabelEN
+		invalidRuleLabel = InvalidRuleLabelEN
 		generationLabel = GenerationLabelEN
 		speedLabel = SpeedLabelEN
 		boundaryLabel = BoundaryLabelEN
 		sizeLabel = SizeLabelEN
+		densityLabel = DensityLabelEN
+		entropyLabel = EntropyLabelEN
 	}
 
+	ruleName := m.ruleStyle().Name
 	tableBuilder.Reset()
-	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(ruleLabel, m.rule)))
+	switch {
+	case m.enteringRule:
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(ruleInputLabel, m.ruleInput)))
+	case m.invalidRule != "":
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(invalidRuleLabel, m.invalidRule, MaxRuleFor(m.ca.states, m.ca.rng))))
+	case m.compare:
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(compareRuleLabel, m.rule, m.compareRule)))
+	case ruleName != "":
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(namedRuleLabel, m.rule, ruleName)))
+	default:
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(ruleLabel, m.rule)))
+	}
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(generationLabel, m.currentStep)))
 	tableBuilder.WriteString(" | ")
@@ -138,26 +266,58 @@ func (m Model) StatusLineView() string {
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sizeLabel, m.gridHeight, m.gridWidth)))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(densityLabel, m.ca.Density())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(entropyLabel, m.ca.Entropy())))
+	if m.ca.IsReversible() {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(reversibleLabel))
+	}
+	if m.viewLine > 0 {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(scrolledBackLabel, m.viewLine)))
+	}
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(status))
+	if m.notice != "" {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(m.notice))
+	}
 
 	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
 }
 
-// ControlLineView returns the control display string: T,B,R + Space, L, Q
+// ControlLineView returns the control display string: T,G,C,Y,S,W,V,E,B,R + PgUp/PgDn, Space, L, Q
 func (m Model) ControlLineView() string {
-	var selectRule, selectBoundary, speedControl, language, space, reset, quit string
+	var selectRule, enterRule, compare, compareRule, saveImage, patternColoring, reversible, legendToggle, selectBoundary, speedControl, scroll, language, space, reset, quit string
 	if m.language == Chinese {
 		selectRule = SelectRuleLabelCN
+		enterRule = EnterRuleLabelCN
+		compare = CompareLabelCN
+		compareRule = CompareRuleSelectLabelCN
+		saveImage = SaveImageLabelCN
+		patternColoring = PatternColoringLabelCN
+		reversible = ReversibleToggleLabelCN
+		legendToggle = LegendToggleLabelCN
 		selectBoundary = SelectBoundaryLabelCN
 		speedControl = SpeedControlLabelCN
+		scroll = ScrollLabelCN
 		language = LanguageLabelCN
 		space = SpaceLabelCN
 		reset = ResetLabelCN
 		quit = QuitLabelCN
 	} else {
 		selectRule = SelectRuleLabelEN
+		enterRule = EnterRuleLabelEN
+		compare = CompareLabelEN
+		compareRule = CompareRuleSelectLabelEN
+		saveImage = SaveImageLabelEN
+		patternColoring = PatternColoringLabelEN
+		reversible = ReversibleToggleLabelEN
+		legendToggle = LegendToggleLabelEN
 		selectBoundary = SelectBoundaryLabelEN
 		speedControl = SpeedControlLabelEN
+		scroll = ScrollLabelEN
 		language = LanguageLabelEN
 		space = SpaceLabelEN
 		reset = ResetLabelEN
@@ -167,10 +327,30 @@ func (m Model) ControlLineView() string {
 	tableBuilder.Reset()
 	tableBuilder.WriteString(labelStyle.Render(selectRule))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(enterRule))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(compare))
+	tableBuilder.WriteString(" | ")
+	if m.compare {
+		tableBuilder.WriteString(labelStyle.Render(compareRule))
+		tableBuilder.WriteString(" | ")
+	}
+	tableBuilder.WriteString(labelStyle.Render(saveImage))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(patternColoring))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(reversible))
+	tableBuilder.WriteString(" | ")
+	if m.ca.isElementary() {
+		tableBuilder.WriteString(labelStyle.Render(legendToggle))
+		tableBuilder.WriteString(" | ")
+	}
 	tableBuilder.WriteString(labelStyle.Render(selectBoundary))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(speedControl))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(scroll))
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(language))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(space))
@@ -181,3 +361,55 @@ func (m Model) ControlLineView() string {
 
 	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
 }
+
+// LegendLineView returns the rule legend: each neighbourhood from 111 down to
+// 000 with the state it gives the middle cell, digits colored like the cells
+// they stand for. It is empty for totalistic rules.
+func (m Model) LegendLineView() string {
+	table, ok := m.ca.GetRuleTable()
+	if !ok {
+		return ""
+	}
+
+	label := RuleLegendLabelEN
+	if m.language == Chinese {
+		label = RuleLegendLabelCN
+	}
+
+	bit := func(alive bool) string {
+		if alive {
+			return m.renderOptions.legendBits[1]
+		}
+		return m.renderOptions.legendBits[0]
+	}
+
+	tableBuilder.Reset()
+	tableBuilder.WriteString(legendStyle.Render(" " + fmt.Sprintf(label, m.rule)))
+	for pattern := NoPattern - 1; pattern >= 0; pattern-- {
+		tableBuilder.WriteString(legendStyle.Render(" "))
+		for shift := 2; shift >= 0; shift-- {
+			tableBuilder.WriteString(bit(pattern>>shift&1 == 1))
+		}
+		tableBuilder.WriteString(legendStyle.Render("→"))
+		tableBuilder.WriteString(bit(table[pattern]))
+	}
+	tableBuilder.WriteString(legendStyle.Render(" "))
+
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
+}
+
+// savedLabel returns the image export success message format
+func (m Model) savedLabel() string {
+	if m.language == Chinese {
+		return SavedLabelCN
+	}
+	return SavedLabelEN
+}
+
+// saveFailedLabel returns the image export failure message format
+func (m Model) saveFailedLabel() string {
+	if m.language == Chinese {
+		return SaveFailedLabelCN
+	}
+	return SaveFailedLabelEN
+}
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 var (
@@ -25,37 +30,81 @@ type Model struct {
 	refreshRate    time.Duration
 	boundary       BoundaryType
 	width          int
+	height         int
 	gridHeight     int
 	gridWidth      int
 	buffer         strings.Builder
 	gridBuffer     strings.Builder
 	gridRingBuffer *GridRingBuffer
+	scrollback     int // Generations kept beyond the visible rows
+	viewLine       int // Rows scrolled back from the newest generation, 0 follows it
 	renderOptions  RenderOptions
 	logger         *slog.Logger
+
+	patternColoring bool // Color live cells by the neighbourhood that produced them
+	showLegend      bool // Show the rule legend below the controls, for elementary rules
+
+	// Rule cycle
+	rules        []RulePreset // Rules cycled by the t and y keys
+	defaultStyle RulePreset   // Configured colors and characters, for rules that set none
+
+	// Rule number entry
+	enteringRule bool   // Whether digit keys are accumulating a rule number
+	ruleInput    string // Digits typed so far
+	invalidRule  string // Last rejected input, shown in the status line until the next key
+
+	notice string // Result of the last image export, shown in the status line until the next key
+
+	// Side-by-side rule comparison
+	compare       bool               // Whether a second automaton runs on the right half
+	compareCA     *CellularAutomaton // Right-hand automaton, stepped in sync with ca
+	compareRule   int
+	compareBuffer *GridRingBuffer
 }
 
 // NewModel creates a new model with the given configuration
 func NewModel(cfg Config) Model {
 	cfg.Check()
 
+	ca := NewTotalisticAutomaton(cfg.Rule, cfg.States, cfg.Range, DefaultCols, DefaultBoundary)
 	gridHeight := DefaultRows - keepHeight
+	if ca.isElementary() {
+		gridHeight-- // The rule legend is shown by default
+	}
 	gridWidth := DefaultCols - keepWidth
+	rules := mergeRules(builtinRules, cfg.Rules)
 	model := Model{
-		ca:             NewCellularAutomaton(cfg.Rule, DefaultCols, DefaultBoundary),
+		ca:             ca,
 		rule:           cfg.Rule,
+		compareCA:      NewTotalisticAutomaton(nextRule(rules, cfg.Rule, MaxRuleFor(cfg.States, cfg.Range)), cfg.States, cfg.Range, DefaultCols, DefaultBoundary),
+		compareBuffer:  NewGridRingBuffer(gridHeight+cfg.Scrollback, gridWidth),
 		language:       cfg.Language,
 		refreshRate:    DefaultRefreshRate,
 		boundary:       DefaultBoundary,
 		width:          DefaultCols,
+		height:         DefaultRows,
 		gridHeight:     gridHeight,
 		gridWidth:      gridWidth,
-		gridRingBuffer: NewGridRingBuffer(gridHeight, gridWidth),
-		renderOptions:  NewRenderOptions(cfg.AliveColor, cfg.DeadColor, cfg.AliveChar, cfg.DeadChar),
+		gridRingBuffer: NewGridRingBuffer(gridHeight+cfg.Scrollback, gridWidth),
+		scrollback:     cfg.Scrollback,
 		logger:         slog.With("module", "ui"),
+		rules:          rules,
+		defaultStyle:   RulePreset{AliveColor: cfg.AliveColor, DeadColor: cfg.DeadColor, AliveChar: cfg.AliveChar, DeadChar: cfg.DeadChar},
+		showLegend:     true,
 	}
 
+	model.compareRule = model.compareCA.GetRule()
+
+	for _, ca := range []*CellularAutomaton{model.ca, model.compareCA} {
+		ca.SetSeed(cfg.Seed, cfg.SeedDensity, cfg.SeedPattern)
+		ca.SetReversible(cfg.Reversible)
+	}
+	model.reseedRandom()
+
+	model.applyRuleStyle()
+
 	// Initialize the ring buffer with the initial state - add safety check
-	model.gridRingBuffer.AddRow(model.ca.GetCurrentRow())
+	model.gridRingBuffer.AddPatternRow(model.ca.GetCurrentRow(), model.ca.GetCurrentPatterns())
 
 	return model
 }
@@ -82,7 +131,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyPress(msg)
 	case tickMsg:
 		m.logger.Debug("Tick", "time", msg)
-		return m.handleTick()
+		return m.handleTick(time.Time(msg))
 	}
 
 	return m, nil
@@ -106,18 +155,33 @@ func (m Model) View() string {
 // handleWindowResize processes terminal window size changes
 func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.width = msg.Width
+	m.height = msg.Height
 	m.gridWidth = msg.Width - keepWidth
-	m.gridHeight = msg.Height - keepHeight
+	m.gridHeight = msg.Height - keepHeight - m.legendLines()
 	m.logger.Debug("Window size changed", "width", m.width, "gridWidth", m.gridWidth, "gridHeight", m.gridHeight)
-	m.ca.Reset(m.rule, m.gridWidth, m.boundary)
-	m.gridRingBuffer = NewGridRingBuffer(m.gridHeight, m.gridWidth)
-	m.gridRingBuffer.AddRow(m.ca.GetCurrentRow())
+	m.newHistoryBuffers()
+	m.resetAutomata(m.gridWidth)
 	return m, nil
 }
 
+// legendLines returns the number of rows the rule legend takes below the
+// controls
+func (m Model) legendLines() int {
+	if m.showLegend && m.ca.isElementary() {
+		return 1
+	}
+	return 0
+}
+
 // handleKeyPress processes keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	keyStr := msg.String()
+	m.invalidRule = ""
+	m.notice = ""
+
+	if m.enteringRule {
+		return m.handleRuleInput(keyStr)
+	}
 
 	// Handle normal application keys when no modal is active
 	switch keyStr {
@@ -128,23 +192,26 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.paused = !m.paused
 
 	case "t": // Toggle rule selection modal (T for "Type" rule)
-		switch m.rule {
-		case 30:
-			m.rule = 90
-		case 90:
-			m.rule = 110
-		case 110:
-			m.rule = 154
-		case 154:
-			m.rule = 184
-		case 184:
-			m.rule = 30
-		default:
-			m.rule = 30
+		m.rule = nextRule(m.rules, m.rule, MaxRuleFor(m.ca.states, m.ca.rng))
+		m.resetAutomata(m.width)
+
+	case "y": // Cycle the right-hand rule in comparison mode
+		if m.compare {
+			m.compareRule = nextRule(m.rules, m.compareRule, MaxRuleFor(m.ca.states, m.ca.rng))
+			m.resetAutomata(m.width)
 		}
-		m.ca.Reset(m.rule, m.width, m.boundary)
-		m.gridRingBuffer.Clear()
-		m.gridRingBuffer.AddRow(m.ca.GetCurrentRow())
+
+	case "c": // Toggle side-by-side rule comparison
+		m.compare = !m.compare
+		m.resetAutomata(m.width)
+
+	case "g": // Start typing a rule number (G for "Go to" rule)
+		m.enteringRule = true
+		m.ruleInput = ""
+
+	case "e": // Toggle the rule legend; the grid takes its row back, as on a resize
+		m.showLegend = !m.showLegend
+		return m.handleWindowResize(tea.WindowSizeMsg{Width: m.width, Height: m.height})
 
 	case "b": // Show boundary selection modal
 		switch m.boundary {
@@ -155,9 +222,37 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case BoundaryReflect:
 			m.boundary = BoundaryPeriodic
 		}
-		m.ca.Reset(m.rule, m.width, m.boundary)
-		m.gridRingBuffer.Clear()
-		m.gridRingBuffer.AddRow(m.ca.GetCurrentRow())
+		m.resetAutomata(m.width)
+	case "w": // Toggle Wolfram-style neighbourhood coloring
+		m.patternColoring = !m.patternColoring
+
+	case "v": // Toggle second-order reversible mode
+		reversible := !m.ca.IsReversible()
+		m.ca.SetReversible(reversible)
+		m.compareCA.SetReversible(reversible)
+		m.resetAutomata(m.width)
+
+	case "s": // Save the generation history as an image
+		path := m.ca.ImageFileName()
+		if err := m.ca.SaveImage(path); err != nil {
+			m.logger.Error("Failed to save image", "path", path, "error", err)
+			m.notice = fmt.Sprintf(m.saveFailedLabel(), err)
+		} else {
+			m.notice = fmt.Sprintf(m.savedLabel(), path)
+		}
+
+	case "pgup": // Scroll back one page
+		m.ScrollUp(m.gridHeight)
+
+	case "pgdown": // Scroll forward one page
+		m.ScrollDown(m.gridHeight)
+
+	case "home": // Jump to the oldest kept generation
+		m.ScrollUp(m.gridRingBuffer.Len())
+
+	case "end": // Follow the newest generation again
+		m.viewLine = 0
+
 	case "l": // Language toggle key
 		if m.language == English {
 			m.language = Chinese
@@ -166,9 +261,7 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "r": // Reset simulation
-		m.ca.Reset(m.rule, m.width, m.boundary)
-		m.gridRingBuffer.Clear()
-		m.gridRingBuffer.AddRow(m.ca.GetCurrentRow())
+		m.resetAutomata(m.width)
 
 	case "+", "=", "up": // Increase refresh rate (make it faster)
 		m.refreshRate = max(m.refreshRate/2, MinRefreshRate)
@@ -179,21 +272,171 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleRuleInput processes keys while a rule number is being typed:
+// digits accumulate, backspace deletes, enter applies and esc cancels
+func (m Model) handleRuleInput(keyStr string) (tea.Model, tea.Cmd) {
+	switch keyStr {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.enteringRule = false
+		m.ruleInput = ""
+
+	case "backspace":
+		if len(m.ruleInput) > 0 {
+			m.ruleInput = m.ruleInput[:len(m.ruleInput)-1]
+		}
+
+	case "enter":
+		m.enteringRule = false
+		input := m.ruleInput
+		m.ruleInput = ""
+		if input == "" {
+			return m, nil
+		}
+
+		rule, err := strconv.Atoi(input)
+		if err != nil || rule < MinRule || rule > MaxRuleFor(m.ca.states, m.ca.rng) {
+			m.invalidRule = input
+			return m, nil
+		}
+		m.rule = rule
+		m.resetAutomata(m.width)
+
+	default:
+		// Allow one digit more than the largest rule; longer input is rejected on enter
+		maxLen := len(strconv.Itoa(MaxRuleFor(m.ca.states, m.ca.rng))) + 1
+		if len(keyStr) == 1 && keyStr[0] >= '0' && keyStr[0] <= '9' && len(m.ruleInput) < maxLen {
+			m.ruleInput += keyStr
+		}
+	}
+	return m, nil
+}
+
+// ruleStyle returns the appearance of the active rule, falling back to the
+// configured colors and characters for fields the rule does not set
+func (m Model) ruleStyle() RulePreset {
+	style := RulePreset{Rule: m.rule}
+	if i := findRule(m.rules, m.rule); i >= 0 {
+		style = m.rules[i]
+	}
+	return style.withDefaults(m.defaultStyle)
+}
+
+// applyRuleStyle updates the rendered and exported colors for the active rule
+func (m *Model) applyRuleStyle() {
+	style := m.ruleStyle()
+	m.renderOptions = NewRenderOptions(style.AliveColor, style.DeadColor, style.AliveChar, style.DeadChar)
+	m.ca.SetColors(style.AliveColor, style.DeadColor)
+	m.compareCA.SetColors(style.AliveColor, style.DeadColor)
+}
+
+// newHistoryBuffers replaces the row history with empty buffers holding the
+// visible rows plus the scrollback, so memory stays within
+// (gridHeight + scrollback) x gridWidth cells per automaton
+func (m *Model) newHistoryBuffers() {
+	capacity := m.gridHeight + m.scrollback
+	m.gridRingBuffer = NewGridRingBuffer(capacity, m.gridWidth)
+	m.compareBuffer = NewGridRingBuffer(capacity, m.gridWidth)
+	m.viewLine = 0
+}
+
+// SetScrollback sets how many generations are kept beyond the visible rows
+// and restarts the simulation with the new history size
+func (m *Model) SetScrollback(n int) {
+	m.scrollback = max(min(n, MaxScrollback), 0)
+	m.newHistoryBuffers()
+	m.resetAutomata(m.width)
+}
+
+// ScrollUp reveals up to n earlier generations
+func (m *Model) ScrollUp(n int) {
+	m.viewLine = min(m.viewLine+n, m.maxViewLine())
+}
+
+// ScrollDown moves up to n generations back towards the newest
+func (m *Model) ScrollDown(n int) {
+	m.viewLine = max(m.viewLine-n, 0)
+}
+
+// maxViewLine returns how far back the view can scroll while staying full
+func (m Model) maxViewLine() int {
+	return max(m.gridRingBuffer.Len()-m.gridHeight, 0)
+}
+
+// visibleRows returns the history indices [start, end) of the rows on screen
+func (m Model) visibleRows() (int, int) {
+	end := m.gridRingBuffer.Len() - m.viewLine
+	return max(end-m.gridHeight, 0), end
+}
+
+// halfWidth returns the number of cells on each side in comparison mode
+func (m Model) halfWidth() int {
+	return (m.gridWidth - lipgloss.Width(CompareDivider)) / 2
+}
+
+// resetAutomata reinitializes the automaton (and in comparison mode the
+// right-hand one, on half the width each) with the current rules and
+// boundary, and restarts the history
+func (m *Model) resetAutomata(cols int) {
+	if m.compare {
+		cols = m.halfWidth()
+	}
+	m.reseedRandom()
+
+	m.ca.Reset(m.rule, cols, m.boundary)
+	m.rule = m.ca.GetRule() // Out of range for the totalistic rule space
+	m.currentStep = 0
+	m.viewLine = 0
+	m.gridRingBuffer.Clear()
+	m.gridRingBuffer.AddPatternRow(m.ca.GetCurrentRow(), m.ca.GetCurrentPatterns())
+
+	m.compareBuffer.Clear()
+	if m.compare {
+		m.compareCA.Reset(m.compareRule, cols, m.boundary)
+		m.compareRule = m.compareCA.GetRule()
+		m.compareBuffer.AddPatternRow(m.compareCA.GetCurrentRow(), m.compareCA.GetCurrentPatterns())
+	}
+	m.applyRuleStyle()
+}
+
+// reseedRandom gives both automata the same fresh random stream, so a
+// comparison turned on at any time starts both from the same row
+func (m *Model) reseedRandom() {
+	// #nosec G115 - Conversion is safe for our use case
+	seed := uint64(time.Now().UnixNano())
+	for _, ca := range []*CellularAutomaton{m.ca, m.compareCA} {
+		// #nosec G404 - Using math/rand for simulation, not cryptography
+		ca.SetRandom(rand.New(rand.NewPCG(seed, seed)))
+	}
+}
+
 // handleTick processes timer ticks
-func (m Model) handleTick() (tea.Model, tea.Cmd) {
+func (m Model) handleTick(tick time.Time) (tea.Model, tea.Cmd) {
 	if !m.paused && m.ca.Step() {
 		m.currentStep = m.ca.GetGeneration()
-		m.gridRingBuffer.AddRow(m.ca.GetCurrentRow())
+		m.gridRingBuffer.AddPatternRow(m.ca.GetCurrentRow(), m.ca.GetCurrentPatterns())
+		// Step the right-hand automaton in lockstep so both show the same generation
+		if m.compare && m.compareCA.Step() {
+			m.compareBuffer.AddPatternRow(m.compareCA.GetCurrentRow(), m.compareCA.GetCurrentPatterns())
+		}
+		// Keep a scrolled-back view on the same generations
+		if m.viewLine > 0 {
+			m.ScrollUp(1)
+		}
 	}
 
-	// Continue ticking only if not quitting
-	return m, tea.Tick(m.refreshRate, func(t time.Time) tea.Msg {
+	// Continue ticking, scheduled from when this tick fired so time spent
+	// stepping does not stretch the interval
+	delay := pkg.NextTickDelay(m.refreshRate, MinRefreshRate, time.Since(tick))
+	return m, tea.Tick(delay, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
 // RenderMode renders the complete UI mode view with enhanced layout
-// Layout: Header -> Status -> Grid -> Control (bottom)
+// Layout: Header -> Status -> Grid -> Control -> Legend (bottom)
 func (m Model) RenderMode() string {
 	m.buffer.Reset()
 
@@ -205,48 +448,79 @@ func (m Model) RenderMode() string {
 	m.buffer.WriteString(m.RenderGrid())
 	m.buffer.WriteString("\n\n")
 	m.buffer.WriteString(m.ControlLineView())
+	if m.legendLines() > 0 {
+		m.buffer.WriteString("\n")
+		m.buffer.WriteString(m.LegendLineView())
+	}
 
 	return m.buffer.String()
 }
 
-// RenderGrid renders the grid using the optimized ring buffer
+// RenderGrid renders the visible window of the row history
 func (m Model) RenderGrid() string {
 	m.gridBuffer.Reset()
-	rows := m.gridRingBuffer.GetRows()
-	if len(rows) == 0 {
+	start, end := m.visibleRows()
+	if end <= start {
 		return ""
 	}
 
 	// Pre-calculate styled strings to avoid repeated lookups
 	aliveStr := m.renderOptions.aliveStyled
 	deadStr := m.renderOptions.deadStyled
+	var patterns, comparePatterns []uint8
 
-	// Render all rows efficiently
-	for i, row := range rows {
+	// In comparison mode each row is the left half, a divider, and the
+	// matching row of the right-hand automaton
+	half := m.halfWidth()
+
+	// Render the visible rows efficiently
+	for i := start; i < end; i++ {
+		row := m.gridRingBuffer.GetRow(i)
 		if row == nil {
 			continue // Skip nil rows
 		}
 
+		if m.patternColoring {
+			patterns = m.gridRingBuffer.GetPatternRow(i)
+			comparePatterns = m.compareBuffer.GetPatternRow(i)
+		}
+
 		m.gridBuffer.WriteString("  ")
 
-		// Render cells in the row
-		for _, cell := range row {
-			if cell {
-				m.gridBuffer.WriteString(aliveStr)
-			} else {
-				m.gridBuffer.WriteString(deadStr)
+		if m.compare {
+			m.writeCells(row[:min(half, len(row))], patterns, aliveStr, deadStr)
+			m.gridBuffer.WriteString(m.renderOptions.dividerStyled)
+			if compareRow := m.compareBuffer.GetRow(i); compareRow != nil {
+				m.writeCells(compareRow[:min(half, len(compareRow))], comparePatterns, aliveStr, deadStr)
 			}
+		} else {
+			m.writeCells(row, patterns, aliveStr, deadStr)
 		}
 
 		// Add newline except for the last row
-		if i < len(rows)-1 {
+		if i < end-1 {
 			m.gridBuffer.WriteByte('\n')
 		}
 	}
 
-	for i := 0; i < m.gridHeight-len(rows); i++ {
+	for i := 0; i < m.gridHeight-(end-start); i++ {
 		m.gridBuffer.WriteString("\n")
 	}
 
 	return m.gridBuffer.String()
 }
+
+// writeCells writes one styled string per cell of the row. Live cells with a
+// recorded neighbourhood in patterns take its color; patterns may be nil.
+func (m *Model) writeCells(row []bool, patterns []uint8, aliveStr, deadStr string) {
+	for i, cell := range row {
+		switch {
+		case !cell:
+			m.gridBuffer.WriteString(deadStr)
+		case i < len(patterns) && patterns[i] < NoPattern:
+			m.gridBuffer.WriteString(m.renderOptions.patternStyled[patterns[i]])
+		default:
+			m.gridBuffer.WriteString(aliveStr)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg/golden"
+)
+
+// typeKeys sends each key to the model and returns the updated model
+func typeKeys(m Model, keys ...tea.KeyMsg) Model {
+	for _, key := range keys {
+		updated, _ := m.Update(key)
+		m = updated.(Model)
+	}
+	return m
+}
+
+// runeKey returns a key message for a single character
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// Test typing a rule number at runtime
+func TestModel_RuleInput(t *testing.T) {
+	m := NewModel(DefaultConfig)
+
+	m = typeKeys(m, runeKey('g'), runeKey('1'), runeKey('2'), runeKey('5'))
+	if !m.enteringRule || m.ruleInput != "125" {
+		t.Fatalf("Expected rule input 125, got %q (entering=%v)", m.ruleInput, m.enteringRule)
+	}
+	if m.paused {
+		t.Error("Digits should not toggle pause")
+	}
+
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyBackspace}, runeKey('6'), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.enteringRule {
+		t.Error("Expected rule entry to end after enter")
+	}
+	if m.rule != 126 || m.ca.rule != 126 {
+		t.Errorf("Expected rule 126, got model %d, automaton %d", m.rule, m.ca.rule)
+	}
+	if m.ca.GetGeneration() != 0 {
+		t.Errorf("Expected automaton to be reinitialized, got generation %d", m.ca.GetGeneration())
+	}
+	if m.paused {
+		t.Error("Enter should apply the rule, not toggle pause")
+	}
+}
+
+// Test rejecting and cancelling rule input
+func TestModel_RuleInputInvalid(t *testing.T) {
+	m := NewModel(DefaultConfig)
+
+	m = typeKeys(m, runeKey('g'), runeKey('3'), runeKey('0'), runeKey('0'), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.rule != DefaultRule {
+		t.Errorf("Expected rule to stay %d, got %d", DefaultRule, m.rule)
+	}
+	if m.invalidRule != "300" {
+		t.Errorf("Expected invalid rule message for 300, got %q", m.invalidRule)
+	}
+
+	// The message clears on the next key
+	m = typeKeys(m, runeKey('l'))
+	if m.invalidRule != "" {
+		t.Errorf("Expected invalid rule message to clear, got %q", m.invalidRule)
+	}
+
+	m = typeKeys(m, runeKey('g'), runeKey('9'), tea.KeyMsg{Type: tea.KeyEsc})
+	if m.enteringRule || m.ruleInput != "" || m.rule != DefaultRule {
+		t.Error("Expected esc to cancel rule entry without changing the rule")
+	}
+}
+
+// Test running two rules side by side
+func TestModel_Compare(t *testing.T) {
+	m := NewModel(DefaultConfig)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 104, Height: 30})
+	m = updated.(Model)
+
+	m = typeKeys(m, runeKey('c'))
+	if !m.compare {
+		t.Fatal("Expected comparison mode after c")
+	}
+	half := m.halfWidth()
+	if len(m.ca.GetCurrentRow()) != half || len(m.compareCA.GetCurrentRow()) != half {
+		t.Errorf("Expected both automata to have %d columns, got %d and %d", half, len(m.ca.GetCurrentRow()), len(m.compareCA.GetCurrentRow()))
+	}
+	if want := nextRule(builtinRules, DefaultRule, MaxRule); m.compareRule != want {
+		t.Errorf("Expected right rule %d, got %d", want, m.compareRule)
+	}
+
+	m = typeKeys(m, runeKey('y'))
+	if m.compareRule != nextRule(builtinRules, nextRule(builtinRules, DefaultRule, MaxRule), MaxRule) || m.rule != DefaultRule {
+		t.Errorf("Expected y to change only the right rule, got left %d, right %d", m.rule, m.compareRule)
+	}
+
+	for range 5 {
+		updated, _ = m.Update(tickMsg{})
+		m = updated.(Model)
+	}
+	if m.ca.GetGeneration() != m.compareCA.GetGeneration() {
+		t.Errorf("Expected automata in sync, got generations %d and %d", m.ca.GetGeneration(), m.compareCA.GetGeneration())
+	}
+
+	lines := strings.Split(strings.TrimRight(m.RenderGrid(), "\n"), "\n")
+	if len(lines) != 6 {
+		t.Fatalf("Expected 6 rows, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, CompareDivider) {
+			t.Errorf("Row %d is missing the divider", i)
+		}
+		if width := lipgloss.Width(line); width != 2+2*half+lipgloss.Width(CompareDivider) {
+			t.Errorf("Row %d has width %d", i, width)
+		}
+	}
+
+	m = typeKeys(m, runeKey('c'))
+	if m.compare || len(m.ca.GetCurrentRow()) != m.width {
+		t.Errorf("Expected single automaton over %d columns, got compare=%v cols=%d", m.width, m.compare, len(m.ca.GetCurrentRow()))
+	}
+}
+
+// Test that comparison starts both automata from the same random row even
+// after resets that happened while it was off
+func TestModel_CompareRandomSeed(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Seed = SeedRandom
+	m := NewModel(cfg)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 104, Height: 30})
+	m = updated.(Model)
+	m = typeKeys(m, runeKey('r'))
+
+	m = typeKeys(m, runeKey('c'))
+	left, right := m.ca.GetCurrentRow(), m.compareCA.GetCurrentRow()
+	if len(left) != len(right) {
+		t.Fatalf("Expected rows of equal width, got %d and %d", len(left), len(right))
+	}
+	for i := range left {
+		if left[i] != right[i] {
+			t.Fatalf("Expected identical first rows, cell %d differs", i)
+		}
+	}
+}
+
+// Test scrolling back through generations that left the screen
+func TestModel_Scrollback(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Scrollback = 20
+	m := NewModel(cfg)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 84, Height: 16}) // 10 visible rows
+	m = updated.(Model)
+
+	for range 40 {
+		updated, _ = m.Update(tickMsg{})
+		m = updated.(Model)
+	}
+	// Memory is bounded by the visible rows plus the scrollback
+	if got := m.gridRingBuffer.Len(); got != m.gridHeight+cfg.Scrollback {
+		t.Fatalf("Expected %d rows kept, got %d", m.gridHeight+cfg.Scrollback, got)
+	}
+
+	live := m.RenderGrid()
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyPgUp})
+	if m.viewLine != m.gridHeight {
+		t.Fatalf("Expected to scroll back %d rows, got %d", m.gridHeight, m.viewLine)
+	}
+	if m.RenderGrid() == live {
+		t.Error("Expected earlier generations after scrolling back")
+	}
+	lines := strings.Split(m.RenderGrid(), "\n")
+	if len(lines) != m.gridHeight {
+		t.Errorf("Expected %d rows on screen, got %d", m.gridHeight, len(lines))
+	}
+
+	// Scrolling stops at the oldest kept generation
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyPgUp}, tea.KeyMsg{Type: tea.KeyPgUp})
+	if m.viewLine != cfg.Scrollback {
+		t.Errorf("Expected to stop %d rows back, got %d", cfg.Scrollback, m.viewLine)
+	}
+
+	// A scrolled-back view stays on the same generations while running
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyPgDown})
+	back := m.RenderGrid()
+	updated, _ = m.Update(tickMsg{})
+	m = updated.(Model)
+	if m.RenderGrid() != back {
+		t.Error("Expected the scrolled-back view not to move on a tick")
+	}
+
+	m = typeKeys(m, tea.KeyMsg{Type: tea.KeyEnd})
+	if m.viewLine != 0 {
+		t.Errorf("Expected end to follow the newest generation, got %d rows back", m.viewLine)
+	}
+
+	m.SetScrollback(0)
+	if m.gridRingBuffer.Len() != 1 || m.ca.GetGeneration() != 0 {
+		t.Errorf("Expected SetScrollback to restart the history, got %d rows", m.gridRingBuffer.Len())
+	}
+}
+
+// Test that W switches live cells to their neighbourhood colors
+func TestModel_PatternColoring(t *testing.T) {
+	m := NewModel(DefaultConfig)
+	m.handleTick(time.Now())
+	updated, _ := m.handleTick(time.Now())
+	m = updated.(Model)
+
+	// Mark each style with a distinct string, as tests render without colors
+	m.renderOptions.aliveStyled = "A"
+	for p := range m.renderOptions.patternStyled {
+		m.renderOptions.patternStyled[p] = strconv.Itoa(p)
+	}
+
+	plain := m.RenderGrid()
+	if strings.ContainsAny(plain, "01234567") {
+		t.Fatal("Expected no neighbourhood colors before W is pressed")
+	}
+
+	m = typeKeys(m, runeKey('w'))
+	if !m.patternColoring {
+		t.Fatal("Expected W to turn on neighbourhood coloring")
+	}
+	// Rule 30 from a single cell: the seed keeps the plain color and the
+	// second row is born, left to right, from 001, 010 and 100
+	lines := strings.Split(m.RenderGrid(), "\n")
+	if got := strings.TrimSpace(lines[0]); got != "A" {
+		t.Errorf("Expected the seed row to keep the alive color, got %q", got)
+	}
+	if got := strings.TrimSpace(lines[1]); got != "124" {
+		t.Errorf("Expected the second row colored 124, got %q", got)
+	}
+
+	m = typeKeys(m, runeKey('w'))
+	if m.RenderGrid() != plain {
+		t.Error("Expected W to restore the two-color view")
+	}
+}
+
+// goldenModel steps a Model by ticks and renders its grid for golden frames
+type goldenModel struct {
+	m Model
+}
+
+func (g *goldenModel) Step() {
+	updated, _ := g.m.Update(tickMsg{})
+	g.m = updated.(Model)
+}
+
+func (g *goldenModel) View() string {
+	return g.m.RenderGrid()
+}
+
+// Test the rule legend and that hiding it gives its row to the grid
+func TestModel_RuleLegend(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Rule = 30
+	updated, _ := NewModel(cfg).Update(tea.WindowSizeMsg{Width: 400, Height: 30})
+	m := updated.(Model)
+
+	legend := "📖 Rule 30: 111→0 110→0 101→0 100→1 011→1 010→1 001→1 000→0"
+	if got := strings.TrimSpace(m.LegendLineView()); got != legend {
+		t.Errorf("Expected legend %q, got %q", legend, got)
+	}
+	if !strings.HasSuffix(strings.TrimRight(m.View(), " "), legend) {
+		t.Error("Expected the legend on the last line")
+	}
+	if got := strings.Count(m.View(), "\n") + 1; got != 30 {
+		t.Errorf("Expected the view to fill 30 lines, got %d", got)
+	}
+
+	m = typeKeys(m, runeKey('e'))
+	if m.gridHeight != 30-keepHeight {
+		t.Errorf("Expected %d grid rows without the legend, got %d", 30-keepHeight, m.gridHeight)
+	}
+	if strings.Contains(m.View(), "111→") {
+		t.Error("Expected the legend to be hidden")
+	}
+
+	m = typeKeys(m, runeKey('e'), runeKey('l'))
+	if m.gridHeight != 30-keepHeight-1 {
+		t.Errorf("Expected %d grid rows with the legend, got %d", 30-keepHeight-1, m.gridHeight)
+	}
+	if !strings.Contains(m.LegendLineView(), "📖 规则 30:") {
+		t.Errorf("Expected a localized legend label, got %q", m.LegendLineView())
+	}
+
+	// Totalistic rules have no neighbourhood table, so no legend or row for it
+	cfg.States = 3
+	m = NewModel(cfg)
+	if m.LegendLineView() != "" || m.legendLines() != 0 {
+		t.Error("Expected no legend for a totalistic rule")
+	}
+}
+
+// Test that Rule 30 grows from a single cell exactly as recorded. Run with
+// -update to regenerate testdata/rule30 after an intended rendering change.
+func TestModel_GoldenRule30(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Rule = 30
+	// 10 grid rows between the status line and the controls and rule legend
+	updated, _ := NewModel(cfg).Update(tea.WindowSizeMsg{Width: 50, Height: 17})
+
+	golden.AssertGoldenFrames(t, &goldenModel{m: updated.(Model)}, 8, "testdata/rule30")
+}
@@ -3,8 +3,11 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/telepair/go-playground/pkg"
 )
 
 // BoundaryType represents the boundary type of the Game of Life
@@ -14,6 +17,8 @@ type BoundaryType int
 const (
 	BoundaryPeriodic BoundaryType = iota // Periodic boundary (wrapping, default)
 	BoundaryFixed                        // Fixed boundary (dead cells outside)
+	BoundaryCylinder                     // Left and right edges wrap, top and bottom are fixed
+	BoundaryKlein                        // Klein bottle: left and right wrap, top and bottom wrap with a flip
 )
 
 // ToString returns the string representation of boundary type
@@ -29,6 +34,16 @@ func (bt BoundaryType) ToString(language Language) string {
 			return "固定"
 		}
 		return "Fixed"
+	case BoundaryCylinder:
+		if language == Chinese {
+			return "圆柱"
+		}
+		return "Cylinder"
+	case BoundaryKlein:
+		if language == Chinese {
+			return "克莱因瓶"
+		}
+		return "Klein bottle"
 	default:
 		if language == Chinese {
 			return "周期"
@@ -37,6 +52,81 @@ func (bt BoundaryType) ToString(language Language) string {
 	}
 }
 
+// Next returns the boundary type that follows bt in the B key cycle
+func (bt BoundaryType) Next() BoundaryType {
+	return (bt + 1) % (BoundaryKlein + 1)
+}
+
+// Topology represents the cell neighbourhood shape of the grid
+type Topology int
+
+// Topology constants
+const (
+	TopologySquare Topology = iota // Square grid with 8 neighbours (default)
+	TopologyHex                    // Hexagonal grid with 6 neighbours
+)
+
+// ToString returns the string representation of topology
+func (t Topology) ToString(language Language) string {
+	switch t {
+	case TopologyHex:
+		if language == Chinese {
+			return "六边形"
+		}
+		return "hex"
+	default:
+		if language == Chinese {
+			return "方形"
+		}
+		return "square"
+	}
+}
+
+// DefaultRule returns the default rulestring for the topology
+func (t Topology) DefaultRule() string {
+	if t == TopologyHex {
+		return DefaultHexRule
+	}
+	return DefaultRule
+}
+
+// State represents the long-term behaviour detected for the simulation
+type State int
+
+// State constants
+const (
+	StateChaotic     State = iota // No repetition detected yet
+	StateStable                   // Fixed point (period 1)
+	StateOscillating              // Short cycle of period 2 to MaxDetectPeriod
+	StateExtinct                  // No live cells
+)
+
+// ToString returns the string representation of the simulation state
+func (s State) ToString(language Language) string {
+	switch s {
+	case StateStable:
+		if language == Chinese {
+			return "稳定"
+		}
+		return "Stable"
+	case StateOscillating:
+		if language == Chinese {
+			return "振荡"
+		}
+		return "Oscillating"
+	case StateExtinct:
+		if language == Chinese {
+			return "灭绝"
+		}
+		return "Extinct"
+	default:
+		if language == Chinese {
+			return "混沌"
+		}
+		return "Chaotic"
+	}
+}
+
 // Language represents the supported languages
 type Language int
 
@@ -77,8 +167,25 @@ const (
 	PatternOscillator
 	PatternPulsar
 	PatternPentomino
+	PatternLWSS
+	PatternAcorn
+	PatternDiehard
+	PatternCustom // Pattern loaded from a file
 )
 
+// Next returns the pattern that follows p in the P key cycle. A pattern
+// loaded from a file joins the cycle after the built-in ones.
+func (p Pattern) Next(hasCustom bool) Pattern {
+	last := PatternDiehard
+	if hasCustom {
+		last = PatternCustom
+	}
+	if p < PatternRandom || p >= last {
+		return PatternRandom
+	}
+	return p + 1
+}
+
 // ToString returns the string representation of pattern type
 func (p Pattern) ToString(language Language) string {
 	switch p {
@@ -112,6 +219,26 @@ func (p Pattern) ToString(language Language) string {
 			return "五格骨牌"
 		}
 		return "pentomino"
+	case PatternLWSS:
+		if language == Chinese {
+			return "轻量级飞船"
+		}
+		return "lwss"
+	case PatternAcorn:
+		if language == Chinese {
+			return "橡子"
+		}
+		return "acorn"
+	case PatternDiehard:
+		if language == Chinese {
+			return "顽固"
+		}
+		return "diehard"
+	case PatternCustom:
+		if language == Chinese {
+			return "自定义"
+		}
+		return "custom"
 	default:
 		if language == Chinese {
 			return "随机"
@@ -120,6 +247,67 @@ func (p Pattern) ToString(language Language) string {
 	}
 }
 
+// Rule holds the neighbor counts that cause birth and survival in a life-like rule
+type Rule struct {
+	Birth    [9]bool // Birth[n] is true if a dead cell with n live neighbors becomes alive
+	Survival [9]bool // Survival[n] is true if a live cell with n live neighbors survives
+}
+
+// ParseRule parses a rulestring in B/S notation, such as "B3/S23" or "B36/S23"
+func ParseRule(s string) (Rule, error) {
+	var rule Rule
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(s)), "/")
+	if len(parts) != 2 {
+		return rule, fmt.Errorf("invalid rule %q, expected format B3/S23", s)
+	}
+
+	var seenBirth, seenSurvival bool
+	for _, part := range parts {
+		var counts *[9]bool
+		switch {
+		case strings.HasPrefix(part, "B") && !seenBirth:
+			counts = &rule.Birth
+			seenBirth = true
+		case strings.HasPrefix(part, "S") && !seenSurvival:
+			counts = &rule.Survival
+			seenSurvival = true
+		default:
+			return rule, fmt.Errorf("invalid rule %q, expected format B3/S23", s)
+		}
+		for _, c := range part[1:] {
+			if c < '0' || c > '8' {
+				return rule, fmt.Errorf("invalid neighbor count %q in rule %q", c, s)
+			}
+			counts[c-'0'] = true
+		}
+	}
+	return rule, nil
+}
+
+// String returns the rule in canonical B/S notation
+func (r Rule) String() string {
+	var b strings.Builder
+	b.WriteByte('B')
+	for n, ok := range r.Birth {
+		if ok {
+			b.WriteByte(byte('0' + n))
+		}
+	}
+	b.WriteString("/S")
+	for n, ok := range r.Survival {
+		if ok {
+			b.WriteByte(byte('0' + n))
+		}
+	}
+	return b.String()
+}
+
+// ConwayRule is the standard B3/S23 rule of Conway's Game of Life
+var ConwayRule = Rule{
+	Birth:    [9]bool{3: true},
+	Survival: [9]bool{2: true, 3: true},
+}
+
 // Application constants
 const (
 	// Grid and display constants
@@ -128,11 +316,20 @@ const (
 	MinRows     = 10 // Minimum window rows
 	MinCols     = 20 // Minimum window columns
 
-	DefaultLanguage    = English               // Default language
-	DefaultRefreshRate = 50 * time.Millisecond // Default refresh rate in milliseconds
-	MinRefreshRate     = 10 * time.Millisecond // Minimum refresh rate in milliseconds
-	DefaultPattern     = PatternRandom         // Default pattern
-	DefaultBoundary    = BoundaryPeriodic      // Default boundary type
+	MaxFixedSize = 1000 // Largest rows or columns of a fixed simulation size
+
+	DefaultLanguage     = English               // Default language
+	DefaultRefreshRate  = 50 * time.Millisecond // Default refresh rate in milliseconds
+	MinRefreshRate      = 10 * time.Millisecond // Minimum refresh rate in milliseconds
+	DefaultPattern      = PatternRandom         // Default pattern
+	DefaultBoundary     = BoundaryPeriodic      // Default boundary type
+	DefaultRule         = "B3/S23"              // Default rulestring (Conway's Game of Life)
+	DefaultHexRule      = "B2/S34"              // Default rulestring on a hexagonal grid
+	MaxDetectPeriod     = 30                    // Longest oscillation period detected
+	AgeBuckets          = 6                     // Number of age color levels
+	DefaultStepsPerTick = 1                     // Generations advanced per tick
+	MaxStepsPerTick     = 256                   // Maximum generations advanced per tick
+	PanStep             = 4                     // Cells moved by each pan key on the infinite plane (even for hex grids)
 
 	// Colors
 	DefaultAliveColor = "#00FF00" // Default alive cell color (green)
@@ -142,6 +339,10 @@ const (
 	DefaultAliveChar = "█" // Default alive cell character
 	DefaultDeadChar  = " " // Default dead cell character
 
+	// Session files, written to the working directory
+	DefaultStateFile   = "conway-state.json"  // Default file the S key saves the session to
+	DefaultPatternFile = "conway-pattern.rle" // File the E key exports the live cells to
+
 	// Default values
 	DefaultLogFile         = "debug.log"     // Default log file path
 	DefaultProfileInterval = 5 * time.Second // Default profile information output interval
@@ -150,20 +351,60 @@ const (
 
 // DefaultConfig is the default configuration
 var DefaultConfig = Config{
+	Rule:       DefaultRule,
 	AliveColor: DefaultAliveColor,
 	DeadColor:  DefaultDeadColor,
 	AliveChar:  DefaultAliveChar,
 	DeadChar:   DefaultDeadChar,
 	Language:   DefaultLanguage,
+	StateFile:  DefaultStateFile,
 }
 
 // Config holds all application configuration
 type Config struct {
-	AliveColor string
-	DeadColor  string
-	AliveChar  string
-	DeadChar   string
-	Language   Language
+	Rule            string   // Life-like rulestring in B/S notation, empty for the topology default
+	Topology        Topology // Square or hexagonal neighbourhood
+	Seed            int64    // Seed for the random pattern, 0 for time-based
+	AliveColor      string
+	DeadColor       string
+	AliveChar       string
+	DeadChar        string
+	Language        Language
+	MonoCells       bool // Render one terminal cell per rune, even for double-width characters
+	StopWhenSettled bool // Stop stepping once the grid reaches a fixed point or dies out
+	AgeColoring     bool // Color live cells by age instead of a single alive color
+	Infinite        bool // Run on an unbounded plane, with the grid as a panning viewport
+
+	// FixedSize keeps the simulation at this size whatever the terminal size,
+	// centered in the window, so patterns keep their scale. Nil follows the
+	// terminal.
+	FixedSize *GridSize
+
+	// StateFile is where the S key saves the session, to be resumed with
+	// -load-state
+	StateFile string
+}
+
+// GridSize is a simulation size in cells
+type GridSize struct {
+	Rows, Cols int
+}
+
+// SetFixedSize sets the fixed simulation size from ROWSxCOLS, e.g. 40x120.
+// An empty size follows the terminal.
+func (c *Config) SetFixedSize(size string) {
+	c.FixedSize = nil
+	if size == "" {
+		return
+	}
+	rows, cols, ok := strings.Cut(strings.ToLower(size), "x")
+	r, errRows := strconv.Atoi(rows)
+	k, errCols := strconv.Atoi(cols)
+	if !ok || errRows != nil || errCols != nil {
+		fmt.Printf("invalid size %q, must be ROWSxCOLS, following the terminal size\n", size)
+		return
+	}
+	c.FixedSize = &GridSize{Rows: r, Cols: k}
 }
 
 // SetLanguage sets the language
@@ -176,39 +417,44 @@ func (c *Config) SetLanguage(lang string) {
 	}
 }
 
+// SetTopology sets the topology
+func (c *Config) SetTopology(topology string) {
+	if strings.ToLower(topology) == "hex" {
+		c.Topology = TopologyHex
+	} else {
+		c.Topology = TopologySquare
+	}
+}
+
 // Check validates the configuration
 func (c *Config) Check() {
-	if !isValidHexColor(c.AliveColor) {
-		fmt.Printf("invalid alive color format: %s, using default\n", c.AliveColor)
-		c.AliveColor = DefaultAliveColor
+	if c.Topology != TopologySquare && c.Topology != TopologyHex {
+		fmt.Printf("invalid topology %d, using default topology %s\n", c.Topology, TopologySquare.ToString(English))
+		c.Topology = TopologySquare
 	}
-	if !isValidHexColor(c.DeadColor) {
-		fmt.Printf("invalid dead color format: %s, using default\n", c.DeadColor)
-		c.DeadColor = DefaultDeadColor
+	if c.Rule == "" {
+		c.Rule = c.Topology.DefaultRule()
 	}
-	if len([]rune(c.AliveChar)) != 1 {
-		fmt.Printf("invalid alive character format: %s, using default\n", c.AliveChar)
-		c.AliveChar = DefaultAliveChar
+	if _, err := ParseRule(c.Rule); err != nil {
+		fmt.Printf("invalid rule %q: %v, using default rule %s\n", c.Rule, err, c.Topology.DefaultRule())
+		c.Rule = c.Topology.DefaultRule()
 	}
-	if len([]rune(c.DeadChar)) != 1 {
-		fmt.Printf("invalid dead character format: %s, using default\n", c.DeadChar)
-		c.DeadChar = DefaultDeadChar
+	for _, msg := range pkg.Validate(
+		pkg.HexColor("alive", &c.AliveColor, DefaultAliveColor),
+		pkg.HexColor("dead", &c.DeadColor, DefaultDeadColor),
+		pkg.Char("alive", &c.AliveChar, DefaultAliveChar),
+		pkg.Char("dead", &c.DeadChar, DefaultDeadChar),
+		pkg.Language(&c.Language, DefaultLanguage),
+	) {
+		fmt.Println(msg)
 	}
-	if c.Language != English && c.Language != Chinese {
-		fmt.Printf("invalid language %s, must be en or cn, using default language %s\n", c.Language.ToString(c.Language), DefaultLanguage.ToString(c.Language))
-		c.Language = DefaultLanguage
+	if c.FixedSize != nil && (c.FixedSize.Rows <= MinRows || c.FixedSize.Cols <= MinCols ||
+		c.FixedSize.Rows > MaxFixedSize || c.FixedSize.Cols > MaxFixedSize) {
+		fmt.Printf("invalid size %dx%d, must be larger than %dx%d and at most %dx%d, following the terminal size\n",
+			c.FixedSize.Rows, c.FixedSize.Cols, MinRows, MinCols, MaxFixedSize, MaxFixedSize)
+		c.FixedSize = nil
 	}
-}
-
-// isValidHexColor checks if a string is a valid hex color
-func isValidHexColor(color string) bool {
-	if len(color) != 7 || color[0] != '#' {
-		return false
-	}
-	for _, c := range color[1:] {
-		if (c < '0' || c > '9') && (c < 'A' || c > 'F') && (c < 'a' || c > 'f') {
-			return false
-		}
+	if c.StateFile == "" {
+		c.StateFile = DefaultStateFile
 	}
-	return true
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"hash/fnv"
 	"log/slog"
 	"math/rand/v2"
 	"time"
@@ -10,24 +11,51 @@ import (
 
 // GameOfLife represents Conway's Game of Life
 type GameOfLife struct {
-	currentGrid [][]bool
-	nextGrid    [][]bool
-	rows        int
-	cols        int
-	generation  int
-	boundary    BoundaryType
-	pattern     Pattern
+	currentGrid  [][]bool
+	nextGrid     [][]bool
+	rows         int
+	cols         int
+	generation   int
+	boundary     BoundaryType
+	pattern      Pattern
+	rule         Rule     // Birth/survival rule applied in Step
+	topology     Topology // Square or hexagonal neighbourhood
+	custom       [][]bool // Custom pattern loaded from a file
+	age          [][]int  // Generations each live cell has survived (0 for newborn or dead cells)
+	population   int      // Number of live cells, maintained incrementally by Step
+	stepsPerTick int      // Generations advanced by each call to Step
+
+	// Settle detection
+	history         []uint64 // Hashes of the most recent grids, oldest first
+	hashBuf         []byte   // Reusable buffer for packing a grid row
+	state           State    // Detected long-term behaviour
+	period          int      // Oscillation period when state is StateOscillating
+	stopWhenSettled bool     // Whether IsFinished reports a fixed point or extinction
+
+	// Random pattern
+	seed int64      // Seed restored by every random fill, 0 for time-based
+	rng  *rand.Rand // Random source for PatternRandom, created on first use
+
+	// Infinite plane, with the dense grid as a viewport onto it
+	infinite bool
+	live     map[cell]int // Age of each live cell, keyed by plane position
+	nextLive map[cell]int // Live set being built by stepSparse
+	counts   map[cell]int // Live neighbour count of each cell next to a live one
+	viewRow  int          // Plane row shown in the first grid row
+	viewCol  int          // Plane column shown in the first grid column
 }
 
 // NewGameOfLife creates a new Game of Life instance
 func NewGameOfLife(rows, cols int, boundary BoundaryType, pattern Pattern) *GameOfLife {
 	slog.Debug("NewGameOfLife", "rows", rows, "cols", cols, "boundary", boundary, "pattern", pattern)
 	game := &GameOfLife{
-		rows:       rows,
-		cols:       cols,
-		boundary:   boundary,
-		pattern:    pattern,
-		generation: 0,
+		rows:         rows,
+		cols:         cols,
+		boundary:     boundary,
+		pattern:      pattern,
+		rule:         ConwayRule,
+		generation:   0,
+		stepsPerTick: DefaultStepsPerTick,
 	}
 	game.Init()
 	return game
@@ -48,6 +76,14 @@ func (g *GameOfLife) setInitialPattern() {
 		g.setPulsarPattern()
 	case PatternPentomino:
 		g.setPentominoPattern()
+	case PatternLWSS:
+		g.setLWSSPattern()
+	case PatternAcorn:
+		g.setAcornPattern()
+	case PatternDiehard:
+		g.setDiehardPattern()
+	case PatternCustom:
+		g.setCustomPattern()
 	default:
 		g.setRandomPattern()
 	}
@@ -55,19 +91,25 @@ func (g *GameOfLife) setInitialPattern() {
 
 // setRandomPattern creates a random initial pattern
 func (g *GameOfLife) setRandomPattern() {
-	// Use time-based seeding for game randomization (not cryptographic)
-	// #nosec G115 - Conversion is safe for our use case
-	seed := uint64(time.Now().UnixNano())
-
-	// Create a new local random generator with the time-based seed
-	// #nosec G404 - Using math/rand for game simulation, not cryptography
-	rng := rand.New(rand.NewPCG(seed, seed))
+	// A fixed seed restarts the sequence, so every reset gives the same grid;
+	// without one the clock-seeded source carries on from the last fill
+	if g.seed != 0 || g.rng == nil {
+		seed := g.seed
+		if seed == 0 {
+			// Use time-based seeding for game randomization (not cryptographic)
+			seed = time.Now().UnixNano()
+		}
+		// #nosec G115 - Conversion is safe for our use case
+		s := uint64(seed)
+		// #nosec G404 - Using math/rand for game simulation, not cryptography
+		g.rng = rand.New(rand.NewPCG(s, s))
+	}
 
 	// Optimized random generation - use Uint32 for better performance
 	for i := range g.rows {
 		for j := range g.cols {
 			// Use bit manipulation for 30% probability (faster than float comparison)
-			g.currentGrid[i][j] = rng.Uint32()%10 < 3 // 30% probability of being alive
+			g.currentGrid[i][j] = g.rng.Uint32()%10 < 3 // 30% probability of being alive
 		}
 	}
 }
@@ -198,6 +240,84 @@ func (g *GameOfLife) setPentominoPattern() {
 	}
 }
 
+// setLWSSPattern creates a lightweight spaceship pattern
+func (g *GameOfLife) setLWSSPattern() {
+	// Clear the grid first
+	g.clearGrid()
+
+	if g.rows >= 6 && g.cols >= 7 {
+		centerRow := g.rows / 2
+		centerCol := g.cols / 2
+		// Lightweight spaceship (LWSS), travels left:
+		//  X  X
+		// X
+		// X   X
+		// XXXX
+		pattern := [][]bool{
+			{false, true, false, false, true},
+			{true, false, false, false, false},
+			{true, false, false, false, true},
+			{true, true, true, true, false},
+		}
+		g.placePattern(centerRow-2, centerCol-2, pattern)
+	}
+}
+
+// setAcornPattern creates an acorn methuselah pattern
+func (g *GameOfLife) setAcornPattern() {
+	// Clear the grid first
+	g.clearGrid()
+
+	if g.rows >= 5 && g.cols >= 9 {
+		centerRow := g.rows / 2
+		centerCol := g.cols / 2
+		// Acorn pattern:
+		//  X
+		//    X
+		// XX  XXX
+		pattern := [][]bool{
+			{false, true, false, false, false, false, false},
+			{false, false, false, true, false, false, false},
+			{true, true, false, false, true, true, true},
+		}
+		g.placePattern(centerRow-1, centerCol-3, pattern)
+	}
+}
+
+// setDiehardPattern creates a diehard methuselah pattern
+func (g *GameOfLife) setDiehardPattern() {
+	// Clear the grid first
+	g.clearGrid()
+
+	if g.rows >= 5 && g.cols >= 10 {
+		centerRow := g.rows / 2
+		centerCol := g.cols / 2
+		// Diehard pattern, dies out after 130 generations:
+		//       X
+		// XX
+		//  X   XXX
+		pattern := [][]bool{
+			{false, false, false, false, false, false, true, false},
+			{true, true, false, false, false, false, false, false},
+			{false, true, false, false, false, true, true, true},
+		}
+		g.placePattern(centerRow-1, centerCol-4, pattern)
+	}
+}
+
+// setCustomPattern places the loaded custom pattern centered on the grid
+func (g *GameOfLife) setCustomPattern() {
+	// Clear the grid first
+	g.clearGrid()
+
+	if len(g.custom) == 0 {
+		return
+	}
+	startRow := (g.rows - len(g.custom)) / 2
+	startCol := (g.cols - len(g.custom[0])) / 2
+	g.placePattern(startRow, startCol, g.custom)
+}
+
 // placePattern places a pattern at the specified position
 func (g *GameOfLife) placePattern(startRow, startCol int, pattern [][]bool) {
 	for i, row := range pattern {
@@ -220,6 +340,17 @@ func (g *GameOfLife) clearGrid() {
 	}
 }
 
+// hexNeighborOffsets lists the (row, col) offsets of the six neighbours of a
+// cell on a hexagonal grid stored in "odd-r" offset coordinates, where odd rows
+// are shifted right by half a cell. Indexed by row parity:
+//
+//	even rows: NW (-1,-1)  NE (-1,0)  W (0,-1)  E (0,+1)  SW (+1,-1)  SE (+1,0)
+//	odd rows:  NW (-1,0)   NE (-1,+1) W (0,-1)  E (0,+1)  SW (+1,0)   SE (+1,+1)
+var hexNeighborOffsets = [2][6][2]int{
+	{{-1, -1}, {-1, 0}, {0, -1}, {0, 1}, {1, -1}, {1, 0}},
+	{{-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, 0}, {1, 1}},
+}
+
 // countNeighbors counts the number of living neighbors for a cell
 // Optimized version with direct neighbor checking
 func (g *GameOfLife) countNeighbors(row, col int) int {
@@ -228,10 +359,15 @@ func (g *GameOfLife) countNeighbors(row, col int) int {
 		return 0
 	}
 
+	if g.topology == TopologyHex {
+		return g.countHexNeighbors(row, col)
+	}
+
 	count := 0
 
 	// Optimized boundary calculations to avoid repeated modulo operations
-	if g.boundary == BoundaryPeriodic {
+	switch g.boundary {
+	case BoundaryPeriodic:
 		// Pre-calculate boundary indices
 		rowMinus1 := row - 1
 		if rowMinus1 < 0 {
@@ -276,7 +412,13 @@ func (g *GameOfLife) countNeighbors(row, col int) int {
 		if g.currentGrid[rowPlus1][colPlus1] {
 			count++
 		}
-	} else {
+	case BoundaryCylinder, BoundaryKlein:
+		for _, offset := range squareNeighborOffsets {
+			if r, c, ok := g.wrapCell(row+offset[0], col+offset[1]); ok && g.currentGrid[r][c] {
+				count++
+			}
+		}
+	default:
 		// Fixed boundary: direct checks with bounds validation
 		rowStart := row - 1
 		if rowStart < 0 {
@@ -309,25 +451,119 @@ func (g *GameOfLife) countNeighbors(row, col int) int {
 	return count
 }
 
-// Step advances the Game of Life by one generation
+// countHexNeighbors counts the living hex neighbours of a cell. With a
+// periodic boundary, grids with an odd number of rows do not tile cleanly:
+// wrapping from the last row to the first joins two rows of the same parity.
+func (g *GameOfLife) countHexNeighbors(row, col int) int {
+	count := 0
+	for _, offset := range hexNeighborOffsets[row%2] {
+		if r, c, ok := g.wrapCell(row+offset[0], col+offset[1]); ok && g.currentGrid[r][c] {
+			count++
+		}
+	}
+	return count
+}
+
+// wrapCell maps a neighbour position, at most one cell outside the grid, onto
+// the grid according to the boundary. It reports false if the position lies
+// beyond an edge that does not wrap. On a Klein bottle, crossing the top or
+// bottom edge mirrors the column, so a cell leaving at column c comes back at
+// column cols-1-c.
+func (g *GameOfLife) wrapCell(r, c int) (int, int, bool) {
+	switch g.boundary {
+	case BoundaryPeriodic:
+		return (r + g.rows) % g.rows, (c + g.cols) % g.cols, true
+	case BoundaryCylinder:
+		if r < 0 || r >= g.rows {
+			return 0, 0, false
+		}
+		return r, (c + g.cols) % g.cols, true
+	case BoundaryKlein:
+		c = (c + g.cols) % g.cols
+		if r < 0 || r >= g.rows {
+			return (r + g.rows) % g.rows, g.cols - 1 - c, true
+		}
+		return r, c, true
+	default:
+		if r < 0 || r >= g.rows || c < 0 || c >= g.cols {
+			return 0, 0, false
+		}
+		return r, c, true
+	}
+}
+
+// Step advances the Game of Life by StepsPerTick generations, stopping early
+// once the simulation is finished
 func (g *GameOfLife) Step() bool {
-	// Apply Conway's Game of Life rules
+	for range max(g.stepsPerTick, 1) {
+		if g.IsFinished() {
+			break
+		}
+		g.stepOnce()
+	}
+	return true
+}
+
+// SetStepsPerTick sets the number of generations advanced by each Step,
+// clamped to 1..MaxStepsPerTick
+func (g *GameOfLife) SetStepsPerTick(steps int) {
+	g.stepsPerTick = max(min(steps, MaxStepsPerTick), 1)
+}
+
+// SetSeed sets the seed used by PatternRandom from the next Init or Reset on;
+// 0 seeds from the clock
+func (g *GameOfLife) SetSeed(seed int64) {
+	g.seed = seed
+}
+
+// GetSeed returns the PatternRandom seed, 0 for time-based
+func (g *GameOfLife) GetSeed() int64 {
+	return g.seed
+}
+
+// GetStepsPerTick returns the number of generations advanced by each Step
+func (g *GameOfLife) GetStepsPerTick() int {
+	return g.stepsPerTick
+}
+
+// stepOnce advances the Game of Life by one generation
+func (g *GameOfLife) stepOnce() {
+	if g.infinite {
+		g.stepSparse()
+		g.generation++
+		g.detectState()
+		return
+	}
+
+	// Apply the life-like rule (B3/S23 for Conway's Game of Life)
 	for i := range g.rows {
 		for j := range g.cols {
 			neighbors := g.countNeighbors(i, j)
 			currentCell := g.currentGrid[i][j]
 
-			// Conway's Game of Life rules:
-			// 1. Any live cell with 2 or 3 live neighbors survives
-			// 2. Any dead cell with exactly 3 live neighbors becomes a live cell
+			// Life-like rules:
+			// 1. Any live cell whose neighbor count is in the survival set survives
+			// 2. Any dead cell whose neighbor count is in the birth set becomes a live cell
 			// 3. All other live cells die, and all other dead cells stay dead
 
 			if currentCell {
 				// Cell is currently alive
-				g.nextGrid[i][j] = (neighbors == 2 || neighbors == 3)
+				g.nextGrid[i][j] = g.rule.Survival[neighbors]
 			} else {
 				// Cell is currently dead
-				g.nextGrid[i][j] = (neighbors == 3)
+				g.nextGrid[i][j] = g.rule.Birth[neighbors]
+			}
+
+			// Surviving cells age, births and deaths start over
+			switch next := g.nextGrid[i][j]; {
+			case currentCell && next:
+				g.age[i][j]++
+			case next:
+				g.population++
+				g.age[i][j] = 0
+			case currentCell:
+				g.population--
+				g.age[i][j] = 0
 			}
 		}
 	}
@@ -336,7 +572,93 @@ func (g *GameOfLife) Step() bool {
 	g.currentGrid, g.nextGrid = g.nextGrid, g.currentGrid
 
 	g.generation++
-	return true
+	g.detectState()
+}
+
+// hashGrid returns a hash of the current grid and whether any cell is alive
+func (g *GameOfLife) hashGrid() (uint64, bool) {
+	if g.infinite {
+		return g.hashLive(), len(g.live) > 0
+	}
+
+	h := fnv.New64a()
+	alive := false
+	for _, row := range g.currentGrid {
+		// Pack eight cells per byte
+		g.hashBuf = g.hashBuf[:0]
+		var b byte
+		for j, cell := range row {
+			if cell {
+				b |= 1 << (j % 8)
+				alive = true
+			}
+			if j%8 == 7 {
+				g.hashBuf = append(g.hashBuf, b)
+				b = 0
+			}
+		}
+		g.hashBuf = append(g.hashBuf, b)
+		_, _ = h.Write(g.hashBuf)
+	}
+	return h.Sum64(), alive
+}
+
+// resetHistory clears settle detection and records the current grid as the first entry
+func (g *GameOfLife) resetHistory() {
+	g.history = g.history[:0]
+	g.state = StateChaotic
+	g.period = 0
+	hash, alive := g.hashGrid()
+	if !alive {
+		g.state = StateExtinct
+	}
+	g.history = append(g.history, hash)
+}
+
+// detectState compares the current grid against recent grids to detect a
+// fixed point, a short oscillation, or extinction
+func (g *GameOfLife) detectState() {
+	hash, alive := g.hashGrid()
+
+	g.state = StateChaotic
+	g.period = 0
+	if !alive {
+		g.state = StateExtinct
+	} else {
+		for p := 1; p <= len(g.history); p++ {
+			if g.history[len(g.history)-p] == hash {
+				g.period = p
+				if p == 1 {
+					g.state = StateStable
+				} else {
+					g.state = StateOscillating
+				}
+				break
+			}
+		}
+	}
+
+	if len(g.history) == MaxDetectPeriod {
+		copy(g.history, g.history[1:])
+		g.history = g.history[:MaxDetectPeriod-1]
+	}
+	g.history = append(g.history, hash)
+}
+
+// GetState returns the detected simulation state and, for oscillations, the period
+func (g *GameOfLife) GetState() (State, int) {
+	return g.state, g.period
+}
+
+// SetStopWhenSettled controls whether IsFinished reports a fixed point or extinction
+func (g *GameOfLife) SetStopWhenSettled(stop bool) {
+	g.stopWhenSettled = stop
+}
+
+// IsFinished reports whether the simulation has settled into a fixed point or
+// died out. It always returns false unless stopping when settled is enabled.
+func (g *GameOfLife) IsFinished() bool {
+	return g.stopWhenSettled && (g.state == StateStable || g.state == StateExtinct)
 }
 
 // GetCurrentGrid returns the current grid state
@@ -344,11 +666,122 @@ func (g *GameOfLife) GetCurrentGrid() [][]bool {
 	return g.currentGrid
 }
 
+// SetRule sets the birth/survival rule used by Step
+func (g *GameOfLife) SetRule(rule Rule) {
+	g.rule = rule
+}
+
+// GetRule returns the active birth/survival rule
+func (g *GameOfLife) GetRule() Rule {
+	return g.rule
+}
+
+// SetTopology sets the neighbourhood shape used by Step
+func (g *GameOfLife) SetTopology(topology Topology) {
+	g.topology = topology
+}
+
+// GetTopology returns the active neighbourhood shape
+func (g *GameOfLife) GetTopology() Topology {
+	return g.topology
+}
+
+// GetSize returns the grid size
+func (g *GameOfLife) GetSize() (int, int) {
+	return g.rows, g.cols
+}
+
+// GetBoundary returns the boundary type
+func (g *GameOfLife) GetBoundary() BoundaryType {
+	return g.boundary
+}
+
+// GetPattern returns the pattern the grid was last reset to
+func (g *GameOfLife) GetPattern() Pattern {
+	return g.pattern
+}
+
+// HasCustomPattern reports whether a pattern has been loaded from a file
+func (g *GameOfLife) HasCustomPattern() bool {
+	return len(g.custom) > 0
+}
+
+// GetAges returns the age grid, counting generations each live cell has survived
+func (g *GameOfLife) GetAges() [][]int {
+	return g.age
+}
+
+// clearAges resets the age of every cell
+func (g *GameOfLife) clearAges() {
+	for i := range g.rows {
+		for j := range g.cols {
+			g.age[i][j] = 0
+		}
+	}
+}
+
+// ToggleCell flips the cell at the given position without advancing the generation
+func (g *GameOfLife) ToggleCell(row, col int) {
+	if row < 0 || row >= g.rows || col < 0 || col >= g.cols {
+		return
+	}
+
+	g.currentGrid[row][col] = !g.currentGrid[row][col]
+	g.age[row][col] = 0
+	if g.currentGrid[row][col] {
+		g.population++
+	} else {
+		g.population--
+	}
+	if g.infinite {
+		pos := cell{g.viewRow + row, g.viewCol + col}
+		if g.currentGrid[row][col] {
+			g.live[pos] = 0
+		} else {
+			delete(g.live, pos)
+		}
+	}
+	g.resetHistory()
+}
+
+// GetPopulation returns the number of live cells
+func (g *GameOfLife) GetPopulation() int {
+	return g.population
+}
+
+// countPopulation recounts the live cells after the grid is replaced wholesale
+func (g *GameOfLife) countPopulation() {
+	g.population = 0
+	for _, row := range g.currentGrid {
+		for _, cell := range row {
+			if cell {
+				g.population++
+			}
+		}
+	}
+}
+
 // GetGeneration returns the current generation number
 func (g *GameOfLife) GetGeneration() int {
 	return g.generation
 }
 
+// Describe returns the current state as structured data
+func (g *GameOfLife) Describe() map[string]any {
+	state, period := g.GetState()
+	return map[string]any{
+		"generation": g.generation,
+		"population": g.population,
+		"rows":       g.rows,
+		"cols":       g.cols,
+		"rule":       g.rule.String(),
+		"topology":   g.topology.ToString(English),
+		"boundary":   g.boundary.ToString(English),
+		"state":      state.ToString(English),
+		"period":     period,
+	}
+}
+
 // Init initializes the game of life
 func (g *GameOfLife) Init() {
 	slog.Debug("GameOfLife Init", "rows", g.rows, "cols", g.cols, "boundary", g.boundary, "pattern", g.pattern)
@@ -362,11 +795,16 @@ func (g *GameOfLife) Init() {
 	}
 	g.currentGrid = make([][]bool, g.rows)
 	g.nextGrid = make([][]bool, g.rows)
+	g.age = make([][]int, g.rows)
 	for i := range g.rows {
 		g.currentGrid[i] = make([]bool, g.cols)
 		g.nextGrid[i] = make([]bool, g.cols)
+		g.age[i] = make([]int, g.cols)
 	}
 	g.setInitialPattern()
+	g.loadLive()
+	g.countPopulation()
+	g.resetHistory()
 }
 
 // Reset resets the game to its initial state
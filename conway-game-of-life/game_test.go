@@ -1,7 +1,11 @@
 package main
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/telepair/go-playground/pkg"
 )
 
 // Test NewGameOfLife creation
@@ -92,6 +96,35 @@ func TestGameOfLife_Reset(t *testing.T) {
 	}
 }
 
+// Test that equal seeds give identical random grids, including after Reset
+func TestGameOfLife_Seed(t *testing.T) {
+	newSeeded := func(seed int64) *GameOfLife {
+		game := NewGameOfLife(20, 30, BoundaryPeriodic, PatternRandom)
+		game.SetSeed(seed)
+		game.Reset(20, 30, BoundaryPeriodic, PatternRandom)
+		return game
+	}
+
+	a, b := newSeeded(7), newSeeded(7)
+	if !reflect.DeepEqual(a.GetCurrentGrid(), b.GetCurrentGrid()) {
+		t.Error("Expected equal seeds to give identical grids")
+	}
+
+	first := make([][]bool, len(a.GetCurrentGrid()))
+	for i, row := range a.GetCurrentGrid() {
+		first[i] = append([]bool(nil), row...)
+	}
+	a.Step()
+	a.Reset(20, 30, BoundaryPeriodic, PatternRandom)
+	if !reflect.DeepEqual(a.GetCurrentGrid(), first) {
+		t.Error("Expected Reset to reproduce the seeded grid")
+	}
+
+	if reflect.DeepEqual(newSeeded(8).GetCurrentGrid(), first) {
+		t.Error("Expected different seeds to give different grids")
+	}
+}
+
 // Test Init with invalid parameters
 func TestGameOfLife_InitInvalidParams(t *testing.T) {
 	game := &GameOfLife{
@@ -167,6 +200,87 @@ func TestGameOfLife_CountNeighborsFixed(t *testing.T) {
 	}
 }
 
+// Test where the neighbours of the top-left corner land under each boundary
+func TestGameOfLife_WrapCellCorner(t *testing.T) {
+	tests := []struct {
+		boundary BoundaryType
+		r, c     int
+		wantR    int
+		wantC    int
+		wantOK   bool
+	}{
+		{BoundaryPeriodic, -1, -1, 19, 29, true},
+		{BoundaryPeriodic, -1, 1, 19, 1, true},
+		{BoundaryFixed, -1, -1, 0, 0, false},
+		{BoundaryFixed, 0, -1, 0, 0, false},
+		{BoundaryCylinder, -1, -1, 0, 0, false},
+		{BoundaryCylinder, 0, -1, 0, 29, true},
+		{BoundaryCylinder, 1, -1, 1, 29, true},
+		{BoundaryKlein, -1, -1, 19, 0, true},
+		{BoundaryKlein, -1, 0, 19, 29, true},
+		{BoundaryKlein, -1, 1, 19, 28, true},
+		{BoundaryKlein, 0, -1, 0, 29, true},
+		{BoundaryKlein, 20, 29, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		game := NewGameOfLife(20, 30, tt.boundary, PatternRandom)
+		r, c, ok := game.wrapCell(tt.r, tt.c)
+		if ok != tt.wantOK || (ok && (r != tt.wantR || c != tt.wantC)) {
+			t.Errorf("%s: (%d,%d) wrapped to (%d,%d,%v), expected (%d,%d,%v)",
+				tt.boundary.ToString(English), tt.r, tt.c, r, c, ok, tt.wantR, tt.wantC, tt.wantOK)
+		}
+	}
+}
+
+// Test that corner cells count the wrapped neighbours of each boundary
+func TestGameOfLife_CountNeighborsCorner(t *testing.T) {
+	tests := []struct {
+		boundary BoundaryType
+		expected int
+	}{
+		{BoundaryPeriodic, 8},
+		{BoundaryFixed, 3},
+		{BoundaryCylinder, 5},
+		{BoundaryKlein, 8},
+	}
+
+	for _, tt := range tests {
+		game := NewGameOfLife(20, 30, tt.boundary, PatternRandom)
+		for i := range game.rows {
+			for j := range game.cols {
+				game.currentGrid[i][j] = true
+			}
+		}
+		if n := game.countNeighbors(0, 0); n != tt.expected {
+			t.Errorf("%s: expected %d neighbours, got %d", tt.boundary.ToString(English), tt.expected, n)
+		}
+
+		// Only the bottom-right cell alive: a diagonal neighbour on the torus,
+		// directly above after the Klein bottle's flip, and out of reach otherwise
+		game.clearGrid()
+		game.currentGrid[19][29] = true
+		want := 0
+		if tt.boundary == BoundaryPeriodic || tt.boundary == BoundaryKlein {
+			want = 1
+		}
+		if n := game.countNeighbors(0, 0); n != want {
+			t.Errorf("%s: expected %d neighbour from the opposite corner, got %d", tt.boundary.ToString(English), want, n)
+		}
+	}
+}
+
+// Test that the B key cycle visits every boundary
+func TestBoundaryType_Next(t *testing.T) {
+	bt := BoundaryPeriodic
+	for _, want := range []BoundaryType{BoundaryFixed, BoundaryCylinder, BoundaryKlein, BoundaryPeriodic} {
+		bt = bt.Next()
+		if bt != want {
+			t.Fatalf("Expected %s, got %s", want.ToString(English), bt.ToString(English))
+		}
+	}
+}
+
 // Test Step function with known patterns
 func TestGameOfLife_Step(t *testing.T) {
 	game := NewGameOfLife(5, 5, BoundaryFixed, PatternRandom)
@@ -309,6 +423,377 @@ func TestGameOfLife_SetInitialPattern(t *testing.T) {
 	}
 }
 
+// Test live-cell counts of the spaceship and methuselah patterns
+func TestGameOfLife_PatternLiveCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  Pattern
+		expected int
+	}{
+		{"Glider", PatternGlider, 5},
+		{"Lightweight spaceship", PatternLWSS, 9},
+		{"Acorn", PatternAcorn, 7},
+		{"Diehard", PatternDiehard, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGameOfLife(20, 30, BoundaryFixed, tt.pattern)
+			if count := countAlive(game); count != tt.expected {
+				t.Errorf("Expected %d live cells, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+// Test the known evolution of the LWSS and diehard patterns
+func TestGameOfLife_PatternEvolution(t *testing.T) {
+	// A lightweight spaceship has period 4 and keeps its 9 cells
+	lwss := NewGameOfLife(20, 30, BoundaryFixed, PatternLWSS)
+	for range 4 {
+		lwss.Step()
+	}
+	if count := countAlive(lwss); count != 9 {
+		t.Errorf("Expected LWSS to keep 9 live cells after 4 generations, got %d", count)
+	}
+
+	// Diehard disappears after 130 generations
+	diehard := NewGameOfLife(40, 60, BoundaryFixed, PatternDiehard)
+	for range 129 {
+		diehard.Step()
+	}
+	if countAlive(diehard) == 0 {
+		t.Error("Diehard should still be alive after 129 generations")
+	}
+	diehard.Step()
+	if count := countAlive(diehard); count != 0 {
+		t.Errorf("Expected diehard to die out after 130 generations, got %d live cells", count)
+	}
+}
+
+// Test rulestring parsing
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"B3/S23", "B3/S23", false},
+		{"b36/s23", "B36/S23", false},
+		{"B2/S", "B2/S", false},
+		{"S23/B3", "B3/S23", false},
+		{" B3/S23 ", "B3/S23", false},
+		{"", "", true},
+		{"B3", "", true},
+		{"B3/S29", "", true},
+		{"B3/B23", "", true},
+		{"X3/S23", "", true},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseRule(tt.input)
+		if tt.hasError {
+			if err == nil {
+				t.Errorf("Expected error for rule %q", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for rule %q: %v", tt.input, err)
+			continue
+		}
+		if rule.String() != tt.expected {
+			t.Errorf("For rule %q, expected %s, got %s", tt.input, tt.expected, rule.String())
+		}
+	}
+}
+
+// Test that invalid rules fall back to the default
+func TestConfig_CheckRule(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Rule = "invalid"
+	cfg.Check()
+	if cfg.Rule != DefaultRule {
+		t.Errorf("Expected rule %s, got %s", DefaultRule, cfg.Rule)
+	}
+}
+
+// Test Step with a non-Conway rule
+func TestGameOfLife_StepSeedsRule(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+	game.clearGrid()
+
+	rule, err := ParseRule("B2/S")
+	if err != nil {
+		t.Fatalf("ParseRule failed: %v", err)
+	}
+	game.SetRule(rule)
+
+	// Two horizontally adjacent cells: under Seeds both die, and the four cells
+	// directly above and below them are born (each has exactly 2 neighbors)
+	game.currentGrid[10][10] = true
+	game.currentGrid[10][11] = true
+	game.Step()
+
+	if game.currentGrid[10][10] || game.currentGrid[10][11] {
+		t.Error("Live cells should not survive under B2/S")
+	}
+	for _, pos := range [][2]int{{9, 10}, {9, 11}, {11, 10}, {11, 11}} {
+		if !game.currentGrid[pos[0]][pos[1]] {
+			t.Errorf("Expected cell (%d,%d) to be born under B2/S", pos[0], pos[1])
+		}
+	}
+}
+
+// Test detection of stable, oscillating, extinct, and chaotic states
+func TestGameOfLife_DetectState(t *testing.T) {
+	tests := []struct {
+		name           string
+		cells          [][2]int
+		steps          int
+		expectedState  State
+		expectedPeriod int
+	}{
+		{"Block is stable", [][2]int{{10, 10}, {10, 11}, {11, 10}, {11, 11}}, 1, StateStable, 1},
+		{"Blinker oscillates", [][2]int{{10, 9}, {10, 10}, {10, 11}}, 2, StateOscillating, 2},
+		{"Single cell dies out", [][2]int{{10, 10}}, 1, StateExtinct, 0},
+		{"Glider is chaotic", [][2]int{{5, 6}, {6, 7}, {7, 5}, {7, 6}, {7, 7}}, 4, StateChaotic, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+			game.clearGrid()
+			for _, pos := range tt.cells {
+				game.currentGrid[pos[0]][pos[1]] = true
+			}
+			game.resetHistory()
+
+			for range tt.steps {
+				game.Step()
+			}
+
+			state, period := game.GetState()
+			if state != tt.expectedState {
+				t.Errorf("Expected state %s, got %s", tt.expectedState.ToString(English), state.ToString(English))
+			}
+			if period != tt.expectedPeriod {
+				t.Errorf("Expected period %d, got %d", tt.expectedPeriod, period)
+			}
+		})
+	}
+}
+
+// Test that IsFinished is gated by the stop-when-settled flag
+func TestGameOfLife_IsFinished(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+	game.clearGrid()
+	game.currentGrid[10][10] = true
+	game.resetHistory()
+	game.Step()
+
+	if game.IsFinished() {
+		t.Error("IsFinished should be false when stopping is disabled")
+	}
+
+	game.SetStopWhenSettled(true)
+	if !game.IsFinished() {
+		t.Error("IsFinished should be true for an extinct grid when stopping is enabled")
+	}
+
+	// Oscillators never count as finished
+	game.Reset(20, 30, BoundaryFixed, PatternOscillator)
+	for range 4 {
+		game.Step()
+	}
+	if state, _ := game.GetState(); state != StateOscillating {
+		t.Errorf("Expected oscillating state, got %s", state.ToString(English))
+	}
+	if game.IsFinished() {
+		t.Error("IsFinished should be false for an oscillating grid")
+	}
+}
+
+// Test that cell ages increase for survivors and reset on birth and death
+func TestGameOfLife_Age(t *testing.T) {
+	game := NewGameOfLife(10, 10, BoundaryFixed, PatternOscillator)
+	game.clearGrid()
+
+	// Block (still life) next to a blinker (period 2)
+	for _, pos := range [][2]int{{1, 1}, {1, 2}, {2, 1}, {2, 2}, {6, 5}, {6, 6}, {6, 7}} {
+		game.currentGrid[pos[0]][pos[1]] = true
+	}
+
+	for range 3 {
+		game.Step()
+	}
+
+	ages := game.GetAges()
+	if ages[1][1] != 3 {
+		t.Errorf("Expected block cell age 3, got %d", ages[1][1])
+	}
+	// Blinker center always survives, its ends are reborn every generation
+	if ages[6][6] != 3 {
+		t.Errorf("Expected blinker center age 3, got %d", ages[6][6])
+	}
+	if ages[5][6] != 0 || ages[6][5] != 0 {
+		t.Errorf("Expected newborn and dead cells to have age 0, got %d and %d", ages[5][6], ages[6][5])
+	}
+
+	game.Reset(10, 10, BoundaryFixed, PatternOscillator)
+	if game.GetAges()[1][1] != 0 {
+		t.Error("Expected ages to be cleared on reset")
+	}
+}
+
+// Test the mapping of ages to color levels
+func TestAgeBucket(t *testing.T) {
+	tests := []struct {
+		age      int
+		expected int
+	}{
+		{0, 0}, {1, 1}, {2, 2}, {3, 2}, {4, 3}, {8, 4}, {16, 5}, {1000, AgeBuckets - 1},
+	}
+	for _, tt := range tests {
+		if got := ageBucket(tt.age); got != tt.expected {
+			t.Errorf("ageBucket(%d) = %d, expected %d", tt.age, got, tt.expected)
+		}
+	}
+}
+
+// Test that the live population is tracked incrementally across steps
+func TestGameOfLife_Population(t *testing.T) {
+	game := NewGameOfLife(10, 10, BoundaryFixed, PatternGlider)
+	if game.GetPopulation() != 5 {
+		t.Errorf("Expected glider population 5, got %d", game.GetPopulation())
+	}
+
+	// A block is a still life and keeps exactly four live cells
+	if err := game.LoadRLE(strings.NewReader("x = 2, y = 2\n2o$2o!")); err != nil {
+		t.Fatalf("LoadRLE failed: %v", err)
+	}
+	for step := range 5 {
+		if game.GetPopulation() != 4 {
+			t.Errorf("Step %d: expected block population 4, got %d", step, game.GetPopulation())
+		}
+		game.Step()
+	}
+
+	// Incremental count matches a full scan on a chaotic pattern
+	game.Reset(20, 20, BoundaryPeriodic, PatternRandom)
+	for range 20 {
+		game.Step()
+		if game.GetPopulation() != countAlive(game) {
+			t.Fatalf("Expected population %d, got %d", countAlive(game), game.GetPopulation())
+		}
+	}
+}
+
+// Test hex neighbour counting for interior cells on even and odd rows
+func TestGameOfLife_CountHexNeighbors(t *testing.T) {
+	for _, boundary := range []BoundaryType{BoundaryPeriodic, BoundaryFixed} {
+		game := NewGameOfLife(20, 30, boundary, PatternGlider)
+		game.SetTopology(TopologyHex)
+		game.clearGrid()
+
+		// Even row 4: neighbours of (4,10) are (3,9) (3,10) (4,9) (4,11) (5,9) (5,10)
+		for _, pos := range [][2]int{{3, 9}, {3, 10}, {4, 9}, {4, 11}, {5, 9}, {5, 10}} {
+			game.currentGrid[pos[0]][pos[1]] = true
+		}
+		if n := game.countNeighbors(4, 10); n != 6 {
+			t.Errorf("%v: expected 6 neighbours on even row, got %d", boundary, n)
+		}
+		// Square-only diagonals (3,11) and (5,11) are not hex neighbours of (4,10)
+		game.clearGrid()
+		game.currentGrid[3][11] = true
+		game.currentGrid[5][11] = true
+		if n := game.countNeighbors(4, 10); n != 0 {
+			t.Errorf("%v: expected 0 neighbours on even row, got %d", boundary, n)
+		}
+
+		// Odd row 5: neighbours of (5,10) are (4,10) (4,11) (5,9) (5,11) (6,10) (6,11)
+		game.clearGrid()
+		for _, pos := range [][2]int{{4, 10}, {4, 11}, {5, 9}, {5, 11}, {6, 10}, {6, 11}} {
+			game.currentGrid[pos[0]][pos[1]] = true
+		}
+		if n := game.countNeighbors(5, 10); n != 6 {
+			t.Errorf("%v: expected 6 neighbours on odd row, got %d", boundary, n)
+		}
+	}
+}
+
+// Test hex neighbour counting for boundary cells
+func TestGameOfLife_CountHexNeighborsBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		boundary BoundaryType
+		row, col int
+		expected int
+	}{
+		{"Periodic top-left corner", BoundaryPeriodic, 0, 0, 6},
+		{"Periodic bottom-right corner", BoundaryPeriodic, 19, 29, 6},
+		{"Periodic right edge odd row", BoundaryPeriodic, 5, 29, 6},
+		{"Fixed top-left corner", BoundaryFixed, 0, 0, 2},
+		{"Fixed bottom-right corner", BoundaryFixed, 19, 29, 2},
+		{"Fixed right edge odd row", BoundaryFixed, 5, 29, 3},
+		{"Fixed left edge odd row", BoundaryFixed, 5, 0, 5},
+		{"Fixed top edge", BoundaryFixed, 0, 10, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGameOfLife(20, 30, tt.boundary, PatternGlider)
+			game.SetTopology(TopologyHex)
+			for i := range game.rows {
+				for j := range game.cols {
+					game.currentGrid[i][j] = true
+				}
+			}
+			if n := game.countNeighbors(tt.row, tt.col); n != tt.expected {
+				t.Errorf("Expected %d neighbours at (%d,%d), got %d", tt.expected, tt.row, tt.col, n)
+			}
+		})
+	}
+}
+
+// Test the topology default rule and the hex rule in Step
+func TestGameOfLife_HexStep(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Rule = ""
+	cfg.SetTopology("hex")
+	cfg.Check()
+	if cfg.Rule != DefaultHexRule {
+		t.Errorf("Expected hex default rule %s, got %s", DefaultHexRule, cfg.Rule)
+	}
+
+	rule, err := ParseRule(cfg.Rule)
+	if err != nil {
+		t.Fatalf("ParseRule failed: %v", err)
+	}
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+	game.SetTopology(TopologyHex)
+	game.SetRule(rule)
+	game.clearGrid()
+
+	// Two adjacent cells die (one neighbour each) and give birth to the two
+	// cells sharing both of them as neighbours
+	game.currentGrid[4][10] = true
+	game.currentGrid[4][11] = true
+	game.countPopulation()
+	game.Step()
+
+	grid := game.GetCurrentGrid()
+	if grid[4][10] || grid[4][11] {
+		t.Error("Expected isolated pair to die under S34")
+	}
+	if !grid[3][10] || !grid[5][10] {
+		t.Error("Expected births at (3,10) and (5,10) under B2")
+	}
+	if game.GetPopulation() != 2 {
+		t.Errorf("Expected population 2, got %d", game.GetPopulation())
+	}
+}
+
 // Benchmark tests
 func BenchmarkNewGameOfLife(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -395,3 +880,59 @@ func BenchmarkGameOfLife_InitGlider(b *testing.B) {
 		game.Init()
 	}
 }
+
+// Test the structured state snapshot
+func TestGameOfLife_Describe(t *testing.T) {
+	var g pkg.Describable = NewGameOfLife(30, 30, BoundaryPeriodic, PatternGlider)
+	game := g.(*GameOfLife)
+	for range 4 {
+		game.Step()
+	}
+
+	desc := g.Describe()
+	want := map[string]any{
+		"generation": 4,
+		"population": 5,
+		"rows":       30,
+		"cols":       30,
+		"rule":       "B3/S23",
+		"boundary":   "Periodic",
+	}
+	for key, value := range want {
+		if desc[key] != value {
+			t.Errorf("Describe()[%q] = %v, want %v", key, desc[key], value)
+		}
+	}
+}
+
+// Test advancing several generations per Step
+func TestGameOfLife_StepsPerTick(t *testing.T) {
+	batched := NewGameOfLife(30, 30, BoundaryPeriodic, PatternGlider)
+	single := NewGameOfLife(30, 30, BoundaryPeriodic, PatternGlider)
+
+	batched.SetStepsPerTick(8)
+	batched.Step()
+	for range 8 {
+		single.Step()
+	}
+
+	if batched.GetGeneration() != 8 {
+		t.Errorf("Expected generation 8 after one batched step, got %d", batched.GetGeneration())
+	}
+	for i, row := range single.GetCurrentGrid() {
+		for j, cell := range row {
+			if batched.GetCurrentGrid()[i][j] != cell {
+				t.Fatalf("Batched grid differs from single steps at (%d,%d)", i, j)
+			}
+		}
+	}
+
+	batched.SetStepsPerTick(0)
+	if batched.GetStepsPerTick() != 1 {
+		t.Errorf("Expected steps per tick clamped to 1, got %d", batched.GetStepsPerTick())
+	}
+	batched.SetStepsPerTick(MaxStepsPerTick * 2)
+	if batched.GetStepsPerTick() != MaxStepsPerTick {
+		t.Errorf("Expected steps per tick clamped to %d, got %d", MaxStepsPerTick, batched.GetStepsPerTick())
+	}
+}
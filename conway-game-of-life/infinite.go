@@ -0,0 +1,158 @@
+package main
+
+// cell is the absolute position of a cell on the infinite plane
+type cell struct {
+	row, col int
+}
+
+// squareNeighborOffsets lists the (row, col) offsets of the eight neighbours
+// of a cell on a square grid
+var squareNeighborOffsets = [8][2]int{
+	{-1, -1}, {-1, 0}, {-1, 1},
+	{0, -1}, {0, 1},
+	{1, -1}, {1, 0}, {1, 1},
+}
+
+// SetInfinite switches between the bounded dense grid and the unbounded
+// sparse plane. In infinite mode the dense grid is a viewport onto the plane
+// and the boundary type is ignored. Birth on zero neighbours (B0) is not
+// supported on the infinite plane and is ignored.
+func (g *GameOfLife) SetInfinite(infinite bool) {
+	g.infinite = infinite
+	if infinite {
+		g.loadLive()
+	} else {
+		g.live, g.nextLive, g.counts = nil, nil, nil
+	}
+	g.resetHistory()
+}
+
+// IsInfinite reports whether the game runs on the unbounded sparse plane
+func (g *GameOfLife) IsInfinite() bool {
+	return g.infinite
+}
+
+// loadLive rebuilds the live set from the dense grid, placing the view at the origin
+func (g *GameOfLife) loadLive() {
+	if !g.infinite {
+		return
+	}
+	g.viewRow, g.viewCol = 0, 0
+	if g.live == nil {
+		g.live = make(map[cell]int)
+		g.nextLive = make(map[cell]int)
+		g.counts = make(map[cell]int)
+	}
+	clear(g.live)
+	for i, row := range g.currentGrid {
+		for j, alive := range row {
+			if alive {
+				g.live[cell{i, j}] = g.age[i][j]
+			}
+		}
+	}
+}
+
+// refreshView copies the part of the plane under the view into the dense grid
+func (g *GameOfLife) refreshView() {
+	for i := range g.rows {
+		for j := range g.cols {
+			age, alive := g.live[cell{g.viewRow + i, g.viewCol + j}]
+			g.currentGrid[i][j] = alive
+			g.age[i][j] = age
+		}
+	}
+}
+
+// stepSparse advances the infinite plane by one generation
+func (g *GameOfLife) stepSparse() {
+	clear(g.counts)
+	for c := range g.live {
+		if g.topology == TopologyHex {
+			for _, offset := range hexNeighborOffsets[c.row&1] {
+				g.counts[cell{c.row + offset[0], c.col + offset[1]}]++
+			}
+		} else {
+			for _, offset := range squareNeighborOffsets {
+				g.counts[cell{c.row + offset[0], c.col + offset[1]}]++
+			}
+		}
+	}
+
+	clear(g.nextLive)
+	for c, n := range g.counts {
+		age, alive := g.live[c]
+		switch {
+		case alive && g.rule.Survival[n]:
+			g.nextLive[c] = age + 1
+		case !alive && g.rule.Birth[n]:
+			g.nextLive[c] = 0
+		}
+	}
+	// Isolated cells have no entry in counts
+	if g.rule.Survival[0] {
+		for c, age := range g.live {
+			if _, ok := g.counts[c]; !ok {
+				g.nextLive[c] = age + 1
+			}
+		}
+	}
+
+	g.live, g.nextLive = g.nextLive, g.live
+	g.population = len(g.live)
+	g.refreshView()
+}
+
+// hashLive returns an order-independent hash of the live set
+func (g *GameOfLife) hashLive() uint64 {
+	var sum uint64
+	for c := range g.live {
+		// #nosec G115 - Wrapping conversion is intended for hashing
+		h := uint64(c.row)*0x9E3779B97F4A7C15 ^ uint64(c.col)*0xC2B2AE3D27D4EB4F
+		h ^= h >> 31
+		h *= 0xBF58476D1CE4E5B9
+		h ^= h >> 29
+		sum += h
+	}
+	return sum
+}
+
+// PanView moves the view over the infinite plane by the given number of rows
+// and columns. On a hex grid dr should be even so rows keep their parity.
+func (g *GameOfLife) PanView(dr, dc int) {
+	if !g.infinite {
+		return
+	}
+	g.viewRow += dr
+	g.viewCol += dc
+	g.refreshView()
+}
+
+// CenterView moves the view so the bounding box of the live cells is centered
+func (g *GameOfLife) CenterView() {
+	if !g.infinite || len(g.live) == 0 {
+		return
+	}
+	first := true
+	var minRow, maxRow, minCol, maxCol int
+	for c := range g.live {
+		if first {
+			minRow, maxRow, minCol, maxCol = c.row, c.row, c.col, c.col
+			first = false
+			continue
+		}
+		minRow = min(minRow, c.row)
+		maxRow = max(maxRow, c.row)
+		minCol = min(minCol, c.col)
+		maxCol = max(maxCol, c.col)
+	}
+	// Keep the view on an even row so hex rows keep their parity
+	g.viewRow = ((minRow+maxRow)/2 - g.rows/2) &^ 1
+	g.viewCol = (minCol+maxCol)/2 - g.cols/2
+	g.refreshView()
+}
+
+// GetViewOrigin returns the plane position of the top-left cell of the view
+func (g *GameOfLife) GetViewOrigin() (int, int) {
+	return g.viewRow, g.viewCol
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test that a glider keeps travelling past the edge of the view on the infinite plane
+func TestGameOfLife_InfiniteGlider(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+	game.SetInfinite(true)
+	if !game.IsInfinite() {
+		t.Fatal("Expected infinite mode to be enabled")
+	}
+
+	// A glider moves one cell diagonally every 4 generations
+	for range 200 {
+		game.Step()
+	}
+	if got := game.GetPopulation(); got != 5 {
+		t.Fatalf("Expected the glider to keep 5 cells, got %d", got)
+	}
+	if state, _ := game.GetState(); state != StateChaotic {
+		t.Errorf("Expected a travelling glider to stay chaotic, got %v", state)
+	}
+	for c := range game.live {
+		if c.row < game.rows && c.col < game.cols {
+			t.Fatalf("Expected the glider to have left the view, found a cell at %v", c)
+		}
+	}
+
+	// Centering the view brings the glider back into the grid
+	game.CenterView()
+	visible := 0
+	for _, row := range game.GetCurrentGrid() {
+		for _, alive := range row {
+			if alive {
+				visible++
+			}
+		}
+	}
+	if visible != 5 {
+		t.Errorf("Expected 5 visible cells after centering, got %d", visible)
+	}
+	if viewRow, _ := game.GetViewOrigin(); viewRow%2 != 0 {
+		t.Errorf("Expected an even view row, got %d", viewRow)
+	}
+}
+
+// Test that the infinite plane matches the dense grid while the pattern stays inside it
+func TestGameOfLife_InfiniteMatchesDense(t *testing.T) {
+	for _, topology := range []Topology{TopologySquare, TopologyHex} {
+		dense := NewGameOfLife(30, 40, BoundaryFixed, PatternPentomino)
+		dense.SetTopology(topology)
+		dense.Reset(30, 40, BoundaryFixed, PatternPentomino)
+		sparse := NewGameOfLife(30, 40, BoundaryFixed, PatternPentomino)
+		sparse.SetTopology(topology)
+		sparse.SetInfinite(true)
+
+		for gen := range 8 {
+			if !reflect.DeepEqual(sparse.GetCurrentGrid(), dense.GetCurrentGrid()) {
+				t.Fatalf("%s: grids differ at generation %d", topology.ToString(English), gen)
+			}
+			if !reflect.DeepEqual(sparse.GetAges(), dense.GetAges()) {
+				t.Fatalf("%s: ages differ at generation %d", topology.ToString(English), gen)
+			}
+			dense.Step()
+			sparse.Step()
+		}
+	}
+}
+
+// Test that panning moves the view without changing the plane
+func TestGameOfLife_PanView(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+
+	// Panning does nothing on the bounded grid
+	game.PanView(PanStep, PanStep)
+	if row, col := game.GetViewOrigin(); row != 0 || col != 0 {
+		t.Fatalf("Expected no pan on the bounded grid, got origin %d,%d", row, col)
+	}
+
+	game.SetInfinite(true)
+	// The glider's bottom row is at grid row 4, columns 2 to 4
+	game.PanView(PanStep, 2)
+	if row, col := game.GetViewOrigin(); row != PanStep || col != 2 {
+		t.Fatalf("Expected origin %d,2, got %d,%d", PanStep, row, col)
+	}
+	grid := game.GetCurrentGrid()
+	if !grid[0][0] || !grid[0][1] || !grid[0][2] {
+		t.Error("Expected the glider's bottom row at the top left of the view")
+	}
+	if game.GetPopulation() != 5 {
+		t.Errorf("Expected panning to keep 5 live cells, got %d", game.GetPopulation())
+	}
+
+	// Toggling a cell edits the plane under the view
+	game.ToggleCell(10, 10)
+	if _, ok := game.live[cell{10 + PanStep, 12}]; !ok {
+		t.Error("Expected the toggled cell on the plane at the view offset")
+	}
+	game.PanView(-PanStep, -2)
+	if !game.GetCurrentGrid()[10+PanStep][12] {
+		t.Error("Expected the toggled cell to stay on the plane after panning back")
+	}
+}
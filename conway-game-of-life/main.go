@@ -24,13 +24,29 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -pattern glider                  # Start with a glider pattern\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -pattern glider-gun -size 30x80  # Glider gun in custom size\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -alive-char '🟢' -dead-char '⚫' # Custom emoji cells\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -rule B36/S23                    # HighLife rule\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -topology hex                    # Hexagonal grid with B2/S34\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -pattern-file gosper.rle         # Load a pattern in RLE format\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -pattern glider -infinite        # Follow a glider on an unbounded plane\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -load-state conway-state.json    # Resume a session saved with S\n", os.Args[0])
 	}
 
 	// Parse command line flags
+	var rule = flag.String("rule", "", "Life-like rule in B/S notation (e.g. B3/S23, B36/S23, B2/S), default "+DefaultRule+" or "+DefaultHexRule+" on hex grids")
+	var topology = flag.String("topology", TopologySquare.ToString(English), "Grid topology (square/hex)")
 	var aliveColor = flag.String("alive-color", DefaultAliveColor, "Alive cell color (hex)")
 	var deadColor = flag.String("dead-color", DefaultDeadColor, "Dead cell color (hex)")
 	var aliveChar = flag.String("alive-char", DefaultAliveChar, "Alive cell character")
 	var deadChar = flag.String("dead-char", DefaultDeadChar, "Dead cell character")
+	var seed = flag.Int64("seed", 0, "Seed for the random pattern, for reproducible runs (0 = time-based)")
+	var patternFile = flag.String("pattern-file", "", "Load the initial pattern from an RLE (.rle) file")
+	var monoCells = flag.Bool("mono-cells", false, "Render one terminal cell per rune, even for double-width characters")
+	var stopWhenSettled = flag.Bool("stop-when-settled", false, "Stop the simulation once it reaches a fixed point or dies out")
+	var ageColoring = flag.Bool("age-coloring", false, "Color live cells by age (newborn bright, old dim)")
+	var size = flag.String("size", "", "Fixed simulation size as ROWSxCOLS (e.g. 40x120), kept when the terminal is resized; empty follows the terminal")
+	var stateFile = flag.String("state-file", DefaultStateFile, "File the S key saves the session to")
+	var loadState = flag.String("load-state", "", "Resume a session saved with the S key from a JSON state file")
+	var infinite = flag.Bool("infinite", false, "Run on an unbounded plane, with the grid as a viewport that can pan")
 	var lang = flag.String("lang", DefaultLanguage.ToString(DefaultLanguage), "Language (en/cn)")
 	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
 	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
@@ -40,7 +56,7 @@ func main() {
 	flag.Parse()
 
 	if *logFile != "" {
-		_ = pkg.InitLog("debug", "text", *logFile)
+		_, _ = pkg.InitLog("debug", "text", *logFile)
 	}
 	slog.Debug("Conway's Game of Life starting")
 
@@ -55,16 +71,40 @@ func main() {
 
 	// Create and configure application
 	config := Config{
-		AliveColor: *aliveColor,
-		DeadColor:  *deadColor,
-		AliveChar:  *aliveChar,
-		DeadChar:   *deadChar,
+		Rule:            *rule,
+		AliveColor:      *aliveColor,
+		DeadColor:       *deadColor,
+		AliveChar:       *aliveChar,
+		DeadChar:        *deadChar,
+		MonoCells:       *monoCells,
+		StopWhenSettled: *stopWhenSettled,
+		AgeColoring:     *ageColoring,
+		Infinite:        *infinite,
+		Seed:            *seed,
+		StateFile:       *stateFile,
 	}
 	config.SetLanguage(*lang)
+	config.SetTopology(*topology)
+	config.SetFixedSize(*size)
 	config.Check()
 
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
 	// Create initial model
 	initialModel := NewModel(config)
+	if *patternFile != "" {
+		if err := initialModel.LoadPatternFile(*patternFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading pattern: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *loadState != "" {
+		if err := initialModel.LoadStateFile(*loadState); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading state: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	// Run the application
 	p := tea.NewProgram(initialModel, tea.WithAltScreen())
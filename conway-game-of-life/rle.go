@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// rleLineLength is the maximum line length used when writing RLE data
+const rleLineLength = 70
+
+// LoadRLE reads a pattern in run-length encoded (RLE) format and places it
+// centered on the current grid. The loaded pattern becomes the custom pattern,
+// so later resets and resizes re-center it.
+func (g *GameOfLife) LoadRLE(r io.Reader) error {
+	pattern, err := parseRLE(r)
+	if err != nil {
+		return err
+	}
+
+	g.custom = pattern
+	g.pattern = PatternCustom
+	g.generation = 0
+	g.setCustomPattern()
+	g.clearAges()
+	g.loadLive()
+	g.countPopulation()
+	g.resetHistory()
+	return nil
+}
+
+// SaveRLE writes the live cells of the current grid in RLE format, cropped to
+// their bounding box
+func (g *GameOfLife) SaveRLE(w io.Writer) error {
+	minRow, maxRow, minCol, maxCol := g.rows, -1, g.cols, -1
+	for i := range g.rows {
+		for j := range g.cols {
+			if g.currentGrid[i][j] {
+				minRow = min(minRow, i)
+				maxRow = max(maxRow, i)
+				minCol = min(minCol, j)
+				maxCol = max(maxCol, j)
+			}
+		}
+	}
+
+	width, height := 0, 0
+	if maxRow >= 0 {
+		width = maxCol - minCol + 1
+		height = maxRow - minRow + 1
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := fmt.Fprintf(bw, "x = %d, y = %d, rule = %s\n", width, height, g.rule); err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	pendingRows := 0
+	for i := 0; i < height; i++ {
+		row := g.currentGrid[minRow+i][minCol : maxCol+1]
+
+		// Trailing dead cells are implied by the end-of-row marker
+		end := len(row)
+		for end > 0 && !row[end-1] {
+			end--
+		}
+		if end == 0 {
+			pendingRows++
+			continue
+		}
+
+		if i > 0 {
+			writeRLERun(&body, pendingRows+1, '$')
+		}
+		pendingRows = 0
+
+		for j := 0; j < end; {
+			k := j
+			for k < end && row[k] == row[j] {
+				k++
+			}
+			tag := byte('b')
+			if row[j] {
+				tag = 'o'
+			}
+			writeRLERun(&body, k-j, tag)
+			j = k
+		}
+	}
+	body.WriteByte('!')
+
+	data := body.String()
+	for len(data) > rleLineLength {
+		// Split lines between tokens so run counts stay attached to their tags
+		cut := rleLineLength
+		for cut > 0 && data[cut-1] >= '0' && data[cut-1] <= '9' {
+			cut--
+		}
+		if cut == 0 {
+			cut = rleLineLength
+		}
+		if _, err := bw.WriteString(data[:cut] + "\n"); err != nil {
+			return err
+		}
+		data = data[cut:]
+	}
+	if _, err := bw.WriteString(data + "\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeRLERun appends a single run-length token, omitting a count of 1
+func writeRLERun(b *strings.Builder, count int, tag byte) {
+	if count > 1 {
+		b.WriteString(strconv.Itoa(count))
+	}
+	b.WriteByte(tag)
+}
+
+// parseRLE parses RLE data into a grid of cells sized by its header
+func parseRLE(r io.Reader) ([][]bool, error) {
+	scanner := bufio.NewScanner(r)
+	width, height := -1, -1
+	var pattern [][]bool
+	row, col := 0, 0
+	count := 0
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if width < 0 {
+			var err error
+			width, height, err = parseRLEHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			pattern = make([][]bool, height)
+			for i := range pattern {
+				pattern[i] = make([]bool, width)
+			}
+			continue
+		}
+
+		for _, ch := range line {
+			switch {
+			case ch >= '0' && ch <= '9':
+				count = count*10 + int(ch-'0')
+			case ch == ' ' || ch == '\t':
+				continue
+			case ch == '!':
+				return pattern, nil
+			case ch == '$':
+				row += max(count, 1)
+				col = 0
+				count = 0
+			case ch == 'b' || ch == '.':
+				col += max(count, 1)
+				count = 0
+			case (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z'):
+				// Any other state letter is treated as alive
+				for range max(count, 1) {
+					if row < height && col < width {
+						pattern[row][col] = true
+					}
+					col++
+				}
+				count = 0
+			default:
+				return nil, fmt.Errorf("invalid RLE character %q", ch)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read RLE data: %w", err)
+	}
+	if width < 0 {
+		return nil, fmt.Errorf("missing RLE header")
+	}
+	return pattern, nil
+}
+
+// parseRLEHeader parses the "x = m, y = n" header line of an RLE file. Sizes
+// above MaxFixedSize are rejected before the pattern is allocated.
+func parseRLEHeader(line string) (int, int, error) {
+	width, height := -1, -1
+	for _, field := range strings.Split(line, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return 0, 0, fmt.Errorf("invalid RLE header: %s", line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "x", "y":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return 0, 0, fmt.Errorf("invalid RLE header value %s = %s", key, value)
+			}
+			if n > MaxFixedSize {
+				return 0, 0, fmt.Errorf("RLE pattern too large: %s = %d, at most %d", key, n, MaxFixedSize)
+			}
+			if key == "x" {
+				width = n
+			} else {
+				height = n
+			}
+		}
+	}
+
+	if width < 0 || height < 0 {
+		return 0, 0, fmt.Errorf("invalid RLE header: %s", line)
+	}
+	return width, height, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const gliderRLE = `#N Glider
+#C A comment line
+x = 3, y = 3, rule = B3/S23
+bob$2bo$3o!
+`
+
+// countAlive counts the live cells in the current grid
+func countAlive(g *GameOfLife) int {
+	count := 0
+	for _, row := range g.GetCurrentGrid() {
+		for _, cell := range row {
+			if cell {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Test loading an RLE pattern centered on the grid
+func TestGameOfLife_LoadRLE(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternRandom)
+	game.Step()
+
+	if err := game.LoadRLE(strings.NewReader(gliderRLE)); err != nil {
+		t.Fatalf("LoadRLE failed: %v", err)
+	}
+
+	if game.GetGeneration() != 0 {
+		t.Errorf("Expected generation 0 after load, got %d", game.GetGeneration())
+	}
+	if game.pattern != PatternCustom {
+		t.Errorf("Expected pattern %v, got %v", PatternCustom, game.pattern)
+	}
+	if count := countAlive(game); count != 5 {
+		t.Errorf("Expected 5 live cells, got %d", count)
+	}
+
+	// Glider is 3x3 and centered: top-left corner at ((20-3)/2, (30-3)/2)
+	grid := game.GetCurrentGrid()
+	expected := [][2]int{{8, 14}, {9, 15}, {10, 13}, {10, 14}, {10, 15}}
+	for _, pos := range expected {
+		if !grid[pos[0]][pos[1]] {
+			t.Errorf("Expected cell (%d,%d) to be alive", pos[0], pos[1])
+		}
+	}
+
+	// Reset keeps the custom pattern
+	game.Reset(30, 40, BoundaryFixed, PatternCustom)
+	if count := countAlive(game); count != 5 {
+		t.Errorf("Expected 5 live cells after reset, got %d", count)
+	}
+}
+
+// Test invalid RLE input
+func TestGameOfLife_LoadRLEInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Empty input", ""},
+		{"Missing header", "bob$2bo$3o!"},
+		{"Invalid header value", "x = a, y = 3\nbob!"},
+		{"Invalid character", "x = 3, y = 3\nb?b!"},
+		{"Too large", "x = 100000000, y = 100000000\nbob!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+			if err := game.LoadRLE(strings.NewReader(tt.input)); err == nil {
+				t.Error("Expected error for invalid RLE input")
+			}
+			if game.pattern != PatternGlider {
+				t.Errorf("Pattern should be unchanged after failed load, got %v", game.pattern)
+			}
+		})
+	}
+}
+
+// Test that saving and loading an RLE pattern round-trips
+func TestGameOfLife_SaveRLE(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternRandom)
+	if err := game.LoadRLE(strings.NewReader(gliderRLE)); err != nil {
+		t.Fatalf("LoadRLE failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := game.SaveRLE(&buf); err != nil {
+		t.Fatalf("SaveRLE failed: %v", err)
+	}
+
+	expected := "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"
+	if buf.String() != expected {
+		t.Errorf("Expected RLE %q, got %q", expected, buf.String())
+	}
+
+	// A larger random pattern spanning several lines survives a round trip
+	other := NewGameOfLife(20, 30, BoundaryFixed, PatternRandom)
+	buf.Reset()
+	if err := other.SaveRLE(&buf); err != nil {
+		t.Fatalf("SaveRLE failed: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if len(line) > rleLineLength {
+			t.Errorf("RLE line exceeds %d characters: %q", rleLineLength, line)
+		}
+	}
+
+	loaded := NewGameOfLife(20, 30, BoundaryFixed, PatternRandom)
+	if err := loaded.LoadRLE(&buf); err != nil {
+		t.Fatalf("LoadRLE failed: %v", err)
+	}
+	if countAlive(loaded) != countAlive(other) {
+		t.Errorf("Expected %d live cells after round trip, got %d", countAlive(other), countAlive(loaded))
+	}
+}
+
+// Test saving an empty grid
+func TestGameOfLife_SaveRLEEmpty(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+	game.clearGrid()
+
+	var buf bytes.Buffer
+	if err := game.SaveRLE(&buf); err != nil {
+		t.Fatalf("SaveRLE failed: %v", err)
+	}
+	if buf.String() != "x = 0, y = 0, rule = B3/S23\n!\n" {
+		t.Errorf("Unexpected RLE for empty grid: %q", buf.String())
+	}
+}
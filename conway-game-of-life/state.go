@@ -0,0 +1,186 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Cell characters used for grids in saved state files
+const (
+	stateAliveCell = 'O'
+	stateDeadCell  = '.'
+)
+
+// sessionState is the JSON form of a saved simulation
+type sessionState struct {
+	Rows       int          `json:"rows"`
+	Cols       int          `json:"cols"`
+	Boundary   BoundaryType `json:"boundary"`
+	Pattern    Pattern      `json:"pattern"`
+	Topology   Topology     `json:"topology"`
+	Rule       string       `json:"rule"`
+	Generation int          `json:"generation"`
+	Grid       []string     `json:"grid"`
+	Custom     []string     `json:"custom,omitempty"`
+
+	// The infinite plane extends beyond the grid, which only holds the
+	// view, so its live cells are saved as [row, col] pairs
+	Infinite bool     `json:"infinite,omitempty"`
+	ViewRow  int      `json:"view_row,omitempty"`
+	ViewCol  int      `json:"view_col,omitempty"`
+	Live     [][2]int `json:"live,omitempty"`
+}
+
+// SaveState writes the grid size, boundary, pattern, rule, generation and live
+// cells to path as JSON, so the simulation can be resumed with LoadState. In
+// infinite mode every live cell of the plane and the view origin are saved.
+func (g *GameOfLife) SaveState(path string) error {
+	state := sessionState{
+		Rows:       g.rows,
+		Cols:       g.cols,
+		Boundary:   g.boundary,
+		Pattern:    g.pattern,
+		Topology:   g.topology,
+		Rule:       g.rule.String(),
+		Generation: g.generation,
+		Grid:       encodeStateGrid(g.currentGrid),
+		Custom:     encodeStateGrid(g.custom),
+	}
+	if g.infinite {
+		state.Infinite = true
+		state.ViewRow, state.ViewCol = g.viewRow, g.viewCol
+		state.Live = make([][2]int, 0, len(g.live))
+		for c := range g.live {
+			state.Live = append(state.Live, [2]int{c.row, c.col})
+		}
+		// Map order is random; sorted cells keep saves of one state identical
+		slices.SortFunc(state.Live, func(a, b [2]int) int {
+			return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+		})
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadState replaces the simulation with one saved by SaveState. Cell ages
+// and settle detection start over from the loaded grid.
+func (g *GameOfLife) LoadState(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var state sessionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("invalid state file: %w", err)
+	}
+	if state.Rows <= MinRows || state.Cols <= MinCols || state.Rows > MaxFixedSize || state.Cols > MaxFixedSize {
+		return fmt.Errorf("invalid state size %dx%d", state.Rows, state.Cols)
+	}
+	if state.Boundary < BoundaryPeriodic || state.Boundary > BoundaryKlein {
+		return fmt.Errorf("invalid boundary %d", state.Boundary)
+	}
+	if state.Pattern < PatternRandom || state.Pattern > PatternCustom {
+		return fmt.Errorf("invalid pattern %d", state.Pattern)
+	}
+	if state.Topology != TopologySquare && state.Topology != TopologyHex {
+		return fmt.Errorf("invalid topology %d", state.Topology)
+	}
+	if state.Generation < 0 {
+		return fmt.Errorf("invalid generation %d", state.Generation)
+	}
+	rule, err := ParseRule(state.Rule)
+	if err != nil {
+		return err
+	}
+	grid, err := decodeStateGrid(state.Grid, state.Rows, state.Cols)
+	if err != nil {
+		return err
+	}
+	custom, err := decodeStateGrid(state.Custom, len(state.Custom), 0)
+	if err != nil {
+		return err
+	}
+
+	g.rule = rule
+	g.topology = state.Topology
+	g.custom = custom
+	g.SetInfinite(state.Infinite)
+	g.Reset(state.Rows, state.Cols, state.Boundary, state.Pattern)
+	for i := range g.rows {
+		copy(g.currentGrid[i], grid[i])
+	}
+	g.generation = state.Generation
+	g.clearAges()
+	g.loadLive()
+	g.countPopulation()
+	if g.infinite {
+		clear(g.live)
+		for _, c := range state.Live {
+			g.live[cell{c[0], c[1]}] = 0
+		}
+		g.viewRow, g.viewCol = state.ViewRow, state.ViewCol
+		g.population = len(g.live)
+		g.refreshView()
+	}
+	g.resetHistory()
+	return nil
+}
+
+// encodeStateGrid converts a grid to one string per row
+func encodeStateGrid(grid [][]bool) []string {
+	if len(grid) == 0 {
+		return nil
+	}
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		line := make([]byte, len(row))
+		for j, cell := range row {
+			line[j] = stateDeadCell
+			if cell {
+				line[j] = stateAliveCell
+			}
+		}
+		lines[i] = string(line)
+	}
+	return lines
+}
+
+// decodeStateGrid parses rows written by encodeStateGrid. A cols of 0 takes
+// the width from the first row; every row must have the same width.
+func decodeStateGrid(lines []string, rows, cols int) ([][]bool, error) {
+	if len(lines) != rows {
+		return nil, fmt.Errorf("expected %d grid rows, got %d", rows, len(lines))
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+	if cols == 0 {
+		cols = len(lines[0])
+	}
+
+	grid := make([][]bool, rows)
+	for i, line := range lines {
+		if len(line) != cols {
+			return nil, fmt.Errorf("grid row %d: expected %d cells, got %d", i, cols, len(line))
+		}
+		grid[i] = make([]bool, cols)
+		for j := range cols {
+			switch line[j] {
+			case stateAliveCell:
+				grid[i][j] = true
+			case stateDeadCell:
+			default:
+				return nil, fmt.Errorf("grid row %d: invalid cell %q", i, line[j])
+			}
+		}
+	}
+	return grid, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Test that a saved mid-simulation state continues exactly like the original
+func TestGameOfLife_SaveLoadState(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryPeriodic, PatternRandom)
+	for range 10 {
+		game.Step()
+	}
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := game.SaveState(path); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+
+	loaded := NewGameOfLife(10, 10, BoundaryFixed, PatternGlider)
+	if err := loaded.LoadState(path); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	if loaded.GetGeneration() != game.GetGeneration() {
+		t.Errorf("Expected generation %d, got %d", game.GetGeneration(), loaded.GetGeneration())
+	}
+	if loaded.boundary != game.boundary || loaded.pattern != game.pattern {
+		t.Errorf("Expected boundary %v and pattern %v, got %v and %v",
+			game.boundary, game.pattern, loaded.boundary, loaded.pattern)
+	}
+	if loaded.GetPopulation() != game.GetPopulation() {
+		t.Errorf("Expected population %d, got %d", game.GetPopulation(), loaded.GetPopulation())
+	}
+
+	game.Step()
+	loaded.Step()
+	if !reflect.DeepEqual(loaded.GetCurrentGrid(), game.GetCurrentGrid()) {
+		t.Error("Expected the loaded game to continue identically to the original")
+	}
+	if loaded.GetGeneration() != game.GetGeneration() {
+		t.Errorf("Expected generation %d after step, got %d", game.GetGeneration(), loaded.GetGeneration())
+	}
+}
+
+// Test that an infinite plane is saved whole, not just the cells in view
+func TestGameOfLife_SaveLoadStateInfinite(t *testing.T) {
+	game := NewGameOfLife(20, 30, BoundaryPeriodic, PatternGlider)
+	game.SetInfinite(true)
+	for range 4 {
+		game.Step()
+	}
+	game.PanView(100, -100) // Every live cell is now off-screen
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := game.SaveState(path); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	loaded := NewGameOfLife(20, 30, BoundaryPeriodic, PatternRandom)
+	if err := loaded.LoadState(path); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+
+	if !loaded.IsInfinite() {
+		t.Fatal("Expected the loaded game to be infinite")
+	}
+	if row, col := loaded.GetViewOrigin(); row != 100 || col != -100 {
+		t.Errorf("Expected the view at 100,-100, got %d,%d", row, col)
+	}
+	if loaded.GetPopulation() != 5 {
+		t.Errorf("Expected the 5 off-screen glider cells, got %d", loaded.GetPopulation())
+	}
+
+	game.Step()
+	loaded.Step()
+	// Ages start over on load, so only the positions are compared
+	if len(loaded.live) != len(game.live) {
+		t.Fatalf("Expected %d live cells after a step, got %d", len(game.live), len(loaded.live))
+	}
+	for c := range game.live {
+		if _, ok := loaded.live[c]; !ok {
+			t.Errorf("Expected cell %v to be alive after a step", c)
+		}
+	}
+}
+
+// Test that malformed state files are rejected without changing the game
+func TestGameOfLife_LoadStateInvalid(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"json":     `{"rows":`,
+		"rule":     `{"rows":20,"cols":30,"rule":"X1","grid":[]}`,
+		"rows":     `{"rows":20,"cols":30,"rule":"B3/S23","grid":["..."]}`,
+		"cells":    `{"rows":1,"cols":3,"rule":"B3/S23","grid":["..x"]}`,
+		"size":     `{"rows":2000,"cols":30,"rule":"B3/S23","grid":[]}`,
+		"boundary": `{"rows":20,"cols":30,"boundary":9,"rule":"B3/S23","grid":[]}`,
+		"pattern":  `{"rows":20,"cols":30,"pattern":-1,"rule":"B3/S23","grid":[]}`,
+		"topology": `{"rows":20,"cols":30,"topology":5,"rule":"B3/S23","grid":[]}`,
+	}
+
+	for name, content := range cases {
+		path := filepath.Join(dir, name+".json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+		if err := game.LoadState(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if game.rows != 20 || game.cols != 30 {
+			t.Errorf("%s: expected the grid to be unchanged, got %dx%d", name, game.rows, game.cols)
+		}
+	}
+
+	game := NewGameOfLife(20, 30, BoundaryFixed, PatternGlider)
+	if err := game.LoadState(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
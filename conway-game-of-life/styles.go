@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"math/bits"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 // Enhanced UI styles for better visual appearance
@@ -43,17 +45,42 @@ const (
 	SizeLabelCN = "📐 尺寸: %d×%d"
 	SizeLabelEN = "📐 Size: %d×%d"
 
+	WindowLabelCN = "🖥️ 窗口: %d×%d"
+	WindowLabelEN = "🖥️ Window: %d×%d"
+
 	BoundaryLabelCN = "🔒 边界: %s"
 	BoundaryLabelEN = "🔒 Boundary: %s"
 
+	BatchLabelCN = "⏩ 每帧: %d 代"
+	BatchLabelEN = "⏩ Batch: %d gens"
+
 	PatternLabelCN = "🎨 模式: %s"
 	PatternLabelEN = "🎨 Pattern: %s"
 
+	PopulationLabelCN = "🧬 存活: %d"
+	PopulationLabelEN = "🧬 Live: %d"
+
+	RuleLabelCN = "📜 规则: %s"
+	RuleLabelEN = "📜 Rule: %s"
+
+	ViewLabelCN = "🔭 视图: %d,%d"
+	ViewLabelEN = "🔭 View: %d,%d"
+
+	StateLabelCN       = "🧭 状态: %s"
+	StateLabelEN       = "🧭 State: %s"
+	StatePeriodLabelCN = "🧭 状态: %s (周期 %d)"
+	StatePeriodLabelEN = "🧭 State: %s (period %d)"
+
 	StatusLabelPlayingCN = "▶️ 运行中"
 	StatusLabelPlayingEN = "▶️ Running"
 	StatusLabelPausedCN  = "⏸️ 已暂停"
 	StatusLabelPausedEN  = "⏸️ Paused"
 
+	SavedLabelCN      = "💾 已保存: %s"
+	SavedLabelEN      = "💾 Saved: %s"
+	SaveFailedLabelCN = "⚠️ 保存失败: %v"
+	SaveFailedLabelEN = "⚠️ Save failed: %v"
+
 	// Control Line
 	SelectPatternLabelCN = "P 选择模式"
 	SelectPatternLabelEN = "P Select Pattern"
@@ -67,9 +94,21 @@ const (
 	SpeedControlLabelCN = "+/- 加速/减速"
 	SpeedControlLabelEN = "+/- Speed Up/Down"
 
+	BatchControlLabelCN = "[/] 每帧代数"
+	BatchControlLabelEN = "[/] Gens per Frame"
+
 	SpaceControlLabelCN = "Space 暂停"
 	SpaceControlLabelEN = "Space Pause"
 
+	EditLabelCN = "←↑↓→/X 编辑(暂停时)"
+	EditLabelEN = "←↑↓→/X Edit (paused)"
+
+	PanLabelCN = "Shift+←↑↓→/C 平移/居中"
+	PanLabelEN = "Shift+←↑↓→/C Pan/Center"
+
+	SaveLabelCN = "S/E 保存/导出RLE"
+	SaveLabelEN = "S/E Save/Export RLE"
+
 	ResetLabelCN = "R 重置"
 	ResetLabelEN = "R Reset"
 
@@ -79,18 +118,87 @@ const (
 
 // RenderOptions contains rendering configuration with cached styles
 type RenderOptions struct {
-	aliveStyled string // Cached styled alive cell
-	deadStyled  string // Cached styled dead cell
+	aliveStyled string   // Cached styled alive cell
+	deadStyled  string   // Cached styled dead cell
+	ageStyled   []string // Cached styled alive cells from newborn (bright) to old (dim)
+
+	cursorAliveStyled string // Cached inverse-video alive cell under the edit cursor
+	cursorDeadStyled  string // Cached inverse-video dead cell under the edit cursor
+
+	cellWidth int          // Display width reserved for each cell
+	palette   *pkg.Palette // Alive, dead and cursor colors
+	aliveChar string
+	deadChar  string
 }
 
 // NewRenderOptions creates optimized render options with pre-computed styles
 func NewRenderOptions(aliveColor, deadColor, aliveChar, deadChar string) RenderOptions {
-	return RenderOptions{
-		aliveStyled: lipgloss.NewStyle().Foreground(lipgloss.Color(aliveColor)).Render(aliveChar),
-		deadStyled:  lipgloss.NewStyle().Foreground(lipgloss.Color(deadColor)).Render(deadChar),
+	// Colors are validated by Config.Check, so fallbacks only apply to direct callers
+	palette := pkg.NewPalette()
+	_ = palette.Set(pkg.RoleAlive, aliveColor, DefaultAliveColor)
+	_ = palette.Set(pkg.RoleDead, deadColor, DefaultDeadColor)
+	_ = palette.Set(pkg.RoleCursor, aliveColor, DefaultAliveColor)
+
+	ro := RenderOptions{
+		palette:   palette,
+		aliveChar: aliveChar,
+		deadChar:  deadChar,
+	}
+	ro.SetCellWidth(false)
+	return ro
+}
+
+// SetCellWidth controls how many terminal columns each cell occupies.
+// By default every cell reserves the display width of the widest character,
+// so double-width runes (CJK, emoji) stay aligned with narrow ones and the
+// grid holds fewer logical columns than the terminal is wide. With mono set,
+// each rune is rendered as-is in a single cell.
+func (ro *RenderOptions) SetCellWidth(mono bool) {
+	aliveChar, deadChar := ro.aliveChar, ro.deadChar
+	ro.cellWidth = 1
+	if !mono {
+		ro.cellWidth = max(lipgloss.Width(aliveChar), lipgloss.Width(deadChar), 1)
+		aliveChar = padCell(aliveChar, ro.cellWidth)
+		deadChar = padCell(deadChar, ro.cellWidth)
+	}
+	ro.aliveStyled = ro.palette.Style(pkg.RoleAlive).Render(aliveChar)
+	ro.deadStyled = ro.palette.Style(pkg.RoleDead).Render(deadChar)
+	ro.cursorAliveStyled = ro.palette.Style(pkg.RoleCursor).Reverse(true).Render(aliveChar)
+	ro.cursorDeadStyled = ro.palette.Style(pkg.RoleCursor).Reverse(true).Render(deadChar)
+
+	// Age gradient fades from the alive color toward the dead color
+	ro.ageStyled = make([]string, AgeBuckets)
+	alive, dead := string(ro.palette.Color(pkg.RoleAlive)), string(ro.palette.Color(pkg.RoleDead))
+	for i := range AgeBuckets {
+		color := blendColor(alive, dead, 0.75*float64(i)/float64(AgeBuckets-1))
+		ro.ageStyled[i] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(color)).Render(aliveChar)
 	}
 }
 
+// ageBucket maps a cell age to an age color level: 0, 1, 2-3, 4-7, 8-15, 16+
+func ageBucket(age int) int {
+	return min(bits.Len(uint(age)), AgeBuckets-1)
+}
+
+// blendColor linearly interpolates between two hex colors, t in [0, 1]
+func blendColor(from, to string, t float64) string {
+	f, _ := pkg.HexToRGB(from)
+	c, _ := pkg.HexToRGB(to)
+	var rgb [3]int
+	for i := range rgb {
+		rgb[i] = int(float64(f[i]) + (float64(c[i])-float64(f[i]))*t)
+	}
+	return fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+}
+
+// padCell right-pads a cell character with spaces up to the given display width
+func padCell(char string, width int) string {
+	if w := lipgloss.Width(char); w < width {
+		return char + strings.Repeat(" ", width-w)
+	}
+	return char
+}
+
 // HeaderLineView returns the header display string
 func (m Model) HeaderLineView() string {
 	style := headerStyle.Width(m.width)
@@ -102,7 +210,7 @@ func (m Model) HeaderLineView() string {
 
 // StatusLineView returns the status display string for the first row
 func (m Model) StatusLineView() string {
-	var status, generationLabel, speedLabel, boundaryLabel, sizeLabel, patternLabel string
+	var status, generationLabel, speedLabel, batchLabel, boundaryLabel, sizeLabel, windowLabel, patternLabel, populationLabel, ruleLabel, viewLabel, stateLabel, statePeriodLabel string
 
 	if m.language == Chinese {
 		status = StatusLabelPlayingCN
@@ -111,9 +219,16 @@ func (m Model) StatusLineView() string {
 		}
 		generationLabel = GenerationLabelCN
 		speedLabel = SpeedLabelCN
+		batchLabel = BatchLabelCN
 		sizeLabel = SizeLabelCN
+		windowLabel = WindowLabelCN
 		boundaryLabel = BoundaryLabelCN
 		patternLabel = PatternLabelCN
+		populationLabel = PopulationLabelCN
+		ruleLabel = RuleLabelCN
+		viewLabel = ViewLabelCN
+		stateLabel = StateLabelCN
+		statePeriodLabel = StatePeriodLabelCN
 	} else {
 		status = StatusLabelPlayingEN
 		if m.paused {
@@ -121,9 +236,22 @@ func (m Model) StatusLineView() string {
 		}
 		generationLabel = GenerationLabelEN
 		speedLabel = SpeedLabelEN
+		batchLabel = BatchLabelEN
 		sizeLabel = SizeLabelEN
+		windowLabel = WindowLabelEN
 		boundaryLabel = BoundaryLabelEN
 		patternLabel = PatternLabelEN
+		populationLabel = PopulationLabelEN
+		ruleLabel = RuleLabelEN
+		viewLabel = ViewLabelEN
+		stateLabel = StateLabelEN
+		statePeriodLabel = StatePeriodLabelEN
+	}
+
+	state, period := m.game.GetState()
+	stateText := fmt.Sprintf(stateLabel, state.ToString(m.language))
+	if state == StateOscillating {
+		stateText = fmt.Sprintf(statePeriodLabel, state.ToString(m.language), period)
 	}
 
 	tableBuilder.Reset()
@@ -131,26 +259,54 @@ func (m Model) StatusLineView() string {
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(speedLabel, m.refreshRate.String())))
 	tableBuilder.WriteString(" | ")
-	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sizeLabel, m.gridHeight, m.gridWidth)))
+	if steps := m.game.GetStepsPerTick(); steps > 1 {
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(batchLabel, steps)))
+		tableBuilder.WriteString(" | ")
+	}
+	rows, cols := m.simSize()
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sizeLabel, rows, cols)))
 	tableBuilder.WriteString(" | ")
-	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(boundaryLabel, m.boundary.ToString(m.language))))
+	if m.fixedSize != nil {
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(windowLabel, m.gridHeight, m.gridWidth)))
+		tableBuilder.WriteString(" | ")
+	}
+	if m.game.IsInfinite() {
+		viewRow, viewCol := m.game.GetViewOrigin()
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(viewLabel, viewRow, viewCol)))
+	} else {
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(boundaryLabel, m.boundary.ToString(m.language))))
+	}
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(patternLabel, m.pattern.ToString(m.language))))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(populationLabel, m.game.GetPopulation())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(ruleLabel, m.game.GetRule())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(stateText))
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(status))
+	if m.notice != "" {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(m.notice))
+	}
 
 	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
 }
 
 // ControlLineView returns the control display string: T,B,R + Space, L, Q
 func (m Model) ControlLineView() string {
-	var selectPattern, selectBoundary, speedControl, language, space, reset, quit string
+	var selectPattern, selectBoundary, speedControl, batchControl, language, space, edit, pan, save, reset, quit string
 	if m.language == Chinese {
 		selectPattern = SelectPatternLabelCN
 		selectBoundary = SelectBoundaryLabelCN
 		language = LanguageLabelCN
 		speedControl = SpeedControlLabelCN
+		batchControl = BatchControlLabelCN
 		space = SpaceControlLabelCN
+		edit = EditLabelCN
+		pan = PanLabelCN
+		save = SaveLabelCN
 		reset = ResetLabelCN
 		quit = QuitLabelCN
 	} else {
@@ -158,7 +314,11 @@ func (m Model) ControlLineView() string {
 		selectBoundary = SelectBoundaryLabelEN
 		language = LanguageLabelEN
 		speedControl = SpeedControlLabelEN
+		batchControl = BatchControlLabelEN
 		space = SpaceControlLabelEN
+		edit = EditLabelEN
+		pan = PanLabelEN
+		save = SaveLabelEN
 		reset = ResetLabelEN
 		quit = QuitLabelEN
 	}
@@ -169,13 +329,39 @@ func (m Model) ControlLineView() string {
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(speedControl))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(batchControl))
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(language))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(space))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(edit))
+	tableBuilder.WriteString(" | ")
+	if m.game.IsInfinite() {
+		tableBuilder.WriteString(labelStyle.Render(pan))
+		tableBuilder.WriteString(" | ")
+	}
+	tableBuilder.WriteString(labelStyle.Render(save))
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(reset))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(quit))
 
 	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
 }
+
+// savedLabel returns the save success message format
+func (m Model) savedLabel() string {
+	if m.language == Chinese {
+		return SavedLabelCN
+	}
+	return SavedLabelEN
+}
+
+// saveFailedLabel returns the save failure message format
+func (m Model) saveFailedLabel() string {
+	if m.language == Chinese {
+		return SaveFailedLabelCN
+	}
+	return SaveFailedLabelEN
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Test cell width detection for narrow and double-width characters
+func TestNewRenderOptions_CellWidth(t *testing.T) {
+	tests := []struct {
+		name      string
+		aliveChar string
+		deadChar  string
+		expected  int
+	}{
+		{"Narrow characters", "█", " ", 1},
+		{"Emoji alive cell", "🟢", " ", 2},
+		{"Emoji both cells", "🟢", "⚫", 2},
+		{"CJK dead cell", "●", "中", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ro := NewRenderOptions(DefaultAliveColor, DefaultDeadColor, tt.aliveChar, tt.deadChar)
+			if ro.cellWidth != tt.expected {
+				t.Errorf("Expected cell width %d, got %d", tt.expected, ro.cellWidth)
+			}
+			if w := lipgloss.Width(ro.aliveStyled); w != tt.expected {
+				t.Errorf("Expected alive cell display width %d, got %d", tt.expected, w)
+			}
+			if w := lipgloss.Width(ro.deadStyled); w != tt.expected {
+				t.Errorf("Expected dead cell display width %d, got %d", tt.expected, w)
+			}
+		})
+	}
+}
+
+// Test that mono mode renders one rune per cell without padding
+func TestRenderOptions_SetCellWidthMono(t *testing.T) {
+	ro := NewRenderOptions(DefaultAliveColor, DefaultDeadColor, "🟢", " ")
+	ro.SetCellWidth(true)
+
+	if ro.cellWidth != 1 {
+		t.Errorf("Expected cell width 1 in mono mode, got %d", ro.cellWidth)
+	}
+	if w := lipgloss.Width(ro.deadStyled); w != 1 {
+		t.Errorf("Expected unpadded dead cell in mono mode, got width %d", w)
+	}
+}
+
+// Test that a grid of wide runes renders rows of equal display width
+func TestModel_RenderGridWideRunes(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.AliveChar = "🟢"
+	cfg.DeadChar = " "
+	m := NewModel(cfg)
+
+	lines := strings.Split(m.RenderGrid(), "\n")
+	if len(lines) == 0 {
+		t.Fatal("Expected rendered grid lines")
+	}
+
+	expected := 1 + len(m.game.GetCurrentGrid()[0])*2
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w != expected {
+			t.Errorf("Row %d: expected display width %d, got %d", i, expected, w)
+		}
+	}
+}
+
+// Test the age color gradient from the alive color toward the dead color
+func TestBlendColor(t *testing.T) {
+	if got := blendColor("#FF0000", "#000000", 0); got != "#FF0000" {
+		t.Errorf("Expected #FF0000 at t=0, got %s", got)
+	}
+	if got := blendColor("#FF0000", "#000000", 1); got != "#000000" {
+		t.Errorf("Expected #000000 at t=1, got %s", got)
+	}
+	if got := blendColor("#00FF00", "#0000FF", 0.5); got != "#007F7F" {
+		t.Errorf("Expected #007F7F at t=0.5, got %s", got)
+	}
+
+	ro := NewRenderOptions("#00FF00", "#333333", "█", " ")
+	if len(ro.ageStyled) != AgeBuckets {
+		t.Errorf("Expected %d age styles, got %d", AgeBuckets, len(ro.ageStyled))
+	}
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
+	"os"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/telepair/go-playground/pkg"
 )
 
 var (
@@ -30,18 +33,36 @@ type Model struct {
 	buffer        strings.Builder
 	gridBuffer    strings.Builder
 	renderOptions RenderOptions
+	ageColoring   bool // Color live cells by age
+	curRow        int  // Edit cursor row, shown while paused
+	curCol        int  // Edit cursor column, shown while paused
 	logger        *slog.Logger
+
+	// fixedSize keeps the simulation size when the window is resized; the
+	// grid is centered in the window instead. Nil follows the window.
+	fixedSize *GridSize
+
+	stateFile string // Where the S key saves the session
+	notice    string // Result of the last save, shown in the status line until the next key
 }
 
 // NewModel creates a new model with the given configuration
 func NewModel(cfg Config) Model {
 	cfg.Check()
 
+	renderOptions := NewRenderOptions(cfg.AliveColor, cfg.DeadColor, cfg.AliveChar, cfg.DeadChar)
+	renderOptions.SetCellWidth(cfg.MonoCells)
+
 	gridHeight := DefaultRows - keepHeight
-	gridWidth := DefaultCols - keepWidth
+	gridWidth := (DefaultCols - keepWidth) / renderOptions.cellWidth
+
+	rows, cols := DefaultRows, DefaultCols
+	if cfg.FixedSize != nil {
+		rows, cols = cfg.FixedSize.Rows, cfg.FixedSize.Cols
+	}
 
 	model := Model{
-		game:          NewGameOfLife(DefaultRows, DefaultCols, DefaultBoundary, DefaultPattern),
+		game:          NewGameOfLife(rows, cols, DefaultBoundary, DefaultPattern),
 		language:      cfg.Language,
 		pattern:       DefaultPattern,
 		boundary:      DefaultBoundary,
@@ -50,14 +71,73 @@ func NewModel(cfg Config) Model {
 		gridWidth:     gridWidth,
 		paused:        false,
 		currentStep:   0,
-		renderOptions: NewRenderOptions(cfg.AliveColor, cfg.DeadColor, cfg.AliveChar, cfg.DeadChar),
+		renderOptions: renderOptions,
+		ageColoring:   cfg.AgeColoring,
 		refreshRate:   DefaultRefreshRate,
 		logger:        slog.With("module", "ui"),
+		fixedSize:     cfg.FixedSize,
+		stateFile:     cfg.StateFile,
+	}
+
+	// Rule was validated by Check, so parsing cannot fail here
+	if rule, err := ParseRule(cfg.Rule); err == nil {
+		model.game.SetRule(rule)
+	}
+	model.game.SetTopology(cfg.Topology)
+	model.game.SetStopWhenSettled(cfg.StopWhenSettled)
+	model.game.SetInfinite(cfg.Infinite)
+	if cfg.Seed != 0 {
+		model.game.SetSeed(cfg.Seed)
+		model.game.Reset(rows, cols, DefaultBoundary, DefaultPattern)
 	}
 
 	return model
 }
 
+// LoadPatternFile loads an RLE pattern file and makes it the active pattern
+func (m *Model) LoadPatternFile(path string) error {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open pattern file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := m.game.LoadRLE(f); err != nil {
+		return fmt.Errorf("failed to load pattern file %s: %w", path, err)
+	}
+	m.pattern = PatternCustom
+	m.currentStep = 0
+	return nil
+}
+
+// SavePatternFile writes the live cells to an RLE pattern file
+func (m *Model) SavePatternFile(path string) error {
+	f, err := os.Create(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to create pattern file: %w", err)
+	}
+	if err := m.game.SaveRLE(f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write pattern file %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// LoadStateFile resumes a session saved with the S key. The loaded grid
+// keeps its size when the window is resized, as with -size.
+func (m *Model) LoadStateFile(path string) error {
+	if err := m.game.LoadState(path); err != nil {
+		return fmt.Errorf("failed to load state file %s: %w", path, err)
+	}
+	rows, cols := m.game.GetSize()
+	m.fixedSize = &GridSize{Rows: rows, Cols: cols}
+	m.pattern = m.game.GetPattern()
+	m.boundary = m.game.GetBoundary()
+	m.currentStep = m.game.GetGeneration()
+	m.clampCursor()
+	return nil
+}
+
 // tickMsg is sent every tick for infinite mode
 type tickMsg time.Time
 
@@ -80,7 +160,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyPress(msg)
 	case tickMsg:
 		m.logger.Debug("Tick", "time", msg)
-		return m.handleTick()
+		return m.handleTick(time.Time(msg))
 	}
 	return m, nil
 }
@@ -103,14 +183,72 @@ func (m Model) View() string {
 // handleWindowResize processes terminal window size changes
 func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.width = msg.Width
-	m.gridWidth = msg.Width - keepWidth
+	m.gridWidth = (msg.Width - keepWidth) / m.renderOptions.cellWidth
 	m.gridHeight = msg.Height - keepHeight
-	m.game.Reset(m.gridHeight, m.gridWidth, m.boundary, m.pattern)
+	if m.fixedSize == nil {
+		m.resetGame()
+	}
+	m.clampCursor()
 	return m, nil
 }
 
+// simSize returns the simulation size, which is the window grid size unless
+// a fixed size is configured
+func (m *Model) simSize() (int, int) {
+	if m.fixedSize != nil {
+		return m.fixedSize.Rows, m.fixedSize.Cols
+	}
+	return m.gridHeight, m.gridWidth
+}
+
+// resetGame restarts the simulation at its current size
+func (m *Model) resetGame() {
+	rows, cols := m.simSize()
+	m.game.Reset(rows, cols, m.boundary, m.pattern)
+}
+
+// clampCursor keeps the edit cursor inside the grid
+func (m *Model) clampCursor() {
+	rows, cols := m.simSize()
+	m.curRow = max(min(m.curRow, rows-1), 0)
+	m.curCol = max(min(m.curCol, cols-1), 0)
+}
+
+// handleEditKey moves the cursor or toggles the cell under it while paused.
+// It reports whether the key was consumed.
+func (m *Model) handleEditKey(key string) bool {
+	if !m.paused {
+		return false
+	}
+
+	switch key {
+	case "up":
+		m.curRow--
+	case "down":
+		m.curRow++
+	case "left":
+		m.curCol--
+	case "right":
+		m.curCol++
+	case "x":
+		m.game.ToggleCell(m.curRow, m.curCol)
+		return true
+	default:
+		return false
+	}
+	m.clampCursor()
+	return true
+}
+
 // handleKeyPress processes keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
+
+	// Arrow keys edit the grid while paused and change speed while running
+	if m.handleEditKey(msg.String()) {
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "ctrl+c", "q", "esc":
 		return m, tea.Quit
@@ -131,35 +269,69 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "-", "_", "down": // Decrease refresh rate (make it slower)
 		m.refreshRate = m.refreshRate * 2
 
+	case "]": // Advance more generations per tick (fast-forward)
+		m.game.SetStepsPerTick(m.game.GetStepsPerTick() * 2)
+
+	case "[": // Advance fewer generations per tick
+		m.game.SetStepsPerTick(m.game.GetStepsPerTick() / 2)
+
 	case "p": // Cycle through patterns
-		m.pattern = Pattern((int(m.pattern) + 1) % 6) // We have 6 patterns
-		m.game.Reset(m.gridHeight, m.gridWidth, m.boundary, m.pattern)
+		m.pattern = m.pattern.Next(m.game.HasCustomPattern())
+		m.resetGame()
 
-	case "b": // Toggle boundary type
-		if m.boundary == BoundaryPeriodic {
-			m.boundary = BoundaryFixed
-		} else {
-			m.boundary = BoundaryPeriodic
-		}
-		m.game.Reset(m.gridHeight, m.gridWidth, m.boundary, m.pattern)
+	case "b": // Cycle through boundary types
+		m.boundary = m.boundary.Next()
+		m.resetGame()
+
+	case "shift+up": // Pan the view over the infinite plane
+		m.game.PanView(-PanStep, 0)
+
+	case "shift+down":
+		m.game.PanView(PanStep, 0)
+
+	case "shift+left":
+		m.game.PanView(0, -PanStep)
+
+	case "shift+right":
+		m.game.PanView(0, PanStep)
+
+	case "c": // Center the view on the live cells
+		m.game.CenterView()
+
+	case "s": // Save the session, to be resumed with -load-state
+		m.notice = m.saveNotice(m.stateFile, m.game.SaveState(m.stateFile))
+
+	case "e": // Export the live cells as an RLE pattern
+		m.notice = m.saveNotice(DefaultPatternFile, m.SavePatternFile(DefaultPatternFile))
 
 	case "r": // Reset simulation
 		m.currentStep = 0
-		m.game.Reset(m.gridHeight, m.gridWidth, m.boundary, m.pattern)
+		m.resetGame()
 	}
 
 	return m, nil
 }
 
+// saveNotice returns the status line message for saving to path
+func (m *Model) saveNotice(path string, err error) string {
+	if err != nil {
+		m.logger.Error("Failed to save", "path", path, "error", err)
+		return fmt.Sprintf(m.saveFailedLabel(), err)
+	}
+	return fmt.Sprintf(m.savedLabel(), path)
+}
+
 // handleTick processes timer ticks
-func (m Model) handleTick() (tea.Model, tea.Cmd) {
+func (m Model) handleTick(tick time.Time) (tea.Model, tea.Cmd) {
 	// Check if we should continue running (only update when not paused)
-	if !m.paused && m.game.Step() {
+	if !m.paused && !m.game.IsFinished() && m.game.Step() {
 		m.currentStep = m.game.GetGeneration()
 	}
 
-	// Continue ticking only if not quitting
-	return m, tea.Tick(m.refreshRate, func(t time.Time) tea.Msg {
+	// Continue ticking, scheduled from when this tick fired so time spent
+	// stepping does not stretch the interval
+	delay := pkg.NextTickDelay(m.refreshRate, MinRefreshRate, time.Since(tick))
+	return m, tea.Tick(delay, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -191,22 +363,51 @@ func (m *Model) RenderGrid() string {
 	// Pre-calculate styled strings to avoid repeated lookups
 	aliveStr := m.renderOptions.aliveStyled
 	deadStr := m.renderOptions.deadStyled
+	ageStr := m.renderOptions.ageStyled
+	ages := m.game.GetAges()
+
+	// A fixed size simulation is centered in the window: smaller grids are
+	// padded and larger ones show their middle
+	top, left := 0, 0
+	rowEnd, colEnd := len(grid), len(grid[0])
+	pad := " "
+	if m.fixedSize != nil {
+		top, left = m.centerOffset(len(grid), len(grid[0]))
+		rowEnd = min(rowEnd, top+m.gridHeight)
+		colEnd = min(colEnd, left+m.gridWidth)
+		for range -top {
+			m.gridBuffer.WriteByte('\n')
+		}
+		pad += strings.Repeat(" ", -min(left, 0)*m.renderOptions.cellWidth)
+		top, left = max(top, 0), max(left, 0)
+	}
 
 	// Render all rows efficiently with minimal allocations
-	lastRowIndex := len(grid) - 1
-	for i, row := range grid {
+	lastRowIndex := rowEnd - 1
+	for i := top; i < rowEnd; i++ {
+		row := grid[i]
 		if row == nil {
 			continue // Skip nil rows
 		}
 
-		m.gridBuffer.WriteString(" ")
+		m.gridBuffer.WriteString(pad)
 
 		// Render cells in the row with optimized string operations
-		for _, cell := range row {
-			if cell {
-				m.gridBuffer.WriteString(aliveStr)
-			} else {
+		for j := left; j < colEnd; j++ {
+			cell := row[j]
+			switch {
+			case m.paused && i == m.curRow && j == m.curCol:
+				if cell {
+					m.gridBuffer.WriteString(m.renderOptions.cursorAliveStyled)
+				} else {
+					m.gridBuffer.WriteString(m.renderOptions.cursorDeadStyled)
+				}
+			case !cell:
 				m.gridBuffer.WriteString(deadStr)
+			case m.ageColoring:
+				m.gridBuffer.WriteString(ageStr[ageBucket(ages[i][j])])
+			default:
+				m.gridBuffer.WriteString(aliveStr)
 			}
 		}
 
@@ -218,3 +419,10 @@ func (m *Model) RenderGrid() string {
 
 	return m.gridBuffer.String()
 }
+
+// centerOffset returns the first simulation row and column shown when a
+// rows×cols grid is centered in the window. Negative offsets mean the grid is
+// smaller than the window and is padded by that many rows or columns.
+func (m *Model) centerOffset(rows, cols int) (int, int) {
+	return (rows - m.gridHeight) / 2, (cols - m.gridWidth) / 2
+}
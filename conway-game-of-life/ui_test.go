@@ -0,0 +1,232 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pressKey sends a key press to the model and returns the updated model
+func pressKey(m Model, key tea.KeyMsg) Model {
+	updated, _ := m.Update(key)
+	return updated.(Model)
+}
+
+// Test that the cursor only moves and edits cells while paused
+func TestModel_CursorEditing(t *testing.T) {
+	m := NewModel(DefaultConfig)
+	m.game.Reset(m.gridHeight, m.gridWidth, BoundaryFixed, PatternGlider)
+	m.game.clearGrid()
+	m.game.countPopulation()
+
+	// While running, x does nothing and arrows change speed
+	rate := m.refreshRate
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyUp})
+	if m.game.GetCurrentGrid()[0][0] {
+		t.Error("Cell should not be editable while running")
+	}
+	if m.curRow != 0 || m.refreshRate == rate {
+		t.Error("Up should change speed, not move the cursor, while running")
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeySpace})
+	if !m.paused {
+		t.Fatal("Expected model to be paused")
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRight})
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRight})
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.curRow != 1 || m.curCol != 2 {
+		t.Errorf("Expected cursor at (1,2), got (%d,%d)", m.curRow, m.curCol)
+	}
+	if !m.game.GetCurrentGrid()[1][2] {
+		t.Error("Expected cell under cursor to be toggled alive")
+	}
+	if m.game.GetPopulation() != 1 {
+		t.Errorf("Expected population 1, got %d", m.game.GetPopulation())
+	}
+	if m.game.GetGeneration() != 0 {
+		t.Errorf("Editing should not advance the generation, got %d", m.game.GetGeneration())
+	}
+
+	// Cursor stays inside the grid
+	for range 3 {
+		m = pressKey(m, tea.KeyMsg{Type: tea.KeyUp})
+		m = pressKey(m, tea.KeyMsg{Type: tea.KeyLeft})
+	}
+	if m.curRow != 0 || m.curCol != 0 {
+		t.Errorf("Expected cursor clamped at (0,0), got (%d,%d)", m.curRow, m.curCol)
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if m.game.GetCurrentGrid()[0][0] || m.game.GetPopulation() != 1 {
+		t.Error("Toggling twice should restore the cell")
+	}
+}
+
+// Test changing the generations advanced per tick
+func TestModel_StepsPerTick(t *testing.T) {
+	m := NewModel(DefaultConfig)
+
+	for range 3 {
+		m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	}
+	if m.game.GetStepsPerTick() != 8 {
+		t.Fatalf("Expected 8 generations per tick, got %d", m.game.GetStepsPerTick())
+	}
+
+	updated, _ := m.Update(tickMsg{})
+	m = updated.(Model)
+	if m.currentStep != 8 {
+		t.Errorf("Expected generation 8 after one tick, got %d", m.currentStep)
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'['}})
+	if m.game.GetStepsPerTick() != 4 {
+		t.Errorf("Expected 4 generations per tick, got %d", m.game.GetStepsPerTick())
+	}
+}
+
+// Test that the model pans and centers with the keyboard in infinite mode
+func TestModel_InfinitePan(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.Infinite = true
+	m := NewModel(cfg)
+	if !m.game.IsInfinite() {
+		t.Fatal("Expected the config to enable infinite mode")
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyShiftDown})
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyShiftRight})
+	if row, col := m.game.GetViewOrigin(); row != PanStep || col != PanStep {
+		t.Errorf("Expected origin %d,%d, got %d,%d", PanStep, PanStep, row, col)
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if m.game.GetPopulation() > 0 {
+		if _, col := m.game.GetViewOrigin(); col == PanStep {
+			t.Error("Expected C to move the view onto the live cells")
+		}
+	}
+}
+
+// Test that a fixed size simulation survives window resizes and is centered
+func TestModel_FixedSize(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.SetFixedSize("12x30")
+	cfg.MonoCells = true
+	m := NewModel(cfg)
+
+	m.game.Step()
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m.game.Step()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 44, Height: 26})
+	m = updated.(Model)
+
+	grid := m.game.GetCurrentGrid()
+	if len(grid) != 12 || len(grid[0]) != 30 {
+		t.Fatalf("Expected the simulation to stay 12x30, got %dx%d", len(grid), len(grid[0]))
+	}
+	if m.game.GetGeneration() != 1 {
+		t.Errorf("Expected a resize not to reset the simulation, got generation %d", m.game.GetGeneration())
+	}
+	if m.gridHeight != 20 || m.gridWidth != 40 {
+		t.Errorf("Expected a 20x40 window grid, got %dx%d", m.gridHeight, m.gridWidth)
+	}
+
+	// 8 spare rows and 10 spare columns are split around the grid
+	lines := strings.Split(m.RenderGrid(), "\n")
+	if len(lines) != 16 || lines[0] != "" || lines[3] != "" {
+		t.Fatalf("Expected 4 padding rows above 12 grid rows, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[4], strings.Repeat(" ", 6)) {
+		t.Errorf("Expected the grid to be indented by 1+5 columns, got %q", lines[4])
+	}
+
+	// A smaller window shows the middle of the grid
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 24, Height: 14})
+	m = updated.(Model)
+	if lines := strings.Split(m.RenderGrid(), "\n"); len(lines) != m.gridHeight {
+		t.Errorf("Expected the grid clipped to %d rows, got %d", m.gridHeight, len(lines))
+	}
+	if !strings.Contains(m.StatusLineView(), "12×30") || !strings.Contains(m.StatusLineView(), "8×20") {
+		t.Error("Expected the status line to show both the simulation and window sizes")
+	}
+}
+
+// Test that S saves the session and -load-state resumes it at its saved size
+func TestModel_SaveLoadState(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.StateFile = filepath.Join(t.TempDir(), "session.json")
+	m := NewModel(cfg)
+	m.game.Step()
+	m.game.Step()
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if !strings.Contains(m.notice, cfg.StateFile) {
+		t.Fatalf("Expected a notice naming the state file, got %q", m.notice)
+	}
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeySpace})
+	if m.notice != "" {
+		t.Errorf("Expected the next key to clear the notice, got %q", m.notice)
+	}
+
+	loaded := NewModel(DefaultConfig)
+	if err := loaded.LoadStateFile(cfg.StateFile); err != nil {
+		t.Fatalf("LoadStateFile failed: %v", err)
+	}
+	updated, _ := loaded.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	loaded = updated.(Model)
+	if loaded.currentStep != 2 || loaded.game.GetGeneration() != 2 {
+		t.Errorf("Expected a resize to keep generation 2, got %d", loaded.game.GetGeneration())
+	}
+	if !reflect.DeepEqual(loaded.game.GetCurrentGrid(), m.game.GetCurrentGrid()) {
+		t.Error("Expected the loaded grid to match the saved one")
+	}
+}
+
+// Test that invalid fixed sizes fall back to following the terminal
+func TestConfig_FixedSize(t *testing.T) {
+	for _, size := range []string{"12", "axb", "5x30", "12x5000"} {
+		cfg := DefaultConfig
+		cfg.SetFixedSize(size)
+		cfg.Check()
+		if cfg.FixedSize != nil {
+			t.Errorf("Expected size %q to be rejected, got %+v", size, *cfg.FixedSize)
+		}
+	}
+}
+
+// Test that P cycles through every built-in pattern, including random, and
+// through a loaded pattern once there is one
+func TestModel_PatternCycle(t *testing.T) {
+	p := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}}
+	m := NewModel(DefaultConfig)
+	for want := PatternGlider; want <= PatternDiehard; want++ {
+		if m = pressKey(m, p); m.pattern != want {
+			t.Fatalf("Expected pattern %v, got %v", want, m.pattern)
+		}
+	}
+	if m = pressKey(m, p); m.pattern != PatternRandom {
+		t.Fatalf("Expected the cycle to skip custom without a loaded pattern, got %v", m.pattern)
+	}
+
+	if err := m.game.LoadRLE(strings.NewReader("x = 3, y = 1\n3o!")); err != nil {
+		t.Fatalf("LoadRLE failed: %v", err)
+	}
+	m.pattern = PatternCustom
+	if m = pressKey(m, p); m.pattern != PatternRandom {
+		t.Errorf("Expected custom to be followed by random, got %v", m.pattern)
+	}
+	m.pattern = PatternDiehard
+	if m = pressKey(m, p); m.pattern != PatternCustom {
+		t.Errorf("Expected the loaded pattern after the built-in ones, got %v", m.pattern)
+	}
+}
@@ -2,7 +2,13 @@
 package main
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 // Language represents the supported languages
@@ -44,15 +50,66 @@ const (
 	DefaultProfileInterval = 5 * time.Second
 )
 
+// runeRange is an inclusive range of Unicode code points
+type runeRange struct {
+	lo, hi rune
+}
+
+// charSetPresets maps preset names to the Unicode ranges they draw from
+var charSetPresets = map[string][]runeRange{
+	"katakana": {{0xFF66, 0xFF9D}}, // Half-width Katakana, as in the films
+	"binary":   {{'0', '1'}},
+	"hex":      {{'0', '9'}, {'A', 'F'}},
+	"ascii":    {{'!', '~'}},
+	"kanji":    {{0x4E00, 0x9FFF}}, // CJK Unified Ideographs, double width
+}
+
+// CharSetPresetNames returns the preset names in sorted order
+func CharSetPresetNames() []string {
+	names := make([]string, 0, len(charSetPresets))
+	for name := range charSetPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CharSetFromPreset builds the character set for a named preset
+func CharSetFromPreset(name string) (string, bool) {
+	ranges, ok := charSetPresets[strings.ToLower(name)]
+	if !ok {
+		return "", false
+	}
+	var sb strings.Builder
+	for _, r := range ranges {
+		for c := r.lo; c <= r.hi; c++ {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String(), true
+}
+
+// CellWidth returns the number of terminal columns each rain column needs,
+// which is 2 when the character set contains any double-width character
+func CellWidth(charSet string) int {
+	width := 1
+	for _, r := range charSet {
+		width = max(width, lipgloss.Width(string(r)))
+	}
+	return width
+}
+
 // Config holds the configuration for the digital rain
 type Config struct {
 	DropColor       string
 	TrailColor      string
 	BackgroundColor string
 	CharSet         string
+	CharSetPreset   string // Named charset that replaces CharSet when set
 	MinSpeed        int
 	MaxSpeed        int
 	DropLength      int
+	TrailGradient   bool // Fade each drop from DropColor at the head to BackgroundColor at the tail
 	Language        Language
 }
 
@@ -77,6 +134,22 @@ func (c *Config) Check() {
 	if c.BackgroundColor == "" {
 		c.BackgroundColor = DefaultBackgroundColor
 	}
+	for _, msg := range pkg.Validate(
+		pkg.HexColor("drop", &c.DropColor, DefaultDropColor),
+		pkg.HexColor("trail", &c.TrailColor, DefaultTrailColor),
+		pkg.HexColor("background", &c.BackgroundColor, DefaultBackgroundColor),
+		pkg.Language(&c.Language, DefaultLanguage),
+	) {
+		fmt.Println(msg)
+	}
+	if c.CharSetPreset != "" {
+		if charSet, ok := CharSetFromPreset(c.CharSetPreset); ok {
+			c.CharSet = charSet
+		} else {
+			fmt.Printf("invalid charset preset %q, using -charset instead\n", c.CharSetPreset)
+			c.CharSetPreset = ""
+		}
+	}
 	if c.CharSet == "" {
 		c.CharSet = DefaultCharSet
 	}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	_ "net/http/pprof" //nolint:gosec
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/telepair/go-playground/pkg"
@@ -23,6 +24,7 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s                              # Run with default settings\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -drop-color '#FFFFFF'        # White rain drops\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -charset '01'                # Binary rain\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -charset-preset katakana     # Classic half-width Katakana rain\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -lang cn                     # Run in Chinese\n", os.Args[0])
 	}
 
@@ -31,9 +33,12 @@ func main() {
 	var trailColor = flag.String("trail-color", DefaultTrailColor, "Trail color (hex)")
 	var bgColor = flag.String("bg-color", DefaultBackgroundColor, "Background color (hex)")
 	var charset = flag.String("charset", DefaultCharSet, "Character set to use")
+	var charsetPreset = flag.String("charset-preset", "",
+		"Named character set, overrides -charset ("+strings.Join(CharSetPresetNames(), "/")+")")
 	var minSpeed = flag.Int("min-speed", DefaultMinSpeed, "Minimum drop speed")
 	var maxSpeed = flag.Int("max-speed", DefaultMaxSpeed, "Maximum drop speed")
 	var dropLength = flag.Int("drop-length", DefaultDropLength, "Drop length")
+	var trailGradient = flag.Bool("trail-gradient", false, "Fade each drop from the drop color to the background color")
 	var lang = flag.String("lang", DefaultLanguage.ToString(), "Language (en/cn)")
 	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
 	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
@@ -43,7 +48,7 @@ func main() {
 	flag.Parse()
 
 	if *logFile != "" {
-		_ = pkg.InitLog("debug", "text", *logFile)
+		_, _ = pkg.InitLog("debug", "text", *logFile)
 	}
 	slog.Debug("Digital Rain starting")
 
@@ -62,13 +67,18 @@ func main() {
 		TrailColor:      *trailColor,
 		BackgroundColor: *bgColor,
 		CharSet:         *charset,
+		CharSetPreset:   *charsetPreset,
 		MinSpeed:        *minSpeed,
 		MaxSpeed:        *maxSpeed,
 		DropLength:      *dropLength,
+		TrailGradient:   *trailGradient,
 	}
 	config.SetLanguage(*lang)
 	config.Check()
 
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
 	// Create initial model
 	initialModel := NewModel(config)
 
@@ -10,7 +10,7 @@ import (
 type Drop struct {
 	X        int    // Column position
 	Y        int    // Current head position
-	Speed    int    // Fall speed (cells per update)
+	Speed    int    // Frames per cell moved (higher is slower)
 	Length   int    // Length of the trail
 	Chars    []rune // Characters in this drop
 	NextMove int    // Counter for next move
@@ -25,6 +25,8 @@ type DigitalRain struct {
 	drops    []*Drop
 	grid     [][]rune
 	trail    [][]int // Trail intensity (0-255)
+	headDist [][]int // Distance of each drawn character from its drop's head
+	speeds   []int   // Stable speed of each column, reseeded on Reset
 	charSet  []rune
 	minSpeed int
 	maxSpeed int
@@ -56,14 +58,18 @@ func (dr *DigitalRain) Reset(width, height int) {
 	// Initialize grid
 	dr.grid = make([][]rune, height)
 	dr.trail = make([][]int, height)
+	dr.headDist = make([][]int, height)
 	for i := 0; i < height; i++ {
 		dr.grid[i] = make([]rune, width)
 		dr.trail[i] = make([]int, width)
+		dr.headDist[i] = make([]int, width)
 	}
 
-	// Initialize drops (one per column)
+	// Pick a speed per column and initialize drops (one per column)
+	dr.speeds = make([]int, width)
 	dr.drops = make([]*Drop, width)
 	for i := 0; i < width; i++ {
+		dr.speeds[i] = dr.minSpeed + rand.Intn(dr.maxSpeed-dr.minSpeed+1)
 		dr.drops[i] = dr.createNewDrop(i)
 	}
 }
@@ -78,7 +84,7 @@ func (dr *DigitalRain) createNewDrop(x int) *Drop {
 	drop := &Drop{
 		X:        x,
 		Y:        -length, // Start above the screen
-		Speed:    dr.minSpeed + rand.Intn(dr.maxSpeed-dr.minSpeed+1),
+		Speed:    dr.speeds[x],
 		Length:   length,
 		Chars:    make([]rune, length),
 		NextMove: 0,
@@ -140,6 +146,7 @@ func (dr *DigitalRain) Step() {
 			y := drop.Y - j
 			if y >= 0 && y < dr.height {
 				dr.grid[y][drop.X] = drop.Chars[j]
+				dr.headDist[y][drop.X] = j
 				// Set trail intensity (brighter at head)
 				intensity := 255 - (j * 255 / drop.Length)
 				if intensity > dr.trail[y][drop.X] {
@@ -169,6 +176,21 @@ func (dr *DigitalRain) GetTrail() [][]int {
 	return dr.trail
 }
 
+// GetHeadDistance returns, for every drawn character, its distance from the
+// head of its drop (0 at the head)
+func (dr *DigitalRain) GetHeadDistance() [][]int {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+	return dr.headDist
+}
+
+// GetColumnSpeeds returns the speed of each column
+func (dr *DigitalRain) GetColumnSpeeds() []int {
+	dr.mu.RLock()
+	defer dr.mu.RUnlock()
+	return dr.speeds
+}
+
 // GetDimensions returns the current width and height
 func (dr *DigitalRain) GetDimensions() (int, int) {
 	dr.mu.RLock()
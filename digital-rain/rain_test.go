@@ -0,0 +1,51 @@
+package main
+
+import "testing"
+
+// Test that every column gets a speed within the configured range
+func TestColumnSpeedsInRange(t *testing.T) {
+	dr := NewDigitalRain(50, 20, DefaultCharSet, 2, 4, 5)
+	speeds := dr.GetColumnSpeeds()
+	if len(speeds) != 50 {
+		t.Fatalf("Expected 50 column speeds, got %d", len(speeds))
+	}
+	for i, speed := range speeds {
+		if speed < 2 || speed > 4 {
+			t.Errorf("Column %d: expected speed in [2, 4], got %d", i, speed)
+		}
+	}
+
+	dr.Reset(30, 20)
+	if speeds := dr.GetColumnSpeeds(); len(speeds) != 30 {
+		t.Errorf("Expected 30 column speeds after reset, got %d", len(speeds))
+	}
+}
+
+// Test that columns advance at their own stable speeds
+func TestColumnsAdvanceAtOwnSpeed(t *testing.T) {
+	dr := NewDigitalRain(2, 100, DefaultCharSet, 1, 3, 5)
+
+	// Pin the two columns to the ends of the range and restart their drops
+	dr.speeds[0], dr.speeds[1] = 1, 3
+	for i := range dr.drops {
+		dr.drops[i] = dr.createNewDrop(i)
+	}
+	start0, start1 := dr.drops[0].Y, dr.drops[1].Y
+
+	const ticks = 12
+	for i := 0; i < ticks; i++ {
+		dr.Step()
+	}
+
+	if moved := dr.drops[0].Y - start0; moved != ticks {
+		t.Errorf("Expected fast column to move %d cells, got %d", ticks, moved)
+	}
+	if moved := dr.drops[1].Y - start1; moved != ticks/3 {
+		t.Errorf("Expected slow column to move %d cells, got %d", ticks/3, moved)
+	}
+
+	// Restarted drops keep the column's speed
+	if drop := dr.createNewDrop(1); drop.Speed != 3 {
+		t.Errorf("Expected new drop in column 1 to have speed 3, got %d", drop.Speed)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 // RenderOptions holds rendering options for the digital rain
@@ -14,13 +15,15 @@ type RenderOptions struct {
 	bgStyle   lipgloss.Style
 	// Pre-computed trail styles for different intensities
 	trailStyles map[int]lipgloss.Style
+	// Pre-computed head-to-tail gradient, nil when disabled
+	gradient []lipgloss.Style
 }
 
 // NewRenderOptions creates new render options with the given colors
 func NewRenderOptions(dropColor, trailColor, backgroundColor string) RenderOptions {
 	opts := RenderOptions{
-		dropStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color(dropColor)),
-		bgStyle:     lipgloss.NewStyle().Background(lipgloss.Color(backgroundColor)),
+		dropStyle:   lipgloss.NewStyle().Foreground(pkg.AdaptColor(dropColor)),
+		bgStyle:     lipgloss.NewStyle().Background(pkg.AdaptColor(backgroundColor)),
 		trailStyles: make(map[int]lipgloss.Style),
 	}
 
@@ -34,12 +37,41 @@ func NewRenderOptions(dropColor, trailColor, backgroundColor string) RenderOptio
 		g := int(float64(trailRGB.g) + (float64(dropRGB.g-trailRGB.g) * intensity))
 		b := int(float64(trailRGB.b) + (float64(dropRGB.b-trailRGB.b) * intensity))
 		color := fmt.Sprintf("#%02x%02x%02x", r, g, b)
-		opts.trailStyles[i] = lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+		opts.trailStyles[i] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(color))
 	}
 
 	return opts
 }
 
+// SetGradient pre-computes a gradient of steps styles fading from the drop
+// color at the head to the background color at the tail
+func (ro *RenderOptions) SetGradient(dropColor, backgroundColor string, steps int) {
+	from := hexToRGB(dropColor)
+	to := hexToRGB(backgroundColor)
+
+	ro.gradient = make([]lipgloss.Style, max(steps, 1))
+	for i := range ro.gradient {
+		// The last step stays one step short of the background so it is visible
+		t := float64(i) / float64(len(ro.gradient))
+		r := int(float64(from.r) + float64(to.r-from.r)*t)
+		g := int(float64(from.g) + float64(to.g-from.g)*t)
+		b := int(float64(from.b) + float64(to.b-from.b)*t)
+		color := fmt.Sprintf("#%02x%02x%02x", r, g, b)
+		ro.gradient[i] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(color))
+	}
+}
+
+// ClearGradient disables the head-to-tail gradient
+func (ro *RenderOptions) ClearGradient() {
+	ro.gradient = nil
+}
+
+// GetGradientStyle returns the gradient style for a character at the given
+// distance from its drop's head; drops longer than the gradient use its tail
+func (ro *RenderOptions) GetGradientStyle(dist int) lipgloss.Style {
+	return ro.gradient[min(max(dist, 0), len(ro.gradient)-1)]
+}
+
 // RGB represents an RGB color
 type RGB struct {
 	r, g, b int
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 var (
@@ -24,6 +26,7 @@ type Model struct {
 	height        int
 	gridWidth     int
 	gridHeight    int
+	cellWidth     int // Terminal columns per rain column, 2 for wide characters
 	buffer        strings.Builder
 	renderOptions RenderOptions
 	config        Config
@@ -36,9 +39,15 @@ func NewModel(cfg Config) Model {
 
 	gridHeight := DefaultRows - keepHeight
 	gridWidth := DefaultCols - keepWidth
+	cellWidth := CellWidth(cfg.CharSet)
+
+	renderOptions := NewRenderOptions(cfg.DropColor, cfg.TrailColor, cfg.BackgroundColor)
+	if cfg.TrailGradient {
+		renderOptions.SetGradient(cfg.DropColor, cfg.BackgroundColor, cfg.DropLength)
+	}
 
 	model := Model{
-		rain:          NewDigitalRain(gridWidth, gridHeight, cfg.CharSet, cfg.MinSpeed, cfg.MaxSpeed, cfg.DropLength),
+		rain:          NewDigitalRain(gridWidth/cellWidth, gridHeight, cfg.CharSet, cfg.MinSpeed, cfg.MaxSpeed, cfg.DropLength),
 		language:      cfg.Language,
 		paused:        false,
 		refreshRate:   DefaultRefreshRate,
@@ -46,7 +55,8 @@ func NewModel(cfg Config) Model {
 		height:        DefaultRows,
 		gridWidth:     gridWidth,
 		gridHeight:    gridHeight,
-		renderOptions: NewRenderOptions(cfg.DropColor, cfg.TrailColor, cfg.BackgroundColor),
+		cellWidth:     cellWidth,
+		renderOptions: renderOptions,
 		config:        cfg,
 		logger:        slog.With("module", "ui"),
 	}
@@ -74,7 +84,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.logger.Debug("Key pressed", "key", msg.String())
 		return m.handleKeyPress(msg)
 	case tickMsg:
-		return m.handleTick()
+		return m.handleTick(time.Time(msg))
 	}
 	return m, nil
 }
@@ -97,7 +107,7 @@ func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.height = msg.Height
 	m.gridWidth = msg.Width - keepWidth
 	m.gridHeight = msg.Height - keepHeight
-	m.rain.Reset(m.gridWidth, m.gridHeight)
+	m.rain.Reset(m.columns(), m.gridHeight)
 	return m, nil
 }
 
@@ -124,33 +134,39 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.refreshRate = m.refreshRate * 2
 
 	case "r": // Reset
-		m.rain.Reset(m.gridWidth, m.gridHeight)
+		m.rain.Reset(m.columns(), m.gridHeight)
 
 	case "d": // Increase drop length
 		if m.config.DropLength < 20 {
 			m.config.DropLength++
-			m.rain = NewDigitalRain(m.gridWidth, m.gridHeight, m.config.CharSet,
+			m.rain = NewDigitalRain(m.columns(), m.gridHeight, m.config.CharSet,
 				m.config.MinSpeed, m.config.MaxSpeed, m.config.DropLength)
+			m.updateGradient()
 		}
 
 	case "D": // Decrease drop length
 		if m.config.DropLength > 3 {
 			m.config.DropLength--
-			m.rain = NewDigitalRain(m.gridWidth, m.gridHeight, m.config.CharSet,
+			m.rain = NewDigitalRain(m.columns(), m.gridHeight, m.config.CharSet,
 				m.config.MinSpeed, m.config.MaxSpeed, m.config.DropLength)
+			m.updateGradient()
 		}
 
+	case "g": // Toggle head-to-tail gradient
+		m.config.TrailGradient = !m.config.TrailGradient
+		m.updateGradient()
+
 	case "s": // Increase max speed
 		if m.config.MaxSpeed < 10 {
 			m.config.MaxSpeed++
-			m.rain = NewDigitalRain(m.gridWidth, m.gridHeight, m.config.CharSet,
+			m.rain = NewDigitalRain(m.columns(), m.gridHeight, m.config.CharSet,
 				m.config.MinSpeed, m.config.MaxSpeed, m.config.DropLength)
 		}
 
 	case "S": // Decrease max speed
 		if m.config.MaxSpeed > m.config.MinSpeed {
 			m.config.MaxSpeed--
-			m.rain = NewDigitalRain(m.gridWidth, m.gridHeight, m.config.CharSet,
+			m.rain = NewDigitalRain(m.columns(), m.gridHeight, m.config.CharSet,
 				m.config.MinSpeed, m.config.MaxSpeed, m.config.DropLength)
 		}
 	}
@@ -158,13 +174,31 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// columns returns how many rain columns fit in the grid width
+func (m Model) columns() int {
+	return m.gridWidth / m.cellWidth
+}
+
+// updateGradient rebuilds the gradient palette for the current drop length,
+// or clears it when the gradient is disabled
+func (m *Model) updateGradient() {
+	if m.config.TrailGradient {
+		m.renderOptions.SetGradient(m.config.DropColor, m.config.BackgroundColor, m.config.DropLength)
+	} else {
+		m.renderOptions.ClearGradient()
+	}
+}
+
 // handleTick processes timer ticks
-func (m Model) handleTick() (tea.Model, tea.Cmd) {
+func (m Model) handleTick(tick time.Time) (tea.Model, tea.Cmd) {
 	if !m.paused {
 		m.rain.Step()
 	}
 
-	return m, tea.Tick(m.refreshRate, func(t time.Time) tea.Msg {
+	// Schedule from when this tick fired, so time spent stepping does not
+	// stretch the interval
+	delay := pkg.NextTickDelay(m.refreshRate, MinRefreshRate, time.Since(tick))
+	return m, tea.Tick(delay, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -223,9 +257,9 @@ func (m Model) renderStatus() string {
 func (m Model) renderControls() string {
 	var controls string
 	if m.language == Chinese {
-		controls = "空格: 暂停/继续 | +/-: 调整速度 | d/D: 雨滴长度 | s/S: 最大速度 | r: 重置 | l: 语言 | q: 退出"
+		controls = "空格: 暂停/继续 | +/-: 调整速度 | d/D: 雨滴长度 | s/S: 最大速度 | g: 渐变 | r: 重置 | l: 语言 | q: 退出"
 	} else {
-		controls = "Space: Pause | +/-: Speed | d/D: Drop Length | s/S: Max Speed | r: Reset | l: Language | q: Quit"
+		controls = "Space: Pause | +/-: Speed | d/D: Drop Length | s/S: Max Speed | g: Gradient | r: Reset | l: Language | q: Quit"
 	}
 	return helpStyle().Render(controls)
 }
@@ -235,25 +269,32 @@ func (m Model) renderGrid() string {
 	var sb strings.Builder
 	grid := m.rain.GetGrid()
 	trail := m.rain.GetTrail()
+	headDist := m.rain.GetHeadDistance()
 
 	if len(grid) == 0 {
 		return ""
 	}
+	blank := strings.Repeat(" ", m.cellWidth)
 
 	for i := 0; i < len(grid); i++ {
 		for j := 0; j < len(grid[i]); j++ {
 			if grid[i][j] != 0 {
 				// Character with appropriate style based on trail intensity
+				// Pad narrow characters so every column spans cellWidth cells
 				char := string(grid[i][j])
+				char += blank[:m.cellWidth-lipgloss.Width(char)]
 				intensity := trail[i][j]
-				if intensity > 200 {
+				if m.renderOptions.gradient != nil {
+					style := m.renderOptions.GetGradientStyle(headDist[i][j])
+					sb.WriteString(style.Render(char))
+				} else if intensity > 200 {
 					sb.WriteString(m.renderOptions.dropStyle.Render(char))
 				} else {
 					style := m.renderOptions.GetTrailStyle(intensity)
 					sb.WriteString(style.Render(char))
 				}
 			} else {
-				sb.WriteString(" ")
+				sb.WriteString(blank)
 			}
 		}
 		if i < len(grid)-1 {
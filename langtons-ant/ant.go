@@ -0,0 +1,193 @@
+package main
+
+import (
+	"strings"
+	"sync"
+)
+
+// Direction is the way the ant is facing
+type Direction int
+
+// Direction constants, in clockwise order
+const (
+	DirectionUp Direction = iota
+	DirectionRight
+	DirectionDown
+	DirectionLeft
+)
+
+// ToString returns the string representation of a direction
+func (d Direction) ToString(language Language) string {
+	switch d {
+	case DirectionRight:
+		if language == Chinese {
+			return "右"
+		}
+		return "Right"
+	case DirectionDown:
+		if language == Chinese {
+			return "下"
+		}
+		return "Down"
+	case DirectionLeft:
+		if language == Chinese {
+			return "左"
+		}
+		return "Left"
+	default:
+		if language == Chinese {
+			return "上"
+		}
+		return "Up"
+	}
+}
+
+// Arrow returns the character drawn for an ant facing this direction
+func (d Direction) Arrow() string {
+	switch d {
+	case DirectionRight:
+		return "▶"
+	case DirectionDown:
+		return "▼"
+	case DirectionLeft:
+		return "◀"
+	default:
+		return "▲"
+	}
+}
+
+// TurnRight returns the direction after a clockwise quarter turn
+func (d Direction) TurnRight() Direction {
+	return (d + 1) % 4
+}
+
+// TurnLeft returns the direction after a counter-clockwise quarter turn
+func (d Direction) TurnLeft() Direction {
+	return (d + 3) % 4
+}
+
+// Ant is the position and heading of the ant
+type Ant struct {
+	Row       int
+	Col       int
+	Direction Direction
+}
+
+// LangtonsAnt is a generalized Langton's Ant on a toroidal grid. Each cell
+// holds a state in [0, len(rule)); on a cell in state s the ant turns by
+// rule[s], advances the cell to state s+1 (wrapping), and moves forward.
+type LangtonsAnt struct {
+	mu    sync.RWMutex
+	rows  int
+	cols  int
+	grid  [][]uint8
+	rule  string
+	ant   Ant
+	steps int
+}
+
+// NewLangtonsAnt creates an ant in the center of an empty grid. The rule
+// must already be valid (see ValidateRule).
+func NewLangtonsAnt(rows, cols int, rule string) *LangtonsAnt {
+	la := &LangtonsAnt{rule: strings.ToUpper(rule)}
+	la.Reset(rows, cols)
+	return la
+}
+
+// Reset clears the grid to the given size and recenters the ant, facing up
+func (la *LangtonsAnt) Reset(rows, cols int) {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+
+	la.rows = max(rows, 1)
+	la.cols = max(cols, 1)
+	la.grid = make([][]uint8, la.rows)
+	for i := range la.grid {
+		la.grid[i] = make([]uint8, la.cols)
+	}
+	la.ant = Ant{Row: la.rows / 2, Col: la.cols / 2, Direction: DirectionUp}
+	la.steps = 0
+}
+
+// SetRule switches to a new rule and restarts on an empty grid
+func (la *LangtonsAnt) SetRule(rule string) error {
+	rule = strings.ToUpper(rule)
+	if err := ValidateRule(rule); err != nil {
+		return err
+	}
+	la.mu.Lock()
+	la.rule = rule
+	rows, cols := la.rows, la.cols
+	la.mu.Unlock()
+
+	la.Reset(rows, cols)
+	return nil
+}
+
+// Step moves the ant n times
+func (la *LangtonsAnt) Step(n int) {
+	la.mu.Lock()
+	defer la.mu.Unlock()
+
+	for range n {
+		la.move()
+	}
+}
+
+// move performs a single turn-flip-advance move
+func (la *LangtonsAnt) move() {
+	cell := &la.grid[la.ant.Row][la.ant.Col]
+	if la.rule[*cell] == 'R' {
+		la.ant.Direction = la.ant.Direction.TurnRight()
+	} else {
+		la.ant.Direction = la.ant.Direction.TurnLeft()
+	}
+	*cell = uint8((int(*cell) + 1) % len(la.rule)) //nolint:gosec // rules are at most MaxRuleLength long
+
+	switch la.ant.Direction {
+	case DirectionUp:
+		la.ant.Row = (la.ant.Row - 1 + la.rows) % la.rows
+	case DirectionRight:
+		la.ant.Col = (la.ant.Col + 1) % la.cols
+	case DirectionDown:
+		la.ant.Row = (la.ant.Row + 1) % la.rows
+	case DirectionLeft:
+		la.ant.Col = (la.ant.Col - 1 + la.cols) % la.cols
+	}
+	la.steps++
+}
+
+// GetGrid returns the cell states
+func (la *LangtonsAnt) GetGrid() [][]uint8 {
+	la.mu.RLock()
+	defer la.mu.RUnlock()
+	return la.grid
+}
+
+// GetAnt returns the ant's position and heading
+func (la *LangtonsAnt) GetAnt() Ant {
+	la.mu.RLock()
+	defer la.mu.RUnlock()
+	return la.ant
+}
+
+// GetRule returns the current rule
+func (la *LangtonsAnt) GetRule() string {
+	la.mu.RLock()
+	defer la.mu.RUnlock()
+	return la.rule
+}
+
+// GetSteps returns the number of moves since the last reset
+func (la *LangtonsAnt) GetSteps() int {
+	la.mu.RLock()
+	defer la.mu.RUnlock()
+	return la.steps
+}
+
+// GetDimensions returns the grid rows and columns
+func (la *LangtonsAnt) GetDimensions() (int, int) {
+	la.mu.RLock()
+	defer la.mu.RUnlock()
+	return la.rows, la.cols
+}
@@ -0,0 +1,163 @@
+package main
+
+import "testing"
+
+// countCells returns the number of cells in a non-zero state
+func countCells(grid [][]uint8) int {
+	count := 0
+	for _, row := range grid {
+		for _, state := range row {
+			if state != 0 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Test the first moves of the classic ant on an empty grid
+func TestClassicAntFirstMoves(t *testing.T) {
+	la := NewLangtonsAnt(10, 10, "RL")
+	start := la.GetAnt()
+	if start.Row != 5 || start.Col != 5 || start.Direction != DirectionUp {
+		t.Fatalf("Expected ant centered at (5,5) facing up, got %+v", start)
+	}
+
+	la.Step(1)
+	if ant := la.GetAnt(); ant.Row != 5 || ant.Col != 6 || ant.Direction != DirectionRight {
+		t.Errorf("Expected ant at (5,6) facing right after one move, got %+v", ant)
+	}
+	if la.GetGrid()[5][5] != 1 {
+		t.Error("Expected the starting cell to be flipped")
+	}
+
+	// Four right turns on empty cells draw a 2x2 square and return home
+	la.Step(3)
+	if ant := la.GetAnt(); ant.Row != 5 || ant.Col != 5 || ant.Direction != DirectionUp {
+		t.Errorf("Expected ant back at (5,5) facing up, got %+v", ant)
+	}
+	if count := countCells(la.GetGrid()); count != 4 {
+		t.Errorf("Expected 4 flipped cells, got %d", count)
+	}
+
+	// On a flipped cell the ant turns left and flips it back
+	la.Step(1)
+	if ant := la.GetAnt(); ant.Row != 5 || ant.Col != 4 || ant.Direction != DirectionLeft {
+		t.Errorf("Expected ant at (5,4) facing left, got %+v", ant)
+	}
+	if la.GetGrid()[5][5] != 0 {
+		t.Error("Expected the starting cell to be flipped back")
+	}
+	if steps := la.GetSteps(); steps != 5 {
+		t.Errorf("Expected 5 steps, got %d", steps)
+	}
+}
+
+// Test that cells cycle through every state of a multi-color rule
+func TestMultiStateRule(t *testing.T) {
+	la := NewLangtonsAnt(20, 20, "RLR")
+	la.Step(500)
+	for _, row := range la.GetGrid() {
+		for _, state := range row {
+			if state > 2 {
+				t.Fatalf("Expected states below 3 for a 3-turn rule, got %d", state)
+			}
+		}
+	}
+}
+
+// Test that the ant wraps around the edges
+func TestAntWraps(t *testing.T) {
+	la := NewLangtonsAnt(1, 3, "RL")
+	// On a single row, moving up or down stays on row 0
+	la.Step(10)
+	if ant := la.GetAnt(); ant.Row != 0 || ant.Col < 0 || ant.Col >= 3 {
+		t.Errorf("Expected ant inside the 1x3 grid, got %+v", ant)
+	}
+}
+
+// Test resetting and changing rules
+func TestResetAndSetRule(t *testing.T) {
+	la := NewLangtonsAnt(10, 10, "RL")
+	la.Step(100)
+
+	la.Reset(6, 8)
+	if rows, cols := la.GetDimensions(); rows != 6 || cols != 8 {
+		t.Errorf("Expected 6x8 grid, got %dx%d", rows, cols)
+	}
+	if ant := la.GetAnt(); ant.Row != 3 || ant.Col != 4 || ant.Direction != DirectionUp {
+		t.Errorf("Expected ant recentered at (3,4) facing up, got %+v", ant)
+	}
+	if la.GetSteps() != 0 || countCells(la.GetGrid()) != 0 {
+		t.Error("Expected an empty grid after reset")
+	}
+
+	la.Step(50)
+	if err := la.SetRule("llrr"); err != nil {
+		t.Fatalf("SetRule failed: %v", err)
+	}
+	if rule := la.GetRule(); rule != "LLRR" {
+		t.Errorf("Expected rule LLRR, got %s", rule)
+	}
+	if la.GetSteps() != 0 || countCells(la.GetGrid()) != 0 {
+		t.Error("Expected an empty grid after changing the rule")
+	}
+
+	if err := la.SetRule("RX"); err == nil {
+		t.Error("Expected error for an invalid rule")
+	}
+	if rule := la.GetRule(); rule != "LLRR" {
+		t.Errorf("Expected rule unchanged after an invalid rule, got %s", rule)
+	}
+}
+
+// Test rule validation
+func TestValidateRule(t *testing.T) {
+	tests := []struct {
+		rule  string
+		valid bool
+	}{
+		{"RL", true},
+		{"RLR", true},
+		{"RRLLLRLLLRRR", true},
+		{"R", false},
+		{"", false},
+		{"RLX", false},
+		{"rl", false},
+		{"RLRLRLRLRLRLRL", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateRule(tt.rule); (err == nil) != tt.valid {
+			t.Errorf("ValidateRule(%q): expected valid=%v, got error %v", tt.rule, tt.valid, err)
+		}
+	}
+	for _, rule := range RulePresets {
+		if err := ValidateRule(rule); err != nil {
+			t.Errorf("Preset %s is invalid: %v", rule, err)
+		}
+	}
+}
+
+// Test configuration validation
+func TestConfigCheck(t *testing.T) {
+	cfg := Config{Rule: "rlr", StepsPerTick: 0, AntColor: "red", CellColor: "#123456", EmptyColor: "#000000", CellChar: "##", EmptyChar: " "}
+	cfg.Check()
+	if cfg.Rule != "RLR" {
+		t.Errorf("Expected rule RLR, got %s", cfg.Rule)
+	}
+	if cfg.StepsPerTick != DefaultStepsPerTick {
+		t.Errorf("Expected default steps per tick, got %d", cfg.StepsPerTick)
+	}
+	if cfg.AntColor != DefaultAntColor {
+		t.Errorf("Expected default ant color, got %s", cfg.AntColor)
+	}
+	if cfg.CellChar != DefaultCellChar {
+		t.Errorf("Expected default cell char, got %s", cfg.CellChar)
+	}
+
+	cfg = Config{Rule: "ABC"}
+	cfg.Check()
+	if cfg.Rule != DefaultRule {
+		t.Errorf("Expected default rule, got %s", cfg.Rule)
+	}
+}
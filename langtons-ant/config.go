@@ -0,0 +1,165 @@
+// Package main implements a terminal-based Langton's Ant visualization.
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/telepair/go-playground/pkg"
+)
+
+// Language represents the supported languages
+type Language int
+
+// Language constants
+const (
+	English Language = iota
+	Chinese
+)
+
+// ToString returns the string representation of language
+func (l Language) ToString(language Language) string {
+	switch l {
+	case English:
+		if language == Chinese {
+			return "英文"
+		}
+		return "en"
+	case Chinese:
+		if language == Chinese {
+			return "中文"
+		}
+		return "cn"
+	}
+	if language == Chinese {
+		return "英文"
+	}
+	return "en"
+}
+
+// Application constants
+const (
+	// Grid and display constants
+	DefaultRows = 30 // Default window rows
+	DefaultCols = 80 // Default window columns
+
+	DefaultLanguage     = English               // Default language
+	DefaultRefreshRate  = 50 * time.Millisecond // Default refresh rate
+	MinRefreshRate      = 10 * time.Millisecond // Minimum refresh rate
+	DefaultRule         = "RL"                  // Classic Langton's Ant
+	DefaultStepsPerTick = 1                     // Ant moves per tick
+	MaxStepsPerTick     = 1024                  // Maximum ant moves per tick
+
+	// Colors
+	DefaultAntColor   = "#FF0000" // Ant color (red)
+	DefaultCellColor  = "#FFFFFF" // Color of the first non-empty cell state (white)
+	DefaultEmptyColor = "#000000" // Empty cell color (black)
+
+	// Characters
+	DefaultCellChar  = "█" // Character for non-empty cells
+	DefaultEmptyChar = " " // Character for empty cells
+
+	// Default values
+	DefaultLogFile         = "debug.log"     // Default log file path
+	DefaultProfileInterval = 5 * time.Second // Default profile information output interval
+	DefaultProfilePort     = 6060            // Default profile server port
+)
+
+// StateColors are the colors of cell states 2 and up for multi-color rules;
+// state 1 uses the configured cell color
+var StateColors = []string{
+	"#00BFFF", // Deep sky blue
+	"#FFD700", // Gold
+	"#32CD32", // Lime green
+	"#FF69B4", // Hot pink
+	"#FF8C00", // Dark orange
+	"#9370DB", // Medium purple
+	"#20B2AA", // Light sea green
+	"#DC143C", // Crimson
+	"#F0E68C", // Khaki
+	"#4682B4", // Steel blue
+	"#ADFF2F", // Green yellow
+}
+
+// MaxRuleLength is the longest supported rule, one state per color
+var MaxRuleLength = len(StateColors) + 2
+
+// RulePresets are the rules cycled through with the N key
+var RulePresets = []string{
+	"RL",           // Classic ant, builds a highway after ~10000 steps
+	"RLR",          // Grows chaotically forever
+	"LLRR",         // Symmetric, grows a filled shape
+	"LRRRRRLLR",    // Fills a square
+	"RRLLLRLLLRRR", // Builds a triangle highway
+}
+
+// DefaultConfig is the default configuration
+var DefaultConfig = Config{
+	Rule:         DefaultRule,
+	StepsPerTick: DefaultStepsPerTick,
+	AntColor:     DefaultAntColor,
+	CellColor:    DefaultCellColor,
+	EmptyColor:   DefaultEmptyColor,
+	CellChar:     DefaultCellChar,
+	EmptyChar:    DefaultEmptyChar,
+	Language:     DefaultLanguage,
+}
+
+// Config holds all application configuration
+type Config struct {
+	Rule         string // Turn per cell state, e.g. "RL"
+	StepsPerTick int    // Ant moves per tick
+	AntColor     string
+	CellColor    string
+	EmptyColor   string
+	CellChar     string
+	EmptyChar    string
+	Language     Language
+}
+
+// SetLanguage sets the language
+func (c *Config) SetLanguage(lang string) {
+	langLower := strings.ToLower(lang)
+	if langLower == "cn" || langLower == "zh" {
+		c.Language = Chinese
+	} else {
+		c.Language = English
+	}
+}
+
+// Check validates the configuration
+func (c *Config) Check() {
+	c.Rule = strings.ToUpper(c.Rule)
+	if err := ValidateRule(c.Rule); err != nil {
+		fmt.Printf("invalid rule %q: %v, using default %s\n", c.Rule, err, DefaultRule)
+		c.Rule = DefaultRule
+	}
+	if c.StepsPerTick < 1 || c.StepsPerTick > MaxStepsPerTick {
+		fmt.Printf("invalid steps per tick %d, must be between 1 and %d, using default %d\n", c.StepsPerTick, MaxStepsPerTick, DefaultStepsPerTick)
+		c.StepsPerTick = DefaultStepsPerTick
+	}
+	for _, msg := range pkg.Validate(
+		pkg.HexColor("ant", &c.AntColor, DefaultAntColor),
+		pkg.HexColor("cell", &c.CellColor, DefaultCellColor),
+		pkg.HexColor("empty", &c.EmptyColor, DefaultEmptyColor),
+		pkg.Char("cell", &c.CellChar, DefaultCellChar),
+		pkg.Char("empty", &c.EmptyChar, DefaultEmptyChar),
+		pkg.Language(&c.Language, DefaultLanguage),
+	) {
+		fmt.Println(msg)
+	}
+}
+
+// ValidateRule checks that a rule is 2 to MaxRuleLength turns, each L or R
+func ValidateRule(rule string) error {
+	if len(rule) < 2 || len(rule) > MaxRuleLength {
+		return fmt.Errorf("rule must have between 2 and %d turns", MaxRuleLength)
+	}
+	for _, c := range rule {
+		if c != 'L' && c != 'R' {
+			return fmt.Errorf("unknown turn %q, must be L or R", c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	_ "net/http/pprof" //nolint:gosec
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/telepair/go-playground/pkg"
+)
+
+func main() {
+	// Custom usage function
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Langton's Ant - A Terminal User Interface implementation of Langton's Ant and its multi-color variants\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s                          # Classic RL ant\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -rule LLRR               # Four-color symmetric ant\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -steps-per-tick 64       # Fast-forward to the highway\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -lang cn                 # Run in Chinese\n", os.Args[0])
+	}
+
+	// Parse command line flags
+	var rule = flag.String("rule", DefaultRule, "Turn for each cell state, L or R (e.g. RL, RLR, LLRR)")
+	var stepsPerTick = flag.Int("steps-per-tick", DefaultStepsPerTick, "Ant moves per tick")
+	var antColor = flag.String("ant-color", DefaultAntColor, "Ant color (hex)")
+	var cellColor = flag.String("cell-color", DefaultCellColor, "Color of flipped cells (hex)")
+	var emptyColor = flag.String("empty-color", DefaultEmptyColor, "Empty cell color (hex)")
+	var cellChar = flag.String("cell-char", DefaultCellChar, "Character for flipped cells")
+	var emptyChar = flag.String("empty-char", DefaultEmptyChar, "Character for empty cells")
+	var lang = flag.String("lang", DefaultLanguage.ToString(DefaultLanguage), "Language (en/cn)")
+	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
+	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
+	var profileInterval = flag.Duration("profile-interval", DefaultProfileInterval, "Profile information output interval")
+	var logFile = flag.String("log-file", DefaultLogFile, "Log file path")
+
+	flag.Parse()
+
+	if *logFile != "" {
+		_, _ = pkg.InitLog("debug", "text", *logFile)
+	}
+	slog.Debug("Langton's Ant starting")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize monitoring if enabled
+	if *enableProfiling {
+		go pkg.StartProfile(ctx, *profilePort)
+		go pkg.StartWatchdog(ctx, *profileInterval)
+	}
+
+	// Create and configure application
+	config := Config{
+		Rule:         *rule,
+		StepsPerTick: *stepsPerTick,
+		AntColor:     *antColor,
+		CellColor:    *cellColor,
+		EmptyColor:   *emptyColor,
+		CellChar:     *cellChar,
+		EmptyChar:    *emptyChar,
+	}
+	config.SetLanguage(*lang)
+	config.Check()
+
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
+	// Create initial model
+	initialModel := NewModel(config)
+
+	// Run the application
+	p := tea.NewProgram(initialModel, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		os.Exit(1)
+	}
+
+	slog.Debug("Langton's Ant finished")
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
+)
+
+// UI styles
+var (
+	// Header styles
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#B7410E")).
+			Padding(0, 2).
+			MarginBottom(1).
+			Align(lipgloss.Center)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#4A5568")).
+			Padding(0, 1).
+			Bold(true)
+
+	tableBuilder strings.Builder
+)
+
+// UI text constants
+const (
+	// Header Line
+	HeaderCN = "🐜 兰顿蚂蚁 🐜"
+	HeaderEN = "🐜 Langton's Ant 🐜"
+
+	// Status Line
+	StepsLabelCN = "📍 步数: %d"
+	StepsLabelEN = "📍 Steps: %d"
+
+	RuleLabelCN = "📜 规则: %s"
+	RuleLabelEN = "📜 Rule: %s"
+
+	DirectionLabelCN = "🧭 方向: %s"
+	DirectionLabelEN = "🧭 Heading: %s"
+
+	BatchLabelCN = "⏩ 每帧步数: %d"
+	BatchLabelEN = "⏩ Steps/Tick: %d"
+
+	SpeedLabelCN = "🔄 刷新: %s"
+	SpeedLabelEN = "🔄 Speed: %s"
+
+	SizeLabelCN = "📐 尺寸: %d×%d"
+	SizeLabelEN = "📐 Size: %d×%d"
+
+	StatusLabelPlayingCN = "▶️ 运行中"
+	StatusLabelPlayingEN = "▶️ Running"
+	StatusLabelPausedCN  = "⏸️ 已暂停"
+	StatusLabelPausedEN  = "⏸️ Paused"
+
+	// Control Line
+	RuleControlLabelCN = "N 切换规则"
+	RuleControlLabelEN = "N Next Rule"
+
+	BatchControlLabelCN = "B/b 每帧步数 +/-"
+	BatchControlLabelEN = "B/b Steps/Tick +/-"
+
+	SpeedControlLabelCN = "+/- 加速/减速"
+	SpeedControlLabelEN = "+/- Speed Up/Down"
+
+	LanguageLabelCN = "L 切换语言"
+	LanguageLabelEN = "L Switch Language"
+
+	SpaceControlLabelCN = "Space 暂停"
+	SpaceControlLabelEN = "Space Pause"
+
+	ResetLabelCN = "R 重置"
+	ResetLabelEN = "R Reset"
+
+	QuitLabelCN = "Q 退出"
+	QuitLabelEN = "Q Quit"
+)
+
+// RenderOptions contains rendering configuration with cached styles
+type RenderOptions struct {
+	antStyled  [4]string // Cached styled ant, indexed by direction
+	cellStyled []string  // Cached styled cells, indexed by state
+}
+
+// NewRenderOptions pre-computes the styled ant and cell strings. State 0
+// uses the empty color and character, state 1 the cell color, and higher
+// states the StateColors palette.
+func NewRenderOptions(cfg Config) RenderOptions {
+	var ro RenderOptions
+	antStyle := lipgloss.NewStyle().Foreground(pkg.AdaptColor(cfg.AntColor)).Bold(true)
+	for d := DirectionUp; d <= DirectionLeft; d++ {
+		ro.antStyled[d] = antStyle.Render(d.Arrow())
+	}
+
+	ro.cellStyled = make([]string, MaxRuleLength)
+	ro.cellStyled[0] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(cfg.EmptyColor)).Render(cfg.EmptyChar)
+	ro.cellStyled[1] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(cfg.CellColor)).Render(cfg.CellChar)
+	for i, color := range StateColors {
+		ro.cellStyled[i+2] = lipgloss.NewStyle().Foreground(pkg.AdaptColor(color)).Render(cfg.CellChar)
+	}
+	return ro
+}
+
+// HeaderLineView returns the header display string
+func (m Model) HeaderLineView() string {
+	style := headerStyle.Width(m.width)
+	if m.language == Chinese {
+		return style.Render(HeaderCN)
+	}
+	return style.Render(HeaderEN)
+}
+
+// StatusLineView returns the status display string
+func (m Model) StatusLineView() string {
+	var status, stepsLabel, ruleLabel, directionLabel, batchLabel, speedLabel, sizeLabel string
+
+	if m.language == Chinese {
+		status = StatusLabelPlayingCN
+		if m.paused {
+			status = StatusLabelPausedCN
+		}
+		stepsLabel = StepsLabelCN
+		ruleLabel = RuleLabelCN
+		directionLabel = DirectionLabelCN
+		batchLabel = BatchLabelCN
+		speedLabel = SpeedLabelCN
+		sizeLabel = SizeLabelCN
+	} else {
+		status = StatusLabelPlayingEN
+		if m.paused {
+			status = StatusLabelPausedEN
+		}
+		stepsLabel = StepsLabelEN
+		ruleLabel = RuleLabelEN
+		directionLabel = DirectionLabelEN
+		batchLabel = BatchLabelEN
+		speedLabel = SpeedLabelEN
+		sizeLabel = SizeLabelEN
+	}
+
+	direction := m.ant.GetAnt().Direction
+
+	tableBuilder.Reset()
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(stepsLabel, m.ant.GetSteps())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(ruleLabel, m.ant.GetRule())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(directionLabel, direction.Arrow()+" "+direction.ToString(m.language))))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(batchLabel, m.stepsPerTick)))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(speedLabel, m.refreshRate.String())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sizeLabel, m.gridHeight, m.gridWidth)))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(status))
+
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
+}
+
+// ControlLineView returns the control display string
+func (m Model) ControlLineView() string {
+	var ruleControl, batchControl, speedControl, language, space, reset, quit string
+	if m.language == Chinese {
+		ruleControl = RuleControlLabelCN
+		batchControl = BatchControlLabelCN
+		speedControl = SpeedControlLabelCN
+		language = LanguageLabelCN
+		space = SpaceControlLabelCN
+		reset = ResetLabelCN
+		quit = QuitLabelCN
+	} else {
+		ruleControl = RuleControlLabelEN
+		batchControl = BatchControlLabelEN
+		speedControl = SpeedControlLabelEN
+		language = LanguageLabelEN
+		space = SpaceControlLabelEN
+		reset = ResetLabelEN
+		quit = QuitLabelEN
+	}
+
+	tableBuilder.Reset()
+	tableBuilder.WriteString(labelStyle.Render(ruleControl))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(batchControl))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(speedControl))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(language))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(space))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(reset))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(quit))
+
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/telepair/go-playground/pkg"
+)
+
+var (
+	keepWidth  = 4
+	keepHeight = 6
+)
+
+// Model represents the application state
+type Model struct {
+	ant *LangtonsAnt
+
+	language     Language
+	stepsPerTick int
+	ruleIndex    int // Index into RulePresets of the last preset selected with N
+
+	paused        bool
+	refreshRate   time.Duration
+	width         int
+	gridHeight    int
+	gridWidth     int
+	buffer        strings.Builder
+	gridBuffer    strings.Builder
+	renderOptions RenderOptions
+	logger        *slog.Logger
+}
+
+// NewModel creates a new model with the given configuration
+func NewModel(cfg Config) Model {
+	cfg.Check()
+
+	gridHeight := DefaultRows - keepHeight
+	gridWidth := DefaultCols - keepWidth
+
+	return Model{
+		ant:           NewLangtonsAnt(gridHeight, gridWidth, cfg.Rule),
+		language:      cfg.Language,
+		stepsPerTick:  cfg.StepsPerTick,
+		ruleIndex:     -1,
+		width:         DefaultCols,
+		gridHeight:    gridHeight,
+		gridWidth:     gridWidth,
+		renderOptions: NewRenderOptions(cfg),
+		refreshRate:   DefaultRefreshRate,
+		logger:        slog.With("module", "ui"),
+	}
+}
+
+// tickMsg is sent every tick
+type tickMsg time.Time
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return tea.Tick(m.refreshRate, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.logger.Debug("Window size changed", "width", msg.Width, "height", msg.Height)
+		return m.handleWindowResize(msg)
+	case tea.KeyMsg:
+		m.logger.Debug("Key pressed", "key", msg.String())
+		return m.handleKeyPress(msg)
+	case tickMsg:
+		return m.handleTick(time.Time(msg))
+	}
+	return m, nil
+}
+
+// View renders the current state
+func (m Model) View() string {
+	m.logger.Debug("Model View",
+		"width", m.width,
+		"gridWidth", m.gridWidth,
+		"gridHeight", m.gridHeight,
+		"rule", m.ant.GetRule(),
+		"language", m.language,
+		"paused", m.paused,
+		"refreshRate", m.refreshRate)
+	return m.RenderMode()
+}
+
+// handleWindowResize processes terminal window size changes
+func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
+	m.width = msg.Width
+	m.gridWidth = msg.Width - keepWidth
+	m.gridHeight = msg.Height - keepHeight
+	m.ant.Reset(m.gridHeight, m.gridWidth)
+	return m, nil
+}
+
+// handleKeyPress processes keyboard input
+func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+
+	case " ", "enter": // Pause/resume
+		m.paused = !m.paused
+
+	case "l": // Language toggle
+		if m.language == English {
+			m.language = Chinese
+		} else {
+			m.language = English
+		}
+
+	case "+", "=", "up": // Increase refresh rate (make it faster)
+		m.refreshRate = max(m.refreshRate/2, MinRefreshRate)
+
+	case "-", "_", "down": // Decrease refresh rate (make it slower)
+		m.refreshRate = m.refreshRate * 2
+
+	case "n": // Cycle through the rule presets
+		m.ruleIndex = (m.ruleIndex + 1) % len(RulePresets)
+		if err := m.ant.SetRule(RulePresets[m.ruleIndex]); err != nil {
+			m.logger.Error("Failed to set rule", "rule", RulePresets[m.ruleIndex], "error", err)
+		}
+
+	case "B": // More moves per tick
+		m.stepsPerTick = min(m.stepsPerTick*2, MaxStepsPerTick)
+
+	case "b": // Fewer moves per tick
+		m.stepsPerTick = max(m.stepsPerTick/2, 1)
+
+	case "r": // Reset simulation
+		m.ant.Reset(m.gridHeight, m.gridWidth)
+	}
+
+	return m, nil
+}
+
+// handleTick processes timer ticks
+func (m Model) handleTick(tick time.Time) (tea.Model, tea.Cmd) {
+	if !m.paused {
+		m.ant.Step(m.stepsPerTick)
+	}
+
+	// Schedule from when this tick fired, so time spent stepping does not
+	// stretch the interval
+	delay := pkg.NextTickDelay(m.refreshRate, MinRefreshRate, time.Since(tick))
+	return m, tea.Tick(delay, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// RenderMode renders the complete UI
+func (m Model) RenderMode() string {
+	m.buffer.Reset()
+
+	m.buffer.WriteString(m.HeaderLineView())
+	m.buffer.WriteString("\n")
+	m.buffer.WriteString(m.StatusLineView())
+	m.buffer.WriteString("\n\n")
+	m.buffer.WriteString(m.RenderGrid())
+	m.buffer.WriteString("\n\n")
+	m.buffer.WriteString(m.ControlLineView())
+
+	return m.buffer.String()
+}
+
+// RenderGrid renders the cells with the ant on top
+func (m *Model) RenderGrid() string {
+	m.gridBuffer.Reset()
+	grid := m.ant.GetGrid()
+	ant := m.ant.GetAnt()
+
+	lastRowIndex := len(grid) - 1
+	for i, row := range grid {
+		m.gridBuffer.WriteString(" ")
+		for j, state := range row {
+			if i == ant.Row && j == ant.Col {
+				m.gridBuffer.WriteString(m.renderOptions.antStyled[ant.Direction])
+			} else {
+				m.gridBuffer.WriteString(m.renderOptions.cellStyled[state])
+			}
+		}
+		if i < lastRowIndex {
+			m.gridBuffer.WriteByte('\n')
+		}
+	}
+
+	return m.gridBuffer.String()
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -86,6 +87,95 @@ func (cs ColorScheme) ToString(language Language) string {
 	}
 }
 
+// FractalType represents the fractal being rendered
+type FractalType int
+
+// FractalType constants
+const (
+	FractalMandelbrot  FractalType = iota // z = z^2 + c, starting from z = 0
+	FractalJulia                          // z = z^2 + c with a fixed c, starting from the point
+	FractalBurningShip                    // z = (|Re z| + i|Im z|)^2 + c
+	FractalTricorn                        // z = conj(z)^2 + c
+)
+
+// ToString returns the string representation of fractal type
+func (f FractalType) ToString(language Language) string {
+	switch f {
+	case FractalMandelbrot:
+		if language == Chinese {
+			return "曼德博"
+		}
+		return "Mandelbrot"
+	case FractalJulia:
+		if language == Chinese {
+			return "朱利亚"
+		}
+		return "Julia"
+	case FractalBurningShip:
+		if language == Chinese {
+			return "燃烧船"
+		}
+		return "Burning Ship"
+	case FractalTricorn:
+		if language == Chinese {
+			return "三角"
+		}
+		return "Tricorn"
+	default:
+		if language == Chinese {
+			return "曼德博"
+		}
+		return "Mandelbrot"
+	}
+}
+
+// Next returns the fractal type after f, wrapping around to Mandelbrot
+func (f FractalType) Next() FractalType {
+	return (f + 1) % (FractalTricorn + 1)
+}
+
+// TrapType represents the shape used for orbit-trap coloring
+type TrapType int
+
+// TrapType constants
+const (
+	TrapNone  TrapType = iota // Escape-time coloring
+	TrapPoint                 // Distance to the origin
+	TrapLine                  // Distance to the real axis
+	TrapCross                 // Distance to the nearer of the two axes
+)
+
+// ToString returns the string representation of trap type
+func (t TrapType) ToString(language Language) string {
+	switch t {
+	case TrapPoint:
+		if language == Chinese {
+			return "点"
+		}
+		return "Point"
+	case TrapLine:
+		if language == Chinese {
+			return "直线"
+		}
+		return "Line"
+	case TrapCross:
+		if language == Chinese {
+			return "十字"
+		}
+		return "Cross"
+	default:
+		if language == Chinese {
+			return "无"
+		}
+		return "None"
+	}
+}
+
+// Next returns the trap type after t, wrapping around to none
+func (t TrapType) Next() TrapType {
+	return (t + 1) % (TrapCross + 1)
+}
+
 // Application constants
 const (
 	// Grid and display constants
@@ -103,6 +193,34 @@ const (
 	DefaultCenterY       = 0.0             // Default center Y coordinate
 	DefaultJuliaC        = "-0.7+0.27015i" // Default Julia set parameter
 
+	// Computation
+	MinParallelCells  = 1024 // Grids with fewer cells are computed on a single goroutine
+	HighPrecisionZoom = 1e13 // Zoom above which HighPrecision switches to math/big
+	PanTolerance      = 1e-3 // Fraction of a cell by which a pan may miss and still shift the grid
+
+	// Orbit traps
+	TrapRadius = 1.0 // Orbit distance from the trap at which trap coloring fades to the lowest color
+
+	// Distance estimation
+	DistanceBailout = 1e3 // Escape radius for distance estimation, large so the estimate converges
+
+	// Julia morphing
+	DefaultJuliaRadius = 0.05                  // Default radius of the circle traced by the Julia parameter
+	MaxJuliaRadius     = 2.0                   // Largest radius, beyond which Julia sets are dust
+	JuliaAnimationStep = math.Pi / 90          // Angle the Julia parameter moves per frame, a full circle every 180 frames
+	JuliaAnimationRate = 50 * time.Millisecond // Fastest frame interval while morphing
+
+	// Image export
+	ImageWidth  = 1920 // Exported image width in pixels
+	ImageHeight = 1080 // Exported image height in pixels
+
+	// Anti-aliasing
+	MaxAntiAlias = 4 // Maximum samples per pixel axis in exported images
+
+	// Coordinate entry
+	LocationInputChars = "0123456789.,-+eE " // Characters accepted while typing a location
+	MaxLocationInput   = 80                  // Maximum length of a typed location
+
 	// Timing constants
 	DefaultRefreshRate = 100 * time.Millisecond // Default refresh rate
 	MinRefreshRate     = 10 * time.Millisecond  // Minimum refresh rate
@@ -110,6 +228,8 @@ const (
 	// Default values
 	DefaultLanguage    = English            // Default language
 	DefaultColorScheme = ColorSchemeClassic // Default color scheme
+	DefaultFractal     = FractalMandelbrot  // Default fractal type
+	DefaultTrap        = TrapNone           // Default orbit trap
 
 	// Profiling and monitoring
 	DefaultLogFile         = "debug.log"     // Default log file path
@@ -124,7 +244,7 @@ var DefaultConfig = Config{
 	CenterX:     DefaultCenterX,
 	CenterY:     DefaultCenterY,
 	ColorScheme: DefaultColorScheme,
-	Julia:       false,
+	Fractal:     DefaultFractal,
 	JuliaC:      DefaultJuliaC,
 	Language:    DefaultLanguage,
 }
@@ -136,9 +256,34 @@ type Config struct {
 	CenterX     float64
 	CenterY     float64
 	ColorScheme ColorScheme
-	Julia       bool
+	Fractal     FractalType
 	JuliaC      string
 	Language    Language
+	Presets     []Preset // User presets, appended to the built-in ones
+
+	// HighPrecision computes points with math/big beyond HighPrecisionZoom,
+	// where float64 runs out of bits and the view turns into blocks. It is
+	// orders of magnitude slower, so float64 stays the default.
+	HighPrecision bool
+
+	// Trap colors each point by how close its orbit comes to a shape instead
+	// of by escape time. Traps are always computed in float64.
+	Trap TrapType
+
+	// DistanceEstimate shades escaped points by their estimated distance to
+	// the set, which keeps thin filaments visible at any zoom. Escape time
+	// stays the default; an orbit trap takes precedence.
+	DistanceEstimate bool
+
+	// JuliaAnimation moves the Julia parameter around a circle of
+	// JuliaRadius, recalculating each frame. It only runs in Julia mode.
+	JuliaAnimation bool
+	JuliaRadius    float64
+
+	// AntiAlias is the number of samples per pixel axis in exported images,
+	// whose colors are averaged to smooth jagged edges: 2 takes 4 samples per
+	// pixel. Export time grows with its square, so 0 or 1 (off) is the default.
+	AntiAlias int
 }
 
 // SetLanguage sets the language
@@ -151,6 +296,40 @@ func (c *Config) SetLanguage(lang string) {
 	}
 }
 
+// SetFractal sets the fractal type from its name
+func (c *Config) SetFractal(name string) {
+	switch strings.ToLower(name) {
+	case "mandelbrot":
+		c.Fractal = FractalMandelbrot
+	case "julia":
+		c.Fractal = FractalJulia
+	case "burning-ship", "burningship", "ship":
+		c.Fractal = FractalBurningShip
+	case "tricorn", "mandelbar":
+		c.Fractal = FractalTricorn
+	default:
+		fmt.Printf("invalid fractal %s, must be mandelbrot, julia, burning-ship or tricorn, using default %s\n", name, DefaultFractal.ToString(English))
+		c.Fractal = DefaultFractal
+	}
+}
+
+// SetTrap sets the orbit trap from its name
+func (c *Config) SetTrap(name string) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		c.Trap = TrapNone
+	case "point":
+		c.Trap = TrapPoint
+	case "line":
+		c.Trap = TrapLine
+	case "cross":
+		c.Trap = TrapCross
+	default:
+		fmt.Printf("invalid trap %s, must be none, point, line or cross, using default %s\n", name, DefaultTrap.ToString(English))
+		c.Trap = DefaultTrap
+	}
+}
+
 // Check validates the configuration
 func (c *Config) Check() {
 	if c.MaxIter < MinMaxIterations || c.MaxIter > MaxMaxIterations {
@@ -161,10 +340,55 @@ func (c *Config) Check() {
 		fmt.Printf("invalid zoom level %f, must be positive, using default %f\n", c.Zoom, DefaultZoom)
 		c.Zoom = DefaultZoom
 	}
+	if math.IsNaN(c.CenterX) || math.IsInf(c.CenterX, 0) || math.IsNaN(c.CenterY) || math.IsInf(c.CenterY, 0) {
+		fmt.Printf("invalid center %g,%g, must be finite, using default %g,%g\n", c.CenterX, c.CenterY, DefaultCenterX, DefaultCenterY)
+		c.CenterX, c.CenterY = DefaultCenterX, DefaultCenterY
+	}
 	if c.ColorScheme < ColorSchemeClassic || c.ColorScheme > ColorSchemeGrayscale {
 		fmt.Printf("invalid color scheme %d, must be between 0 and 4, using default %d\n", c.ColorScheme, DefaultColorScheme)
 		c.ColorScheme = DefaultColorScheme
 	}
+	if c.Fractal < FractalMandelbrot || c.Fractal > FractalTricorn {
+		fmt.Printf("invalid fractal type %d, must be between 0 and 3, using default %d\n", c.Fractal, DefaultFractal)
+		c.Fractal = DefaultFractal
+	}
+	if c.Trap < TrapNone || c.Trap > TrapCross {
+		fmt.Printf("invalid trap type %d, must be between 0 and 3, using default %d\n", c.Trap, DefaultTrap)
+		c.Trap = DefaultTrap
+	}
+	if c.JuliaRadius < 0 || c.JuliaRadius > MaxJuliaRadius {
+		fmt.Printf("invalid Julia radius %g, must be between 0 and %g, using default %g\n", c.JuliaRadius, MaxJuliaRadius, DefaultJuliaRadius)
+		c.JuliaRadius = DefaultJuliaRadius
+	}
+	if c.AntiAlias < 0 || c.AntiAlias > MaxAntiAlias {
+		fmt.Printf("invalid anti-alias %d, must be between 1 and %d, turning anti-aliasing off\n", c.AntiAlias, MaxAntiAlias)
+		c.AntiAlias = 1
+	}
+}
+
+// ParseLocation parses a view location typed as "centerX,centerY,zoom". The
+// zoom may be omitted, in which case it is returned as 0.
+func ParseLocation(s string) (x, y, zoom float64, err error) {
+	parts := strings.Split(strings.ReplaceAll(s, " ", ""), ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, 0, 0, fmt.Errorf("invalid location %q, expected centerX,centerY[,zoom]", s)
+	}
+
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, 0, 0, fmt.Errorf("invalid number %q in location %q", part, s)
+		}
+		values[i] = v
+	}
+	if len(values) == 3 {
+		if values[2] <= 0 {
+			return 0, 0, 0, fmt.Errorf("invalid zoom %v in location %q, must be positive", values[2], s)
+		}
+		zoom = values[2]
+	}
+	return values[0], values[1], zoom, nil
 }
 
 // ParseComplexNumber parses a complex number string in the format "a+bi" or "a-bi"
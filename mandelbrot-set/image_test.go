@@ -0,0 +1,153 @@
+package main
+
+import (
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Test exporting the current view as a PNG image
+func TestSaveImage(t *testing.T) {
+	m := NewMandelbrotSet(DefaultConfig)
+	m.SetColorScheme(ColorSchemeHot)
+
+	path := filepath.Join(t.TempDir(), "view.png")
+	if err := m.SaveImage(path, 64, 36); err != nil {
+		t.Fatalf("SaveImage failed: %v", err)
+	}
+
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		t.Fatalf("Failed to open image: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("Failed to decode PNG: %v", err)
+	}
+
+	if bounds := img.Bounds(); bounds.Dx() != 64 || bounds.Dy() != 36 {
+		t.Fatalf("Expected 64x36 image, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	// Pixel colors match the terminal colors for the same points
+	ro := NewRenderOptions(ColorSchemeHot)
+	v := m.viewportFor(64, 36)
+	for _, pos := range [][2]int{{0, 0}, {32, 18}, {10, 30}, {50, 5}} {
+		iter := m.iterationsAt(pos[0], pos[1], v)
+		expected := hexToRGBA(string(ro.GetColorForIteration(iter, m.GetMaxIterations())))
+		if got := color.RGBAModel.Convert(img.At(pos[0], pos[1])); got != expected {
+			t.Errorf("Pixel (%d,%d): expected %v, got %v", pos[0], pos[1], expected, got)
+		}
+	}
+
+	// The default center (-0.5, 0) lies inside the set
+	if got := color.RGBAModel.Convert(img.At(32, 18)); got != (color.RGBA{A: 0xFF}) {
+		t.Errorf("Expected black at the center, got %v", got)
+	}
+}
+
+// Test that anti-aliased pixels average their sub-pixel samples
+func TestRenderImageAntiAlias(t *testing.T) {
+	m := NewMandelbrotSet(DefaultConfig)
+	m.SetColorScheme(ColorSchemeHot)
+	m.SetAntiAlias(2)
+	if m.GetAntiAlias() != 2 {
+		t.Fatalf("Expected anti-alias 2, got %d", m.GetAntiAlias())
+	}
+
+	const width, height = 32, 18
+	img := m.renderImage(width, height)
+	ro := NewRenderOptions(ColorSchemeHot)
+	v := m.viewportFor(width*2, height*2)
+
+	blended := 0
+	for y := range height {
+		for x := range width {
+			var samples []color.RGBA
+			for _, d := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				iter := m.iterationsAt(x*2+d[0], y*2+d[1], v)
+				samples = append(samples, hexToRGBA(string(ro.GetColorForIteration(iter, m.GetMaxIterations()))))
+			}
+
+			got := img.RGBAAt(x, y)
+			for c, channel := range [][5]uint8{
+				{got.R, samples[0].R, samples[1].R, samples[2].R, samples[3].R},
+				{got.G, samples[0].G, samples[1].G, samples[2].G, samples[3].G},
+				{got.B, samples[0].B, samples[1].B, samples[2].B, samples[3].B},
+			} {
+				lo, hi := min(channel[1], channel[2], channel[3], channel[4]), max(channel[1], channel[2], channel[3], channel[4])
+				if channel[0] < lo || channel[0] > hi {
+					t.Fatalf("Pixel (%d,%d) channel %d: %d is outside the samples %v", x, y, c, channel[0], channel[1:])
+				}
+				if lo != hi {
+					blended++
+				}
+			}
+		}
+	}
+	if blended == 0 {
+		t.Error("Expected some pixels to blend different sample colors")
+	}
+
+	m.SetAntiAlias(MaxAntiAlias + 1)
+	if m.GetAntiAlias() != MaxAntiAlias {
+		t.Errorf("Expected anti-alias clamped to %d, got %d", MaxAntiAlias, m.GetAntiAlias())
+	}
+}
+
+// Test rounding of averaged colors
+func TestAverageRGBA(t *testing.T) {
+	if got := averageRGBA(0+255+255+255, 0, 1+2, 4); got != (color.RGBA{R: 191, G: 0, B: 1, A: 0xFF}) {
+		t.Errorf("Unexpected average %v", got)
+	}
+}
+
+// Test image export errors
+func TestSaveImageInvalid(t *testing.T) {
+	m := NewMandelbrotSet(DefaultConfig)
+	if err := m.SaveImage(filepath.Join(t.TempDir(), "view.png"), 0, 10); err == nil {
+		t.Error("Expected error for zero width")
+	}
+	if err := m.SaveImage(filepath.Join(t.TempDir(), "missing", "view.png"), 10, 10); err == nil {
+		t.Error("Expected error when saving to a missing directory")
+	}
+}
+
+// Test the default image file name
+func TestImageFileName(t *testing.T) {
+	m := NewMandelbrotSet(DefaultConfig)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if name := m.ImageFileName(now); name != "mandelbrot-20250102-030405.png" {
+		t.Errorf("Unexpected file name %s", name)
+	}
+	m.fractal = FractalJulia
+	if name := m.ImageFileName(now); name != "julia-20250102-030405.png" {
+		t.Errorf("Unexpected file name %s", name)
+	}
+	m.fractal = FractalBurningShip
+	if name := m.ImageFileName(now); name != "burning-ship-20250102-030405.png" {
+		t.Errorf("Unexpected file name %s", name)
+	}
+}
+
+// Test hex color parsing
+func TestHexToRGBA(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected color.RGBA
+	}{
+		{"#FF8000", color.RGBA{R: 0xFF, G: 0x80, A: 0xFF}},
+		{"#000000", color.RGBA{A: 0xFF}},
+		{"invalid", color.RGBA{A: 0xFF}},
+		{"#GGGGGG", color.RGBA{A: 0xFF}},
+	}
+	for _, tt := range tests {
+		if got := hexToRGBA(tt.input); got != tt.expected {
+			t.Errorf("hexToRGBA(%q) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+}
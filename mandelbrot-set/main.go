@@ -24,6 +24,12 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -zoom 2.0 -center-x -0.5        # Zoom into a specific area\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -max-iter 100 -color-scheme 2   # High iteration with different colors\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -julia -julia-c '0.285+0.01i'   # Julia set mode with custom parameter\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -fractal burning-ship            # Burning Ship fractal\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -presets my-presets.json         # Add your own preset locations\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -high-precision -zoom 1e14       # Deep zoom past the float64 limit\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -trap cross -color-scheme 3        # Orbit-trap coloring\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -distance-estimate               # Crisp boundary shading\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -julia -julia-animate            # Morphing Julia set\n", os.Args[0])
 	}
 
 	// Parse command line flags
@@ -32,8 +38,16 @@ func main() {
 	var centerX = flag.Float64("center-x", DefaultCenterX, "Center X coordinate")
 	var centerY = flag.Float64("center-y", DefaultCenterY, "Center Y coordinate")
 	var colorScheme = flag.Int("color-scheme", int(DefaultColorScheme), "Color scheme (0-4)")
-	var julia = flag.Bool("julia", false, "Enable Julia set mode")
+	var fractal = flag.String("fractal", "mandelbrot", "Fractal type (mandelbrot/julia/burning-ship/tricorn)")
+	var trap = flag.String("trap", "none", "Orbit trap coloring (none/point/line/cross)")
+	var distanceEstimate = flag.Bool("distance-estimate", false, "Shade escaped points by estimated distance to the set")
+	var julia = flag.Bool("julia", false, "Enable Julia set mode (same as -fractal julia)")
 	var juliaC = flag.String("julia-c", DefaultJuliaC, "Julia set parameter (complex number)")
+	var juliaAnimate = flag.Bool("julia-animate", false, "Morph the Julia set by moving its parameter around a circle")
+	var juliaRadius = flag.Float64("julia-radius", DefaultJuliaRadius, "Radius of the circle traced by -julia-animate")
+	var highPrecision = flag.Bool("high-precision", false, "Use arbitrary precision beyond zoom 1e13 (much slower)")
+	var antiAlias = flag.Int("anti-alias", 1, fmt.Sprintf("Samples per pixel axis in exported images, 1 (off) to %d", MaxAntiAlias))
+	var presetsFile = flag.String("presets", "", "JSON file with extra preset locations")
 	var lang = flag.String("lang", DefaultLanguage.ToString(DefaultLanguage), "Language (en/cn)")
 	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
 	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
@@ -43,7 +57,7 @@ func main() {
 	flag.Parse()
 
 	if *logFile != "" {
-		_ = pkg.InitLog("debug", "text", *logFile)
+		_, _ = pkg.InitLog("debug", "text", *logFile)
 	}
 	slog.Debug("Mandelbrot Set starting")
 
@@ -62,17 +76,39 @@ func main() {
 		CenterX:     *centerX,
 		CenterY:     *centerY,
 		ColorScheme: ColorScheme(*colorScheme),
-		Julia:       *julia,
 		JuliaC:      *juliaC,
+
+		HighPrecision: *highPrecision,
+		AntiAlias:     *antiAlias,
+
+		DistanceEstimate: *distanceEstimate,
+
+		JuliaAnimation: *juliaAnimate,
+		JuliaRadius:    *juliaRadius,
+	}
+	if *presetsFile != "" {
+		presets, err := LoadPresets(*presetsFile)
+		if err != nil {
+			fmt.Printf("failed to load presets: %v, using built-in presets only\n", err)
+		}
+		config.Presets = presets
 	}
 	config.SetLanguage(*lang)
+	config.SetFractal(*fractal)
+	config.SetTrap(*trap)
+	if *julia {
+		config.Fractal = FractalJulia
+	}
 	config.Check()
 
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
 	// Create initial model
 	initialModel := NewModel(config)
 
 	// Run the application
-	p := tea.NewProgram(initialModel, tea.WithAltScreen())
+	p := tea.NewProgram(initialModel, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
 		slog.Error("Error running program", "error", err)
 		os.Exit(1)
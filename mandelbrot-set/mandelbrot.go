@@ -1,22 +1,84 @@
 package main
 
 import (
+	"context"
+	"math"
+	"math/big"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// MandelbrotSet represents the Mandelbrot/Julia set calculator
+// MandelbrotSet represents the escape-time fractal calculator
 type MandelbrotSet struct {
 	width       int         // Grid width (columns)
 	height      int         // Grid height (rows)
 	maxIter     int         // Maximum iterations
 	zoom        float64     // Zoom level
-	centerX     float64     // Center X coordinate
-	centerY     float64     // Center Y coordinate
-	julia       bool        // Julia set mode
+	centerX     float64     // Center X coordinate, rounded from exactReal
+	centerY     float64     // Center Y coordinate, rounded from exactImag
+	fractal     FractalType // Fractal being rendered
 	juliaC      complex128  // Julia set parameter
 	grid        [][]int     // Iteration count grid
 	colorScheme ColorScheme // Color scheme for rendering
+
+	autoCalculate bool     // Whether setters recalculate the grid immediately
+	presets       []Preset // Built-in and user presets
+	highPrecision bool     // Whether deep zooms are computed with math/big
+	trap          TrapType // Orbit trap used for coloring, TrapNone for escape time
+	antiAlias     int      // Samples per pixel axis in exported images, 1 for none
+
+	// distanceEstimate shades escaped points by their estimated distance to
+	// the set instead of by escape time; see distanceIterations
+	distanceEstimate bool
+
+	// Julia morphing moves juliaC around a circle of juliaRadius centered on
+	// juliaOrbit, juliaAngle radians along; see AdvanceJuliaAnimation
+	juliaAnimation bool
+	juliaRadius    float64
+	juliaOrbit     complex128
+	juliaAngle     float64
+
+	// exactReal and exactImag hold the center in math/big, so that moves
+	// smaller than the float64 resolution of the center are not lost at
+	// deep zooms; see setCenter. They are replaced, never modified, so
+	// snapshots may share them.
+	exactReal, exactImag *big.Float
+
+	renderDuration time.Duration // Time spent computing the grid; see LastRenderDuration
+	renderedCells  int           // Cells computed in renderDuration; see PixelsPerSecond
+
+	gridView viewKey // View the grid was fully computed for, zero if partial; see Pan
+}
+
+// viewKey identifies everything that determines the iteration grid
+type viewKey struct {
+	width, height    int
+	maxIter          int
+	zoom             float64
+	center           string // Exact center; see centerKey
+	fractal          FractalType
+	juliaC           complex128
+	trap             TrapType
+	highPrecision    bool
+	distanceEstimate bool
+}
+
+// viewKey returns the key of the current view
+func (m *MandelbrotSet) viewKey() viewKey {
+	return viewKey{
+		width:            m.width,
+		height:           m.height,
+		maxIter:          m.maxIter,
+		zoom:             m.zoom,
+		center:           m.centerKey(),
+		fractal:          m.fractal,
+		juliaC:           m.juliaC,
+		trap:             m.trap,
+		highPrecision:    m.highPrecision,
+		distanceEstimate: m.distanceEstimate,
+	}
 }
 
 // NewMandelbrotSet creates a new Mandelbrot set instance
@@ -28,16 +90,22 @@ func NewMandelbrotSet(config Config) *MandelbrotSet {
 	}
 
 	m := &MandelbrotSet{
-		width:       DefaultCols,
-		height:      DefaultRows,
-		maxIter:     config.MaxIter,
-		zoom:        config.Zoom,
-		centerX:     config.CenterX,
-		centerY:     config.CenterY,
-		julia:       config.Julia,
-		juliaC:      juliaC,
-		colorScheme: config.ColorScheme,
+		width:            DefaultCols,
+		height:           DefaultRows,
+		maxIter:          config.MaxIter,
+		zoom:             config.Zoom,
+		fractal:          config.Fractal,
+		juliaC:           juliaC,
+		colorScheme:      config.ColorScheme,
+		autoCalculate:    true,
+		presets:          append(append([]Preset(nil), builtinPresets...), config.Presets...),
+		highPrecision:    config.HighPrecision,
+		trap:             config.Trap,
+		antiAlias:        max(config.AntiAlias, 1),
+		distanceEstimate: config.DistanceEstimate,
 	}
+	m.setCenter(big.NewFloat(config.CenterX), big.NewFloat(config.CenterY))
+	m.SetJuliaAnimation(config.JuliaAnimation, config.JuliaRadius)
 
 	// Initialize grid
 	m.grid = make([][]int, m.height)
@@ -51,62 +119,131 @@ func NewMandelbrotSet(config Config) *MandelbrotSet {
 	return m
 }
 
-// Calculate computes the Mandelbrot or Julia set
-func (m *MandelbrotSet) Calculate() {
+// viewport maps grid cells to points on the complex plane
+type viewport struct {
+	minReal, minImag   float64
+	stepReal, stepImag float64
+
+	// Used by the high precision path, which adds each cell's offset from
+	// the exact center in math/big instead of float64
+	centerReal, centerImag *big.Float
+	halfWidth, halfHeight  float64
+	prec                   uint // math/big mantissa bits, 0 for float64
+}
+
+// viewport returns the mapping for the current center, zoom, and grid size
+func (m *MandelbrotSet) viewport() viewport {
+	return m.viewportFor(m.width, m.height)
+}
+
+// viewportFor returns the mapping for the current center and zoom onto a
+// grid of the given size
+func (m *MandelbrotSet) viewportFor(width, height int) viewport {
 	// Calculate the viewing window based on zoom and center
 	viewWidth := 4.0 / m.zoom
-	viewHeight := (4.0 * float64(m.height) / float64(m.width)) / m.zoom
+	viewHeight := (4.0 * float64(height) / float64(width)) / m.zoom
+
+	return viewport{
+		minReal:    m.centerX - viewWidth/2,
+		minImag:    m.centerY - viewHeight/2,
+		stepReal:   viewWidth / float64(width),
+		stepImag:   viewHeight / float64(height),
+		centerReal: m.exactReal,
+		centerImag: m.exactImag,
+		halfWidth:  viewWidth / 2,
+		halfHeight: viewHeight / 2,
+		prec:       m.precision(),
+	}
+}
 
-	minReal := m.centerX - viewWidth/2
-	maxReal := m.centerX + viewWidth/2
-	minImag := m.centerY - viewHeight/2
-	maxImag := m.centerY + viewHeight/2
+// iterationsAt returns the iteration count of the point at grid cell (x, y)
+func (m *MandelbrotSet) iterationsAt(x, y int, v viewport) int {
+	if m.trap != TrapNone {
+		return m.trapIterations(complex(v.minReal+float64(x)*v.stepReal, v.minImag+float64(y)*v.stepImag))
+	}
+	if m.distanceEstimate {
+		return m.distanceIterations(complex(v.minReal+float64(x)*v.stepReal, v.minImag+float64(y)*v.stepImag), v.stepReal)
+	}
+	if v.prec > 0 {
+		return m.bigIterationsAt(x, y, v)
+	}
+	c := complex(v.minReal+float64(x)*v.stepReal, v.minImag+float64(y)*v.stepImag)
+	switch m.fractal {
+	case FractalJulia:
+		return m.juliaIterations(c)
+	case FractalBurningShip:
+		return m.burningShipIterations(c)
+	case FractalTricorn:
+		return m.tricornIterations(c)
+	default:
+		return m.mandelbrotIterations(c)
+	}
+}
 
-	// Calculate step size
-	stepReal := (maxReal - minReal) / float64(m.width)
-	stepImag := (maxImag - minImag) / float64(m.height)
+// Calculate computes the current fractal
+func (m *MandelbrotSet) Calculate() {
+	v := m.viewport()
+	start := time.Now()
+
+	// Goroutine overhead dominates on tiny grids
+	if m.width*m.height < MinParallelCells {
+		m.calculateSerial(v)
+	} else {
+		m.calculateParallel(v, runtime.NumCPU())
+	}
+	m.renderDuration = time.Since(start)
+	m.renderedCells = m.width * m.height
+	m.gridView = m.viewKey()
+}
 
-	// Use parallel computation for better performance
-	numWorkers := runtime.NumCPU()
-	rowsPerWorker := m.height / numWorkers
-	if rowsPerWorker < 1 {
-		rowsPerWorker = 1
-		numWorkers = m.height
+// calculateSerial computes every row on the calling goroutine
+func (m *MandelbrotSet) calculateSerial(v viewport) {
+	for y := range m.height {
+		m.calculateRow(y, v)
 	}
+}
 
-	var wg sync.WaitGroup
-	wg.Add(numWorkers)
+// calculateParallel computes rows on a pool of workers
+func (m *MandelbrotSet) calculateParallel(v viewport, numWorkers int) {
+	parallelRows(m.height, numWorkers, func(y int) {
+		m.calculateRow(y, v)
+	})
+}
 
-	for worker := 0; worker < numWorkers; worker++ {
-		startY := worker * rowsPerWorker
-		endY := startY + rowsPerWorker
-		if worker == numWorkers-1 {
-			endY = m.height // Handle remainder rows
-		}
+// parallelRows calls fn for every row in [0, height) on a pool of workers.
+// Workers pull the next row from a shared counter, so rows near the set
+// (which take the most iterations) are balanced across workers. Each row is
+// handled by exactly one worker, so fn needs no locking when it only writes
+// to its own row.
+func parallelRows(height, numWorkers int, fn func(y int)) {
+	numWorkers = max(min(numWorkers, height), 1)
 
-		go func(startY, endY int) {
+	var nextRow atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(numWorkers)
+	for range numWorkers {
+		go func() {
 			defer wg.Done()
-
-			for y := startY; y < endY; y++ {
-				imagPart := minImag + float64(y)*stepImag
-				for x := 0; x < m.width; x++ {
-					realPart := minReal + float64(x)*stepReal
-					c := complex(realPart, imagPart)
-
-					// Calculate iterations for this point
-					if m.julia {
-						m.grid[y][x] = m.juliaIterations(c)
-					} else {
-						m.grid[y][x] = m.mandelbrotIterations(c)
-					}
+			for {
+				y := int(nextRow.Add(1) - 1)
+				if y >= height {
+					return
 				}
+				fn(y)
 			}
-		}(startY, endY)
+		}()
 	}
-
 	wg.Wait()
 }
 
+// calculateRow computes the iteration counts of one grid row
+func (m *MandelbrotSet) calculateRow(y int, v viewport) {
+	row := m.grid[y]
+	for x := range row {
+		row[x] = m.iterationsAt(x, y, v)
+	}
+}
+
 // mandelbrotIterations calculates the number of iterations for a point in the Mandelbrot set
 func (m *MandelbrotSet) mandelbrotIterations(c complex128) int {
 	// Extract real and imaginary parts once to avoid repeated function calls
@@ -178,51 +315,419 @@ func (m *MandelbrotSet) juliaIterations(z complex128) int {
 	return m.maxIter
 }
 
+// burningShipIterations calculates the number of iterations for a point in the
+// Burning Ship fractal, which folds z into the first quadrant before squaring
+func (m *MandelbrotSet) burningShipIterations(c complex128) int {
+	cr := real(c)
+	ci := imag(c)
+	zr, zi := 0.0, 0.0
+
+	for i := 0; i < m.maxIter; i++ {
+		zr2 := zr * zr
+		zi2 := zi * zi
+
+		if zr2+zi2 > 4.0 {
+			return i
+		}
+
+		// (|zr| + |zi|*i)^2 = zr^2 - zi^2 + 2*|zr*zi|*i
+		znewR := zr2 - zi2 + cr
+		znewI := 2*math.Abs(zr*zi) + ci
+
+		zr = znewR
+		zi = znewI
+	}
+
+	return m.maxIter
+}
+
+// tricornIterations calculates the number of iterations for a point in the
+// Tricorn (Mandelbar) fractal, which squares the complex conjugate of z
+func (m *MandelbrotSet) tricornIterations(c complex128) int {
+	cr := real(c)
+	ci := imag(c)
+	zr, zi := 0.0, 0.0
+
+	for i := 0; i < m.maxIter; i++ {
+		zr2 := zr * zr
+		zi2 := zi * zi
+
+		if zr2+zi2 > 4.0 {
+			return i
+		}
+
+		// (zr - zi*i)^2 = zr^2 - zi^2 - 2*zr*zi*i
+		znewR := zr2 - zi2 + cr
+		znewI := -2*zr*zi + ci
+
+		zr = znewR
+		zi = znewI
+	}
+
+	return m.maxIter
+}
+
+// trapIterations iterates the point like the escape-time functions, but
+// returns a value in [0, maxIter) that grows as the orbit comes closer to the
+// trap shape, so the regular iteration palette colors the trap distance.
+// Points that never escape are colored too.
+func (m *MandelbrotSet) trapIterations(p complex128) int {
+	zr, zi := 0.0, 0.0
+	cr, ci := real(p), imag(p)
+	if m.fractal == FractalJulia {
+		zr, zi = cr, ci
+		cr, ci = real(m.juliaC), imag(m.juliaC)
+	}
+
+	minDist := TrapRadius
+	for i := 0; i < m.maxIter; i++ {
+		zr2 := zr * zr
+		zi2 := zi * zi
+
+		if zr2+zi2 > 4.0 {
+			break
+		}
+
+		// z = 0 for the Mandelbrot family would land on every trap
+		if i > 0 || m.fractal == FractalJulia {
+			minDist = min(minDist, m.trapDistance(zr, zi))
+		}
+
+		im := 2 * zr * zi
+		switch m.fractal {
+		case FractalBurningShip:
+			im = math.Abs(im)
+		case FractalTricorn:
+			im = -im
+		}
+		zr = zr2 - zi2 + cr
+		zi = im + ci
+	}
+
+	return int((1 - minDist/TrapRadius) * float64(m.maxIter-1))
+}
+
+// trapDistance returns the distance from z to the trap shape
+func (m *MandelbrotSet) trapDistance(zr, zi float64) float64 {
+	switch m.trap {
+	case TrapLine:
+		return math.Abs(zi)
+	case TrapCross:
+		return min(math.Abs(zr), math.Abs(zi))
+	default:
+		return math.Hypot(zr, zi)
+	}
+}
+
+// distanceIterations iterates the point while tracking the derivative dz of
+// z, and returns a value in [0, maxIter) that grows as the estimated distance
+// 0.5*|z|*ln|z|/|dz| to the set shrinks relative to the pixel size, so the
+// boundary stays one pixel sharp at any zoom. Points that never escape return
+// maxIter and get the inside color. The folding fractals reuse the z² + c
+// derivative, which only differs from theirs in sign.
+func (m *MandelbrotSet) distanceIterations(p complex128, pixel float64) int {
+	zr, zi := 0.0, 0.0
+	cr, ci := real(p), imag(p)
+	// dz/dc starts at 0 and gains 1 per step; a Julia set differentiates
+	// with respect to its starting point instead, so dz starts at 1
+	dr, di, dc := 0.0, 0.0, 1.0
+	if m.fractal == FractalJulia {
+		zr, zi = cr, ci
+		cr, ci = real(m.juliaC), imag(m.juliaC)
+		dr, dc = 1, 0
+	}
+
+	escaped := false
+	for i := 0; i < m.maxIter; i++ {
+		zr2 := zr * zr
+		zi2 := zi * zi
+		if zr2+zi2 > DistanceBailout*DistanceBailout {
+			escaped = true
+			break
+		}
+
+		// dz = 2*z*dz + dc
+		dr, di = 2*(zr*dr-zi*di)+dc, 2*(zr*di+zi*dr)
+
+		im := 2 * zr * zi
+		switch m.fractal {
+		case FractalBurningShip:
+			im = math.Abs(im)
+		case FractalTricorn:
+			im = -im
+		}
+		zr = zr2 - zi2 + cr
+		zi = im + ci
+	}
+	// Points that left the escape-time radius but not the larger bailout
+	// before maxIter still escaped
+	escaped = escaped || zr*zr+zi*zi > 4.0
+	if !escaped {
+		return m.maxIter
+	}
+
+	absZ := math.Hypot(zr, zi)
+	absDz := math.Hypot(dr, di)
+	if absDz == 0 {
+		return 0
+	}
+	dist := 0.5 * absZ * math.Log(absZ) / absDz
+
+	// 1 on the boundary, 1/2 a pixel away, fading with distance
+	return int(pixel / (pixel + dist) * float64(m.maxIter-1))
+}
+
+// ProgressiveStrides are the block sizes of successive progressive rendering
+// passes, coarse to fine. Each stride is half the previous one, so every
+// point computed in one pass is reused by the next.
+var ProgressiveStrides = []int{4, 2, 1}
+
+// CalculatePass computes the grid at the given stride: one point is computed
+// per stride x stride block and copied to the whole block. Points already
+// computed by a previous pass at prevStride (0 if none) are not recomputed.
+// It returns the context's error if the pass was cancelled, leaving the grid
+// partially updated. The time of each pass is added to LastRenderDuration,
+// which the first pass (prevStride 0) resets.
+func (m *MandelbrotSet) CalculatePass(ctx context.Context, stride, prevStride int) error {
+	v := m.viewport()
+	if prevStride == 0 {
+		m.renderDuration = 0
+		m.renderedCells = m.width * m.height
+	}
+	m.gridView = viewKey{}
+	start := time.Now()
+	blockRows := (m.height + stride - 1) / stride
+	parallelRows(blockRows, runtime.NumCPU(), func(by int) {
+		if ctx.Err() != nil {
+			return
+		}
+		m.calculateBlockRow(by*stride, stride, prevStride, v)
+	})
+	m.renderDuration += time.Since(start)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if stride == 1 {
+		m.gridView = m.viewKey()
+	}
+	return nil
+}
+
+// calculateBlockRow computes one row of stride x stride blocks starting at
+// grid row y0
+func (m *MandelbrotSet) calculateBlockRow(y0, stride, prevStride int, v viewport) {
+	y1 := min(y0+stride, m.height)
+	reuseRow := prevStride > 0 && y0%prevStride == 0
+	for x0 := 0; x0 < m.width; x0 += stride {
+		iter := m.grid[y0][x0]
+		if !reuseRow || x0%prevStride != 0 {
+			iter = m.iterationsAt(x0, y0, v)
+		}
+		x1 := min(x0+stride, m.width)
+		for y := y0; y < y1; y++ {
+			row := m.grid[y]
+			for x := x0; x < x1; x++ {
+				row[x] = iter
+			}
+		}
+	}
+}
+
+// Snapshot returns a copy of the set with its own grid, so it can be
+// calculated on another goroutine while the original is displayed
+func (m *MandelbrotSet) Snapshot() *MandelbrotSet {
+	s := *m
+	s.grid = make([][]int, len(m.grid))
+	for i, row := range m.grid {
+		s.grid[i] = append([]int(nil), row...)
+	}
+	return &s
+}
+
+// SetAutoCalculate controls whether setters recalculate the grid immediately.
+// When disabled, the caller is responsible for calculating, e.g. with
+// CalculatePass on a Snapshot.
+func (m *MandelbrotSet) SetAutoCalculate(enabled bool) {
+	m.autoCalculate = enabled
+}
+
+// update recalculates the grid after a parameter change if auto calculation
+// is enabled
+func (m *MandelbrotSet) update() {
+	if m.autoCalculate {
+		m.Calculate()
+	}
+}
+
 // GetGrid returns the current iteration grid
 func (m *MandelbrotSet) GetGrid() [][]int {
 	return m.grid
 }
 
+// SetGridFrom replaces the iteration grid with the one of s, e.g. the result
+// of a pass calculated on a Snapshot. The grid is shared, so s must not be
+// calculated again.
+func (m *MandelbrotSet) SetGridFrom(s *MandelbrotSet) {
+	m.grid = s.grid
+	m.gridView = s.gridView
+}
+
 // SetZoom sets the zoom level and recalculates
 func (m *MandelbrotSet) SetZoom(zoom float64) {
 	if zoom > 0 {
 		m.zoom = zoom
-		m.Calculate()
+		m.update()
 	}
 }
 
 // SetCenter sets the center coordinates and recalculates
 func (m *MandelbrotSet) SetCenter(x, y float64) {
-	m.centerX = x
-	m.centerY = y
-	m.Calculate()
+	m.setCenter(big.NewFloat(x), big.NewFloat(y))
+	m.update()
+}
+
+// CenterOnCell recenters the view on grid cell (x, y) of a gridW x gridH view
+// and recalculates. Unlike SetCenter with the point from ScreenToComplex, it
+// keeps the center exact at deep zooms.
+func (m *MandelbrotSet) CenterOnCell(x, y, gridW, gridH int) {
+	dx, dy := m.cellOffset(x, y, gridW, gridH)
+	m.moveCenter(dx, dy)
+	m.update()
 }
 
 // SetMaxIterations sets the maximum iterations and recalculates
 func (m *MandelbrotSet) SetMaxIterations(maxIter int) {
 	if maxIter > 0 {
 		m.maxIter = maxIter
-		m.Calculate()
+		m.update()
 	}
 }
 
+// SetHighPrecision enables or disables math/big beyond HighPrecisionZoom and
+// recalculates
+func (m *MandelbrotSet) SetHighPrecision(enabled bool) {
+	m.highPrecision = enabled
+	m.update()
+}
+
+// SetTrap sets the orbit trap used for coloring and recalculates
+func (m *MandelbrotSet) SetTrap(trap TrapType) {
+	m.trap = trap
+	m.update()
+}
+
+// SetDistanceEstimate enables or disables distance-estimate shading and
+// recalculates
+func (m *MandelbrotSet) SetDistanceEstimate(enabled bool) {
+	m.distanceEstimate = enabled
+	m.update()
+}
+
 // SetColorScheme sets the color scheme
 func (m *MandelbrotSet) SetColorScheme(scheme ColorScheme) {
 	m.colorScheme = scheme
 }
 
-// ToggleMode toggles between Mandelbrot and Julia set modes
+// ToggleMode cycles to the next fractal type
 func (m *MandelbrotSet) ToggleMode() {
-	m.julia = !m.julia
-	m.Calculate()
+	m.fractal = m.fractal.Next()
+	m.update()
+}
+
+// SetFractal switches to the given fractal type and recalculates
+func (m *MandelbrotSet) SetFractal(fractal FractalType) {
+	m.fractal = fractal
+	m.update()
 }
 
-// SetJuliaParameter sets the Julia set parameter and recalculates if in Julia mode
+// SetJuliaParameter sets the Julia set parameter and recalculates if in Julia
+// mode. A manual edit pauses Julia morphing, which would move the parameter
+// away again.
 func (m *MandelbrotSet) SetJuliaParameter(c complex128) {
 	m.juliaC = c
-	if m.julia {
-		m.Calculate()
+	m.juliaAnimation = false
+	if m.fractal == FractalJulia {
+		m.update()
+	}
+}
+
+// SetJuliaAnimation enables or disables Julia morphing. When enabled, the
+// Julia parameter traces a circle of the given radius around its current
+// value; a radius of 0 or less uses DefaultJuliaRadius.
+func (m *MandelbrotSet) SetJuliaAnimation(enabled bool, radius float64) {
+	if radius <= 0 {
+		radius = DefaultJuliaRadius
+	}
+	if enabled && !m.juliaAnimation {
+		// Start the circle where the parameter is, so it does not jump
+		m.juliaOrbit = m.juliaC - complex(radius, 0)
+		m.juliaAngle = 0
+	}
+	m.juliaAnimation = enabled
+	m.juliaRadius = radius
+}
+
+// AdvanceJuliaAnimation moves the Julia parameter step radians along its
+// circle and recalculates. It does nothing and returns false unless Julia
+// morphing is enabled and the Julia set is shown.
+func (m *MandelbrotSet) AdvanceJuliaAnimation(step float64) bool {
+	if !m.juliaAnimation || m.fractal != FractalJulia {
+		return false
+	}
+	m.juliaAngle = math.Mod(m.juliaAngle+step, 2*math.Pi)
+	m.juliaC = m.juliaOrbit + complex(m.juliaRadius*math.Cos(m.juliaAngle), m.juliaRadius*math.Sin(m.juliaAngle))
+	m.update()
+	return true
+}
+
+// GetJuliaAnimation returns whether Julia morphing is enabled and its radius
+func (m *MandelbrotSet) GetJuliaAnimation() (bool, float64) {
+	return m.juliaAnimation, m.juliaRadius
+}
+
+// ZoomAt zooms by a factor while keeping the complex point (x, y) at the
+// same place on screen, then recalculates
+func (m *MandelbrotSet) ZoomAt(x, y, factor float64) {
+	if factor <= 0 {
+		return
+	}
+	prec := centerPrec(m.zoom * factor)
+	scale := func(center *big.Float, p float64) *big.Float {
+		point := big.NewFloat(p)
+		z := new(big.Float).SetPrec(prec).Sub(center, point)
+		z.Quo(z, big.NewFloat(factor))
+		return z.Add(z, point)
+	}
+	m.setCenter(scale(m.exactReal, x), scale(m.exactImag, y))
+	m.SetZoom(m.zoom * factor)
+}
+
+// ZoomAtCell zooms by a factor while keeping the point in grid cell (x, y) of
+// a gridW x gridH view at the same place on screen, then recalculates. Unlike
+// ZoomAt with the point from ScreenToComplex, it keeps the center exact at
+// deep zooms.
+func (m *MandelbrotSet) ZoomAtCell(x, y, gridW, gridH int, factor float64) {
+	if factor <= 0 {
+		return
 	}
+	// The cell's offset from the center shrinks by the factor
+	dx, dy := m.cellOffset(x, y, gridW, gridH)
+	m.moveCenter(dx*(1-1/factor), dy*(1-1/factor))
+	m.SetZoom(m.zoom * factor)
+}
+
+// cellOffset returns the offset from the center of the point in grid cell
+// (x, y) of a gridW x gridH view
+func (m *MandelbrotSet) cellOffset(x, y, gridW, gridH int) (float64, float64) {
+	v := m.viewportFor(gridW, gridH)
+	return float64(x)*v.stepReal - v.halfWidth, float64(y)*v.stepImag - v.halfHeight
+}
+
+// ScreenToComplex maps grid cell (x, y) of a gridW x gridH view to the
+// complex-plane coordinate rendered in that cell
+func (m *MandelbrotSet) ScreenToComplex(x, y, gridW, gridH int) (float64, float64) {
+	v := m.viewportFor(gridW, gridH)
+	return v.minReal + float64(x)*v.stepReal, v.minImag + float64(y)*v.stepImag
 }
 
 // ZoomIn zooms in by a factor at the current center
@@ -235,8 +740,16 @@ func (m *MandelbrotSet) ZoomOut(factor float64) {
 	m.SetZoom(m.zoom / factor)
 }
 
-// Pan moves the view by the specified offsets (in screen coordinates)
-func (m *MandelbrotSet) Pan(deltaX, deltaY int) {
+// Pan moves the view by the specified offsets (in screen coordinates). If the
+// grid holds the fully computed view and the offsets are smaller than the
+// grid, it is shifted by the offsets and only the newly exposed rows and
+// columns are computed; Pan then reports true. Otherwise, or if the cells are
+// computed from a float64 center that rounding kept from moving by whole
+// cells, the grid is left to a full recalculation.
+func (m *MandelbrotSet) Pan(deltaX, deltaY int) bool {
+	canShift := m.gridView == m.viewKey() &&
+		max(deltaX, -deltaX) < m.width && max(deltaY, -deltaY) < m.height
+
 	// Calculate the current view dimensions
 	viewWidth := 4.0 / m.zoom
 	viewHeight := (4.0 * float64(m.height) / float64(m.width)) / m.zoom
@@ -245,10 +758,71 @@ func (m *MandelbrotSet) Pan(deltaX, deltaY int) {
 	stepReal := viewWidth / float64(m.width)
 	stepImag := viewHeight / float64(m.height)
 
-	newCenterX := m.centerX + float64(deltaX)*stepReal
-	newCenterY := m.centerY + float64(deltaY)*stepImag
+	oldX, oldY := m.centerX, m.centerY
+	m.moveCenter(float64(deltaX)*stepReal, float64(deltaY)*stepImag)
+
+	// The exact center always moves by whole cells; its float64 rounding
+	// may not at deep zooms, and shifted cells would then disagree with the
+	// exposed ones computed next to them
+	if !m.bigPixels() {
+		missX := m.centerX - oldX - float64(deltaX)*stepReal
+		missY := m.centerY - oldY - float64(deltaY)*stepImag
+		canShift = canShift && math.Abs(missX) <= PanTolerance*stepReal && math.Abs(missY) <= PanTolerance*stepImag
+	}
 
-	m.SetCenter(newCenterX, newCenterY)
+	if !canShift {
+		m.update()
+		return false
+	}
+	m.shiftGrid(deltaX, deltaY)
+	return true
+}
+
+// shiftGrid moves the grid contents by (-dx, -dy) cells, so that each cell
+// keeps the point it showed before the center moved by (dx, dy), and
+// computes the cells exposed at the edges. |dx| and |dy| must be smaller than
+// the grid.
+func (m *MandelbrotSet) shiftGrid(dx, dy int) {
+	v := m.viewport()
+	start := time.Now()
+
+	// Rotate the rows; those that wrap around are recomputed below
+	rows := make([][]int, m.height)
+	for y := range rows {
+		rows[y] = m.grid[(y+dy+m.height)%m.height]
+	}
+	m.grid = rows
+
+	// Exposed columns of the rows that were kept
+	x0, x1 := m.width-dx, m.width
+	if dx < 0 {
+		x0, x1 = 0, -dx
+	}
+	exposedRows := max(dy, -dy)
+	m.renderedCells = exposedRows*m.width + (m.height-exposedRows)*(x1-x0)
+
+	numWorkers := runtime.NumCPU()
+	if m.renderedCells < MinParallelCells {
+		numWorkers = 1
+	}
+	parallelRows(m.height, numWorkers, func(y int) {
+		if y+dy < 0 || y+dy >= m.height {
+			m.calculateRow(y, v)
+			return
+		}
+		row := m.grid[y]
+		if dx > 0 {
+			copy(row, row[dx:])
+		} else if dx < 0 {
+			copy(row[-dx:], row)
+		}
+		for x := x0; x < x1; x++ {
+			row[x] = m.iterationsAt(x, y, v)
+		}
+	})
+
+	m.renderDuration = time.Since(start)
+	m.gridView = m.viewKey()
 }
 
 // Reset resets to default parameters
@@ -256,22 +830,26 @@ func (m *MandelbrotSet) Reset(height, width int) {
 	m.height = height
 	m.width = width
 	m.zoom = DefaultZoom
-	m.centerX = DefaultCenterX
-	m.centerY = DefaultCenterY
+	m.setCenter(big.NewFloat(DefaultCenterX), big.NewFloat(DefaultCenterY))
 	m.maxIter = DefaultMaxIterations
 	m.grid = make([][]int, m.height)
 	for i := range m.grid {
 		m.grid[i] = make([]int, m.width)
 	}
-	m.julia = false
+	m.fractal = DefaultFractal
 	juliaC, _ := ParseComplexNumber(DefaultJuliaC)
 	m.juliaC = juliaC
-	m.Calculate()
+	if m.juliaAnimation {
+		// Keep morphing, around the restored parameter
+		m.juliaOrbit = m.juliaC - complex(m.juliaRadius, 0)
+		m.juliaAngle = 0
+	}
+	m.update()
 }
 
-// GetCurrentMode returns the current mode (Mandelbrot or Julia)
-func (m *MandelbrotSet) GetCurrentMode() bool {
-	return m.julia
+// GetCurrentMode returns the current fractal type
+func (m *MandelbrotSet) GetCurrentMode() FractalType {
+	return m.fractal
 }
 
 // GetZoom returns the current zoom level
@@ -294,29 +872,44 @@ func (m *MandelbrotSet) GetJuliaParameter() complex128 {
 	return m.juliaC
 }
 
+// GetHighPrecision reports whether deep zooms are computed with math/big
+func (m *MandelbrotSet) GetHighPrecision() bool {
+	return m.highPrecision
+}
+
+// GetTrap returns the orbit trap used for coloring
+func (m *MandelbrotSet) GetTrap() TrapType {
+	return m.trap
+}
+
+// GetDistanceEstimate reports whether escaped points are shaded by estimated
+// distance to the set
+func (m *MandelbrotSet) GetDistanceEstimate() bool {
+	return m.distanceEstimate
+}
+
+// LastRenderDuration returns the wall-clock time spent computing the current
+// grid, excluding drawing it to the terminal
+func (m *MandelbrotSet) LastRenderDuration() time.Duration {
+	return m.renderDuration
+}
+
+// PixelsPerSecond returns the grid cells computed per second by the last
+// render, or 0 if nothing has been timed yet
+func (m *MandelbrotSet) PixelsPerSecond() float64 {
+	if m.renderDuration <= 0 {
+		return 0
+	}
+	return float64(m.renderedCells) / m.renderDuration.Seconds()
+}
+
 // GetColorScheme returns the current color scheme
 func (m *MandelbrotSet) GetColorScheme() ColorScheme {
 	return m.colorScheme
 }
 
-// GetInterestingPoints returns a list of interesting coordinates to explore
-func (m *MandelbrotSet) GetInterestingPoints() []struct {
-	Name string
-	X, Y float64
-	Zoom float64
-} {
-	return []struct {
-		Name string
-		X, Y float64
-		Zoom float64
-	}{
-		{"Classic View", -0.5, 0.0, 1.0},
-		{"Seahorse Valley", -0.75, 0.1, 50.0},
-		{"Lightning", -1.775, 0.0, 100.0},
-		{"Elephant Valley", 0.25, 0.0, 10.0},
-		{"Spiral", -0.1592, -1.0317, 100.0},
-		{"Mini Mandelbrot", -1.25066, 0.02012, 2000.0},
-		{"Feather", -0.7463, 0.1102, 200.0},
-		{"Dragon", -0.7269, 0.1889, 300.0},
-	}
+// GetInterestingPoints returns the built-in preset locations followed by any
+// presets loaded from a file
+func (m *MandelbrotSet) GetInterestingPoints() []Preset {
+	return m.presets
 }
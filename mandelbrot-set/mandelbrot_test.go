@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
+	"math"
+	"math/cmplx"
+	"runtime"
 	"testing"
+	"time"
 )
 
 func TestNewMandelbrotSet(t *testing.T) {
@@ -39,6 +44,143 @@ func TestMandelbrotIterations(t *testing.T) {
 	}
 }
 
+func TestBurningShipAndTricornIterations(t *testing.T) {
+	mandelbrot := NewMandelbrotSet(DefaultConfig)
+
+	// The origin is bounded for every fractal of the z^2 + c family
+	if result := mandelbrot.burningShipIterations(0); result != DefaultMaxIterations {
+		t.Errorf("Burning Ship: point (0,0) should be in the set, got %d iterations", result)
+	}
+	if result := mandelbrot.tricornIterations(0); result != DefaultMaxIterations {
+		t.Errorf("Tricorn: point (0,0) should be in the set, got %d iterations", result)
+	}
+
+	// Far points escape immediately
+	if result := mandelbrot.burningShipIterations(complex(2, 2)); result >= DefaultMaxIterations {
+		t.Errorf("Burning Ship: point (2,2) should diverge quickly, got %d iterations", result)
+	}
+	if result := mandelbrot.tricornIterations(complex(2, 2)); result >= DefaultMaxIterations {
+		t.Errorf("Tricorn: point (2,2) should diverge quickly, got %d iterations", result)
+	}
+
+	// The Tricorn is symmetric under conjugation; c = -0.1+0.8i lies near
+	// the Mandelbrot set's boundary, where the two fractals differ
+	c := complex(-0.1, 0.8)
+	if mandelbrot.tricornIterations(c) != mandelbrot.tricornIterations(complex(real(c), -imag(c))) {
+		t.Error("Tricorn should be symmetric about the real axis")
+	}
+
+	// Burning Ship is not symmetric about the real axis
+	differs := false
+	for _, c := range []complex128{complex(-1.75, 0.03), complex(-0.5, 0.5), complex(0.3, 0.6)} {
+		if mandelbrot.burningShipIterations(c) != mandelbrot.burningShipIterations(complex(real(c), -imag(c))) {
+			differs = true
+		}
+	}
+	if !differs {
+		t.Error("Burning Ship should not be symmetric about the real axis")
+	}
+}
+
+func TestToggleModeCyclesFractals(t *testing.T) {
+	m := newSizedSet(20, 10)
+	expected := []FractalType{FractalJulia, FractalBurningShip, FractalTricorn, FractalMandelbrot}
+	for _, want := range expected {
+		m.ToggleMode()
+		if got := m.GetCurrentMode(); got != want {
+			t.Errorf("Expected fractal %s, got %s", want.ToString(English), got.ToString(English))
+		}
+	}
+}
+
+func TestConfigSetFractal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected FractalType
+	}{
+		{"mandelbrot", FractalMandelbrot},
+		{"Julia", FractalJulia},
+		{"burning-ship", FractalBurningShip},
+		{"tricorn", FractalTricorn},
+		{"unknown", DefaultFractal},
+	}
+
+	for _, test := range tests {
+		config := DefaultConfig
+		config.SetFractal(test.input)
+		if config.Fractal != test.expected {
+			t.Errorf("For input '%s', expected %d, got %d", test.input, test.expected, config.Fractal)
+		}
+	}
+}
+
+func TestScreenToComplex(t *testing.T) {
+	m := newSizedSet(80, 40)
+
+	// The top-left cell maps to the corner of the 4-wide view
+	x, y := m.ScreenToComplex(0, 0, 80, 40)
+	if x != DefaultCenterX-2 || y != DefaultCenterY-1 {
+		t.Errorf("Expected top-left (%f, %f), got (%f, %f)", DefaultCenterX-2, DefaultCenterY-1, x, y)
+	}
+
+	// The middle cell maps to the center
+	x, y = m.ScreenToComplex(40, 20, 80, 40)
+	if x != DefaultCenterX || y != DefaultCenterY {
+		t.Errorf("Expected center (%f, %f), got (%f, %f)", DefaultCenterX, DefaultCenterY, x, y)
+	}
+}
+
+func TestZoomAtKeepsPointFixed(t *testing.T) {
+	m := newSizedSet(80, 40)
+	px, py := m.ScreenToComplex(10, 5, 80, 40)
+
+	m.ZoomAt(px, py, 2.0)
+	if m.GetZoom() != 2*DefaultZoom {
+		t.Errorf("Expected zoom %f, got %f", 2*DefaultZoom, m.GetZoom())
+	}
+	x, y := m.ScreenToComplex(10, 5, 80, 40)
+	if math.Abs(x-px) > 1e-12 || math.Abs(y-py) > 1e-12 {
+		t.Errorf("Expected (%f, %f) to stay under the cursor, got (%f, %f)", px, py, x, y)
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		input      string
+		x, y, zoom float64
+		hasError   bool
+	}{
+		{"-0.75,0.1,50", -0.75, 0.1, 50, false},
+		{" -0.743643 , 0.131825 , 5e3 ", -0.743643, 0.131825, 5000, false},
+		{"0.25,0", 0.25, 0, 0, false}, // Zoom is optional
+		{"", 0, 0, 0, true},
+		{"0.5", 0, 0, 0, true},
+		{"1,2,3,4", 0, 0, 0, true},
+		{"1,,3", 0, 0, 0, true},
+		{"1,2,0", 0, 0, 0, true},
+		{"1,2,-5", 0, 0, 0, true},
+		{"1e400,0", 0, 0, 0, true},
+		{"1-2,0", 0, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		x, y, zoom, err := ParseLocation(test.input)
+		if test.hasError {
+			if err == nil {
+				t.Errorf("Expected error for input '%s', but got none", test.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for input '%s': %v", test.input, err)
+		}
+		if x != test.x || y != test.y || zoom != test.zoom {
+			t.Errorf("For input '%s', expected (%v, %v, %v), got (%v, %v, %v)",
+				test.input, test.x, test.y, test.zoom, x, y, zoom)
+		}
+	}
+}
+
 func TestComplexNumberParsing(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -99,7 +241,7 @@ func BenchmarkMandelbrotCalculation(b *testing.B) {
 		CenterX:     -0.5,
 		CenterY:     0.0,
 		ColorScheme: ColorSchemeClassic,
-		Julia:       false,
+		Fractal:     FractalMandelbrot,
 		JuliaC:      DefaultJuliaC,
 		Language:    DefaultLanguage,
 	}
@@ -111,6 +253,268 @@ func BenchmarkMandelbrotCalculation(b *testing.B) {
 	}
 }
 
+// newSizedSet returns a Mandelbrot set with the given grid size, not yet calculated
+func newSizedSet(width, height int) *MandelbrotSet {
+	m := NewMandelbrotSet(DefaultConfig)
+	m.width = width
+	m.height = height
+	m.grid = make([][]int, height)
+	for i := range m.grid {
+		m.grid[i] = make([]int, width)
+	}
+	return m
+}
+
+// Test that the serial and parallel paths produce identical grids
+func TestCalculateParallelMatchesSerial(t *testing.T) {
+	for fractal := FractalMandelbrot; fractal <= FractalTricorn; fractal++ {
+		serial := newSizedSet(120, 40)
+		serial.fractal = fractal
+		serial.calculateSerial(serial.viewport())
+
+		for _, workers := range []int{1, 3, 8, 100} {
+			parallel := newSizedSet(120, 40)
+			parallel.fractal = fractal
+			parallel.calculateParallel(parallel.viewport(), workers)
+
+			for y := range serial.grid {
+				for x := range serial.grid[y] {
+					if serial.grid[y][x] != parallel.grid[y][x] {
+						t.Fatalf("fractal=%d workers=%d: cell (%d,%d) serial %d, parallel %d",
+							fractal, workers, y, x, serial.grid[y][x], parallel.grid[y][x])
+					}
+				}
+			}
+		}
+	}
+}
+
+// Test that progressive passes end with the same grid as a full calculation
+func TestCalculatePassMatchesSerial(t *testing.T) {
+	// Sizes that are not multiples of the coarsest stride exercise partial blocks
+	for _, size := range [][2]int{{120, 40}, {37, 13}} {
+		serial := newSizedSet(size[0], size[1])
+		serial.calculateSerial(serial.viewport())
+
+		progressive := newSizedSet(size[0], size[1])
+		prevStride := 0
+		for _, stride := range ProgressiveStrides {
+			if err := progressive.CalculatePass(context.Background(), stride, prevStride); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			prevStride = stride
+		}
+
+		for y := range serial.grid {
+			for x := range serial.grid[y] {
+				if serial.grid[y][x] != progressive.grid[y][x] {
+					t.Fatalf("size %v: cell (%d,%d) serial %d, progressive %d",
+						size, y, x, serial.grid[y][x], progressive.grid[y][x])
+				}
+			}
+		}
+	}
+}
+
+// Test that a coarse pass fills every cell of a block with its corner value
+func TestCalculatePassCoarseBlocks(t *testing.T) {
+	m := newSizedSet(40, 20)
+	if err := m.CalculatePass(context.Background(), 4, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for y := range m.grid {
+		for x := range m.grid[y] {
+			if corner := m.grid[y-y%4][x-x%4]; m.grid[y][x] != corner {
+				t.Fatalf("cell (%d,%d) is %d, block corner is %d", y, x, m.grid[y][x], corner)
+			}
+		}
+	}
+}
+
+// Test that a cancelled pass reports the cancellation
+func TestCalculatePassCancelled(t *testing.T) {
+	m := newSizedSet(40, 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.CalculatePass(ctx, 1, 0); err == nil {
+		t.Error("Expected error for a cancelled pass")
+	}
+}
+
+// Test that render timing covers every progressive pass and restarts with the first
+func TestLastRenderDuration(t *testing.T) {
+	m := newSizedSet(120, 40)
+	m.Calculate()
+	if m.LastRenderDuration() <= 0 {
+		t.Fatal("Expected a positive duration after Calculate")
+	}
+	if m.PixelsPerSecond() <= 0 {
+		t.Error("Expected a positive throughput after Calculate")
+	}
+
+	var passes time.Duration
+	prevStride := 0
+	for _, stride := range ProgressiveStrides {
+		before := m.LastRenderDuration()
+		if err := m.CalculatePass(context.Background(), stride, prevStride); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if prevStride == 0 {
+			before = 0
+		}
+		if m.LastRenderDuration() <= before {
+			t.Errorf("stride %d: expected the duration to grow from %v, got %v", stride, before, m.LastRenderDuration())
+		}
+		passes = m.LastRenderDuration()
+		prevStride = stride
+	}
+
+	want := float64(120*40) / passes.Seconds()
+	if got := m.PixelsPerSecond(); math.Abs(got-want) > want*1e-9 {
+		t.Errorf("Expected %v pixels per second, got %v", want, got)
+	}
+}
+
+// Test that a snapshot does not share its grid with the original
+func TestSnapshot(t *testing.T) {
+	m := newSizedSet(10, 5)
+	snapshot := m.Snapshot()
+	snapshot.grid[0][0] = -1
+	if m.grid[0][0] == -1 {
+		t.Error("Snapshot should not share the grid")
+	}
+}
+
+// Test that panning a computed grid matches a full recalculation at the new center
+func TestPanShiftMatchesCalculate(t *testing.T) {
+	// A power-of-two width makes every cell an exact binary fraction, so the
+	// shifted points are bit-identical to the recomputed ones
+	m := newSizedSet(64, 32)
+	m.Calculate()
+
+	for _, delta := range [][2]int{{5, 0}, {-5, 0}, {0, 3}, {0, -3}, {7, -2}, {-63, 31}} {
+		if !m.Pan(delta[0], delta[1]) {
+			t.Fatalf("pan %v: expected the grid to be shifted", delta)
+		}
+		expected := newSizedSet(64, 32)
+		expected.setCenter(m.exactReal, m.exactImag)
+		expected.Calculate()
+		for y := range expected.grid {
+			for x := range expected.grid[y] {
+				if m.grid[y][x] != expected.grid[y][x] {
+					t.Fatalf("pan %v: cell (%d,%d) is %d, expected %d", delta, y, x, m.grid[y][x], expected.grid[y][x])
+				}
+			}
+		}
+	}
+}
+
+// Test that panning recalculates when the grid cannot be shifted
+func TestPanFallsBackToCalculate(t *testing.T) {
+	m := newSizedSet(64, 32)
+	if m.Pan(1, 0) {
+		t.Error("Expected no shift before the grid is calculated")
+	}
+	if m.Pan(64, 0) || m.Pan(0, -32) {
+		t.Error("Expected no shift for offsets as large as the grid")
+	}
+	if !m.Pan(1, 0) {
+		t.Error("Expected a shift after the full recalculation")
+	}
+
+	// Without auto calculation a zoom leaves the grid stale
+	m.SetAutoCalculate(false)
+	m.ZoomIn(2)
+	if m.Pan(1, 0) {
+		t.Error("Expected no shift after a zoom")
+	}
+
+	// Only the final full-resolution pass makes the grid shiftable
+	if err := m.CalculatePass(context.Background(), 4, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Pan(1, 0) {
+		t.Error("Expected no shift after a coarse pass")
+	}
+	if err := m.CalculatePass(context.Background(), 1, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !m.Pan(1, 0) {
+		t.Error("Expected a shift after the full-resolution pass")
+	}
+}
+
+// Test that at a deep zoom a pan the float64 center cannot make is
+// recalculated instead of shifted, unless cells follow the exact center
+func TestPanDeepZoom(t *testing.T) {
+	m := newSizedSet(40, 10)
+	m.SetCenter(-2, 0)
+	m.SetZoom(1e16) // A cell is about a fortieth of an ulp of -2
+
+	if m.Pan(5, 0) {
+		t.Error("Expected no shift when rounding absorbs the move")
+	}
+	expected := newSizedSet(40, 10)
+	expected.zoom = m.zoom
+	expected.setCenter(m.exactReal, m.exactImag)
+	expected.Calculate()
+	for y := range expected.grid {
+		for x := range expected.grid[y] {
+			if m.grid[y][x] != expected.grid[y][x] {
+				t.Fatalf("Cell (%d,%d) is %d, expected %d", y, x, m.grid[y][x], expected.grid[y][x])
+			}
+		}
+	}
+
+	m.SetHighPrecision(true)
+	if !m.Pan(5, 0) {
+		t.Error("Expected a shift when cells are computed from the exact center")
+	}
+}
+
+// benchmarkCalculate benchmarks the serial or parallel path at a grid size
+func benchmarkCalculate(b *testing.B, width, height int, parallel bool) {
+	m := newSizedSet(width, height)
+	v := m.viewport()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if parallel {
+			m.calculateParallel(v, runtime.NumCPU())
+		} else {
+			m.calculateSerial(v)
+		}
+	}
+}
+
+func BenchmarkCalculateSerial120x40(b *testing.B) {
+	benchmarkCalculate(b, 120, 40, false)
+}
+
+func BenchmarkCalculateParallel120x40(b *testing.B) {
+	benchmarkCalculate(b, 120, 40, true)
+}
+
+func BenchmarkCalculateSerial480x160(b *testing.B) {
+	benchmarkCalculate(b, 480, 160, false)
+}
+
+func BenchmarkCalculateParallel480x160(b *testing.B) {
+	benchmarkCalculate(b, 480, 160, true)
+}
+
+// BenchmarkPan480x160 measures a 5-column pan, which computes only the
+// exposed columns
+func BenchmarkPan480x160(b *testing.B) {
+	m := newSizedSet(480, 160)
+	m.Calculate()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Pan(5-10*(i%2), 0)
+	}
+}
+
 // Test numerical stability with extreme values
 func TestMandelbrotNumericalStability(t *testing.T) {
 	config := Config{
@@ -119,7 +523,7 @@ func TestMandelbrotNumericalStability(t *testing.T) {
 		CenterX:     0.0,
 		CenterY:     0.0,
 		ColorScheme: ColorSchemeClassic,
-		Julia:       false,
+		Fractal:     FractalMandelbrot,
 		JuliaC:      DefaultJuliaC,
 		Language:    DefaultLanguage,
 	}
@@ -145,3 +549,146 @@ func TestMandelbrotNumericalStability(t *testing.T) {
 		}
 	}
 }
+
+// Test orbit-trap coloring values
+func TestTrapIterations(t *testing.T) {
+	m := newSizedSet(20, 10)
+
+	// The orbit of 0 stays on the origin, the closest any orbit can come
+	m.trap = TrapPoint
+	if got := m.trapIterations(0); got != m.maxIter-1 {
+		t.Errorf("Point trap: expected %d for c = 0, got %d", m.maxIter-1, got)
+	}
+	// An orbit that escapes without coming near the trap gets the lowest value
+	if got := m.trapIterations(complex(2, 2)); got != 0 {
+		t.Errorf("Point trap: expected 0 for c = 2+2i, got %d", got)
+	}
+
+	// Real c keeps the whole orbit on the real axis
+	m.trap = TrapLine
+	if got := m.trapIterations(complex(-1, 0)); got != m.maxIter-1 {
+		t.Errorf("Line trap: expected %d for c = -1, got %d", m.maxIter-1, got)
+	}
+
+	// Every value stays below maxIter, so no point is drawn as inside the set
+	for trap := TrapPoint; trap <= TrapCross; trap++ {
+		m.trap = trap
+		for fractal := FractalMandelbrot; fractal <= FractalTricorn; fractal++ {
+			m.fractal = fractal
+			m.calculateSerial(m.viewport())
+			for y, row := range m.grid {
+				for x, v := range row {
+					if v < 0 || v >= m.maxIter {
+						t.Fatalf("trap=%d fractal=%d: cell (%d,%d) is %d", trap, fractal, y, x, v)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Test distance-estimate shading values
+func TestDistanceIterations(t *testing.T) {
+	m := newSizedSet(20, 10)
+	m.distanceEstimate = true
+	ro := NewRenderOptions(ColorSchemeClassic)
+	pixel := m.viewport().stepReal
+
+	// Points inside the set never escape and get the inside color
+	for _, c := range []complex128{0, -1, complex(-0.1, 0.1), complex(0.25, 0)} {
+		got := m.distanceIterations(c, pixel)
+		if got != m.maxIter {
+			t.Errorf("Expected %d for c = %v inside the set, got %d", m.maxIter, c, got)
+		}
+		if color := ro.GetColorForIteration(got, m.maxIter); color != "#000000" {
+			t.Errorf("Expected the inside color for c = %v, got %s", c, color)
+		}
+	}
+
+	// Escaped points get brighter as they approach the boundary
+	far := m.distanceIterations(complex(2, 2), pixel)
+	near := m.distanceIterations(complex(0.26, 0), pixel)
+	if far < 0 || near >= m.maxIter || near <= far {
+		t.Errorf("Expected 0 <= far < near < %d, got far %d and near %d", m.maxIter, far, near)
+	}
+
+	// The grid goes through the distance path and the inside color survives
+	m.calculateSerial(m.viewport())
+	center := m.grid[m.height/2][m.width/2]
+	if center != m.maxIter {
+		t.Errorf("Expected the view center -0.5 to be inside, got %d", center)
+	}
+}
+
+// Test that Julia morphing moves the parameter around its circle, only in
+// Julia mode, and pauses on a manual edit
+func TestJuliaAnimation(t *testing.T) {
+	m := newSizedSet(20, 10)
+	m.SetAutoCalculate(false)
+	start := m.GetJuliaParameter()
+
+	m.SetJuliaAnimation(true, 0.1)
+	if m.AdvanceJuliaAnimation(math.Pi / 2) {
+		t.Error("Expected no morphing outside Julia mode")
+	}
+
+	m.SetFractal(FractalJulia)
+	if !m.AdvanceJuliaAnimation(math.Pi / 2) {
+		t.Fatal("Expected morphing in Julia mode")
+	}
+	// A quarter turn from the start of a circle of radius 0.1 around start-0.1
+	want := start + complex(-0.1, 0.1)
+	if got := m.GetJuliaParameter(); cmplx.Abs(got-want) > 1e-12 {
+		t.Errorf("Expected parameter %v after a quarter turn, got %v", want, got)
+	}
+	m.AdvanceJuliaAnimation(3 * math.Pi / 2)
+	if got := m.GetJuliaParameter(); cmplx.Abs(got-start) > 1e-12 {
+		t.Errorf("Expected a full turn to return to %v, got %v", start, got)
+	}
+
+	m.SetJuliaParameter(complex(0.285, 0.01))
+	if enabled, _ := m.GetJuliaAnimation(); enabled {
+		t.Error("Expected a manual edit to pause morphing")
+	}
+	if m.AdvanceJuliaAnimation(math.Pi/2) || m.GetJuliaParameter() != complex(0.285, 0.01) {
+		t.Error("Expected the edited parameter to stay put")
+	}
+
+	m.SetJuliaAnimation(true, 0)
+	if _, radius := m.GetJuliaAnimation(); radius != DefaultJuliaRadius {
+		t.Errorf("Expected radius 0 to use the default %g, got %g", DefaultJuliaRadius, radius)
+	}
+}
+
+func TestConfigSetTrap(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TrapType
+	}{
+		{"none", TrapNone},
+		{"Point", TrapPoint},
+		{"line", TrapLine},
+		{"cross", TrapCross},
+		{"unknown", DefaultTrap},
+	}
+
+	for _, test := range tests {
+		config := DefaultConfig
+		config.SetTrap(test.input)
+		if config.Trap != test.expected {
+			t.Errorf("For input '%s', expected %d, got %d", test.input, test.expected, config.Trap)
+		}
+	}
+
+	if TrapCross.Next() != TrapNone {
+		t.Error("Expected trap cycle to wrap around to none")
+	}
+
+	// Check validates a trap set directly, without SetTrap
+	config := DefaultConfig
+	config.Trap = TrapCross + 1
+	config.Check()
+	if config.Trap != DefaultTrap {
+		t.Errorf("Expected an out of range trap to reset to %d, got %d", DefaultTrap, config.Trap)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math"
+	"math/big"
+)
+
+// Beyond HighPrecisionZoom the distance between neighbouring cells drops
+// below the float64 resolution of the point itself, so minReal+x*stepReal
+// rounds several cells to the same point and the view turns into blocks.
+// The high precision path keeps the float64 offset of a cell from the center
+// exact by adding it to the exact center in math/big and iterates in math/big
+// as well.
+// Every operation allocates and works on multi-word mantissas, so it is
+// roughly two orders of magnitude slower than float64; it is therefore
+// opt-in and only used once the zoom requires it.
+
+// precisionMargin is the number of mantissa bits kept beyond those needed to
+// resolve one cell at the current zoom
+const precisionMargin = 64
+
+// precision returns the math/big mantissa size needed at the current zoom, or
+// 0 when float64 is sufficient
+func (m *MandelbrotSet) precision() uint {
+	if !m.highPrecision || m.zoom <= HighPrecisionZoom {
+		return 0
+	}
+	return uint(math.Ceil(math.Log2(m.zoom))) + precisionMargin
+}
+
+// bigPixels reports whether iterationsAt computes cells from the exact
+// center in math/big rather than from its float64 rounding
+func (m *MandelbrotSet) bigPixels() bool {
+	return m.precision() > 0 && m.trap == TrapNone && !m.distanceEstimate
+}
+
+// centerPrec returns the mantissa size of the exact center at the given zoom:
+// enough to resolve a cell with precisionMargin bits to spare, and never less
+// than float64
+func centerPrec(zoom float64) uint {
+	return max(53, uint(math.Ceil(math.Log2(max(zoom, 1))))+precisionMargin)
+}
+
+// setCenter sets the exact center and its float64 rounding
+func (m *MandelbrotSet) setCenter(re, im *big.Float) {
+	m.exactReal, m.exactImag = re, im
+	m.centerX, _ = re.Float64()
+	m.centerY, _ = im.Float64()
+}
+
+// moveCenter moves the exact center by (dx, dy) on the complex plane. The
+// sum is kept in math/big, so a move below the float64 resolution of the
+// center still counts even when centerX and centerY do not change.
+func (m *MandelbrotSet) moveCenter(dx, dy float64) {
+	prec := centerPrec(m.zoom)
+	re := new(big.Float).SetPrec(prec).SetFloat64(dx)
+	im := new(big.Float).SetPrec(prec).SetFloat64(dy)
+	m.setCenter(re.Add(re, m.exactReal), im.Add(im, m.exactImag))
+}
+
+// centerKey returns the exact center as text, for viewKey
+func (m *MandelbrotSet) centerKey() string {
+	return m.exactReal.Text('p', 0) + "," + m.exactImag.Text('p', 0)
+}
+
+// bigIterationsAt returns the iteration count of the point at grid cell
+// (x, y), computed with v.prec bits of precision
+func (m *MandelbrotSet) bigIterationsAt(x, y int, v viewport) int {
+	prec := v.prec
+	newFloat := func(f float64) *big.Float {
+		return new(big.Float).SetPrec(prec).SetFloat64(f)
+	}
+
+	pr := newFloat(float64(x)*v.stepReal - v.halfWidth)
+	pr.Add(pr, v.centerReal)
+	pi := newFloat(float64(y)*v.stepImag - v.halfHeight)
+	pi.Add(pi, v.centerImag)
+
+	// Julia iterates the point with a fixed c; the others start from z = 0
+	var zr, zi, cr, ci *big.Float
+	if m.fractal == FractalJulia {
+		zr, zi = pr, pi
+		cr, ci = newFloat(real(m.juliaC)), newFloat(imag(m.juliaC))
+	} else {
+		zr, zi = newFloat(0), newFloat(0)
+		cr, ci = pr, pi
+	}
+
+	zr2, zi2, t := newFloat(0), newFloat(0), newFloat(0)
+	four := big.NewFloat(4)
+
+	for i := 0; i < m.maxIter; i++ {
+		zr2.Mul(zr, zr)
+		zi2.Mul(zi, zi)
+
+		if t.Add(zr2, zi2).Cmp(four) > 0 {
+			return i
+		}
+
+		// Imaginary part 2*zr*zi, folded or conjugated like the float64 paths
+		t.Mul(zr, zi)
+		t.SetMantExp(t, 1)
+		switch m.fractal {
+		case FractalBurningShip:
+			t.Abs(t)
+		case FractalTricorn:
+			t.Neg(t)
+		}
+
+		zi.Add(t, ci)
+		zr.Sub(zr2, zi2)
+		zr.Add(zr, cr)
+	}
+
+	return m.maxIter
+}
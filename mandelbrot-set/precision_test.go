@@ -0,0 +1,139 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Test that the high precision path is only used beyond the zoom threshold
+func TestPrecision(t *testing.T) {
+	m := newSizedSet(20, 10)
+	m.zoom = HighPrecisionZoom * 10
+	if prec := m.precision(); prec != 0 {
+		t.Errorf("Expected float64 when disabled, got %d bits", prec)
+	}
+
+	m.highPrecision = true
+	if prec := m.precision(); prec <= 53 {
+		t.Errorf("Expected more than 53 bits at zoom %g, got %d", m.zoom, prec)
+	}
+
+	m.zoom = DefaultZoom
+	if prec := m.precision(); prec != 0 {
+		t.Errorf("Expected float64 at zoom %g, got %d bits", m.zoom, prec)
+	}
+}
+
+// Test that at low zoom both paths render the same grid. Points on the
+// boundary are chaotic, so the last-bit differences between the two may
+// change the count of a few of them.
+func TestHighPrecisionMatchesFloat64(t *testing.T) {
+	for fractal := FractalMandelbrot; fractal <= FractalTricorn; fractal++ {
+		m := newSizedSet(80, 30)
+		m.fractal = fractal
+		v := m.viewport()
+		hp := v
+		hp.prec = 128
+
+		differ := 0
+		for y := range m.height {
+			for x := range m.width {
+				if m.iterationsAt(x, y, v) != m.iterationsAt(x, y, hp) {
+					differ++
+				}
+			}
+		}
+		if maxDiffer := m.width * m.height / 100; differ > maxDiffer {
+			t.Errorf("%s: %d of %d cells differ, expected at most %d",
+				fractal.ToString(English), differ, m.width*m.height, maxDiffer)
+		}
+	}
+}
+
+// Test that a deep zoom resolves detail float64 collapses into blocks
+func TestHighPrecisionDeepZoom(t *testing.T) {
+	m := newSizedSet(40, 10)
+	m.maxIter = 1000
+	m.setCenter(big.NewFloat(0), big.NewFloat(1)) // Misiurewicz point on the boundary
+	m.zoom = 1e15
+
+	distinct := func() int {
+		seen := map[int]bool{}
+		for _, row := range m.grid {
+			for _, iter := range row {
+				seen[iter] = true
+			}
+		}
+		return len(seen)
+	}
+
+	m.calculateSerial(m.viewport())
+	lowRows := make([][]int, len(m.grid))
+	for i, row := range m.grid {
+		lowRows[i] = append([]int(nil), row...)
+	}
+
+	m.highPrecision = true
+	m.calculateSerial(m.viewport())
+	if distinct() < 2 {
+		t.Errorf("Expected detail at zoom %g, got a uniform grid", m.zoom)
+	}
+
+	same := true
+	for y := range m.grid {
+		for x := range m.grid[y] {
+			if m.grid[y][x] != lowRows[y][x] {
+				same = false
+			}
+		}
+	}
+	if same {
+		t.Error("Expected the high precision grid to differ from float64 at deep zoom")
+	}
+}
+
+// Test that moves below the float64 resolution of the center are kept: at
+// zoom 1e16 a few cells are less than one ulp of -2, the tip of the antenna
+func TestDeepZoomCenterIsExact(t *testing.T) {
+	m := newSizedSet(40, 10)
+	m.highPrecision = true
+	m.SetCenter(-2, 0)
+	m.SetZoom(1e16)
+	start := m.centerKey()
+	before := m.Snapshot()
+
+	m.Pan(5, 0)
+	if x, _ := m.GetCenter(); x != -2 {
+		t.Fatalf("Expected the float64 center to stay at -2, got %v", x)
+	}
+	if m.centerKey() == start {
+		t.Fatal("Expected the pan to move the exact center")
+	}
+	same := true
+	for y := range m.grid {
+		for x := range m.grid[y] {
+			if m.grid[y][x] != before.grid[y][x] {
+				same = false
+			}
+		}
+	}
+	if same {
+		t.Error("Expected the pan to change the rendered grid")
+	}
+
+	m.Pan(-5, 0)
+	if m.centerKey() != start {
+		t.Errorf("Expected panning back to restore the center, got %s", m.centerKey())
+	}
+
+	// Recentering on the center cell and zooming at it keep the center
+	m.CenterOnCell(20, 5, 40, 10)
+	m.ZoomAtCell(20, 5, 40, 10, 2)
+	if m.centerKey() != start {
+		t.Errorf("Expected the center cell to keep the center, got %s", m.centerKey())
+	}
+	m.ZoomAtCell(30, 5, 40, 10, 2)
+	if m.centerKey() == start {
+		t.Error("Expected zooming at another cell to move the exact center")
+	}
+}
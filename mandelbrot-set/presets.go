@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+)
+
+// Preset is a named location on the complex plane
+type Preset struct {
+	Name    string  `json:"name"`
+	X       float64 `json:"x"`
+	Y       float64 `json:"y"`
+	Zoom    float64 `json:"zoom"`
+	MaxIter int     `json:"maxIter,omitempty"` // 0 keeps the current iteration count
+}
+
+// builtinPresets are the preset locations that are always available
+var builtinPresets = []Preset{
+	{Name: "Classic View", X: -0.5, Y: 0.0, Zoom: 1.0},
+	{Name: "Seahorse Valley", X: -0.75, Y: 0.1, Zoom: 50.0},
+	{Name: "Lightning", X: -1.775, Y: 0.0, Zoom: 100.0},
+	{Name: "Elephant Valley", X: 0.25, Y: 0.0, Zoom: 10.0},
+	{Name: "Spiral", X: -0.1592, Y: -1.0317, Zoom: 100.0},
+	{Name: "Mini Mandelbrot", X: -1.25066, Y: 0.02012, Zoom: 2000.0},
+	{Name: "Feather", X: -0.7463, Y: 0.1102, Zoom: 200.0},
+	{Name: "Dragon", X: -0.7269, Y: 0.1889, Zoom: 300.0},
+}
+
+// LoadPresets reads presets from a JSON file holding an array of objects with
+// name, x, y, zoom and optional maxIter fields. Malformed entries are skipped
+// with a logged warning; an error is returned only if the file cannot be read
+// or is not a JSON array.
+func LoadPresets(path string) ([]Preset, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse presets %s: %w", path, err)
+	}
+
+	presets := make([]Preset, 0, len(entries))
+	for i, entry := range entries {
+		var p Preset
+		if err := json.Unmarshal(entry, &p); err != nil {
+			slog.Warn("Skipping malformed preset", "file", path, "index", i, "error", err)
+			continue
+		}
+		if err := p.Validate(); err != nil {
+			slog.Warn("Skipping invalid preset", "file", path, "index", i, "error", err)
+			continue
+		}
+		presets = append(presets, p)
+	}
+	return presets, nil
+}
+
+// Validate reports whether the preset can be navigated to
+func (p Preset) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+		return fmt.Errorf("preset %q: invalid center (%v, %v)", p.Name, p.X, p.Y)
+	}
+	if !(p.Zoom > 0) || math.IsInf(p.Zoom, 0) {
+		return fmt.Errorf("preset %q: zoom %v must be positive", p.Name, p.Zoom)
+	}
+	if p.MaxIter != 0 && (p.MaxIter < MinMaxIterations || p.MaxIter > MaxMaxIterations) {
+		return fmt.Errorf("preset %q: max iterations %d must be between %d and %d",
+			p.Name, p.MaxIter, MinMaxIterations, MaxMaxIterations)
+	}
+	return nil
+}
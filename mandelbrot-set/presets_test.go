@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writePresets writes content to a presets file in a temporary directory
+func writePresets(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "presets.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write presets: %v", err)
+	}
+	return path
+}
+
+// Test that valid entries are loaded and malformed ones are skipped
+func TestLoadPresets(t *testing.T) {
+	path := writePresets(t, `[
+		{"name": "Deep Zoom", "x": -0.743643, "y": 0.131825, "zoom": 5000, "maxIter": 500},
+		{"name": "Default Iterations", "x": 0.3, "y": 0.5, "zoom": 20},
+		{"name": "", "x": 0, "y": 0, "zoom": 1},
+		{"name": "Bad Zoom", "x": 0, "y": 0, "zoom": 0},
+		{"name": "Bad Iterations", "x": 0, "y": 0, "zoom": 1, "maxIter": 5},
+		{"name": "Wrong Type", "x": "left", "y": 0, "zoom": 1},
+		42
+	]`)
+
+	presets, err := LoadPresets(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []Preset{
+		{Name: "Deep Zoom", X: -0.743643, Y: 0.131825, Zoom: 5000, MaxIter: 500},
+		{Name: "Default Iterations", X: 0.3, Y: 0.5, Zoom: 20},
+	}
+	if len(presets) != len(expected) {
+		t.Fatalf("Expected %d presets, got %d: %v", len(expected), len(presets), presets)
+	}
+	for i := range expected {
+		if presets[i] != expected[i] {
+			t.Errorf("Expected preset %v, got %v", expected[i], presets[i])
+		}
+	}
+}
+
+// Test that unreadable or non-array files are reported
+func TestLoadPresetsErrors(t *testing.T) {
+	if _, err := LoadPresets(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+	if _, err := LoadPresets(writePresets(t, `{"name": "Not An Array"}`)); err == nil {
+		t.Error("Expected error for a file that is not a JSON array")
+	}
+}
+
+// Test that user presets follow the built-in ones
+func TestGetInterestingPointsMergesPresets(t *testing.T) {
+	config := DefaultConfig
+	config.Presets = []Preset{{Name: "Mine", X: 0.1, Y: 0.2, Zoom: 3}}
+	m := NewMandelbrotSet(config)
+
+	presets := m.GetInterestingPoints()
+	if len(presets) != len(builtinPresets)+1 {
+		t.Fatalf("Expected %d presets, got %d", len(builtinPresets)+1, len(presets))
+	}
+	if presets[0] != builtinPresets[0] {
+		t.Errorf("Expected built-in presets first, got %v", presets[0])
+	}
+	if presets[len(presets)-1].Name != "Mine" {
+		t.Errorf("Expected user preset last, got %v", presets[len(presets)-1])
+	}
+}
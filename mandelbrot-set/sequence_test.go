@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Test that a zoom sequence writes one numbered PNG per frame
+func TestRenderZoomSequence(t *testing.T) {
+	m := NewMandelbrotSet(DefaultConfig)
+	dir := filepath.Join(t.TempDir(), "frames")
+
+	target := Point{X: -0.75, Y: 0.1}
+	if err := m.RenderZoomSequence(target, 1, 100, 5, 32, 18, dir); err != nil {
+		t.Fatalf("RenderZoomSequence failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read frame directory: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 frames, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		if want := fmt.Sprintf("frame_%04d.png", i); entry.Name() != want {
+			t.Errorf("Expected %s, got %s", want, entry.Name())
+		}
+	}
+
+	// The set keeps its own view
+	if m.GetZoom() != DefaultZoom {
+		t.Errorf("Expected zoom %f to be unchanged, got %f", DefaultZoom, m.GetZoom())
+	}
+}
+
+// Test the zoom and center of each frame
+func TestZoomFrame(t *testing.T) {
+	m := NewMandelbrotSet(DefaultConfig)
+	target := Point{X: -0.75, Y: 0.1}
+
+	zoom, x, y := m.zoomFrame(target, 1, 1000, 0, 4)
+	if zoom != 1 || x != DefaultCenterX || y != DefaultCenterY {
+		t.Errorf("First frame: expected zoom 1 at the current center, got %g at (%g, %g)", zoom, x, y)
+	}
+
+	// Zoom is interpolated geometrically
+	zoom, _, _ = m.zoomFrame(target, 1, 1000, 1, 4)
+	if math.Abs(zoom-10) > 1e-9 {
+		t.Errorf("Second frame: expected zoom 10, got %g", zoom)
+	}
+
+	zoom, x, y = m.zoomFrame(target, 1, 1000, 3, 4)
+	if math.Abs(zoom-1000) > 1e-9 || x != target.X || y != target.Y {
+		t.Errorf("Last frame: expected zoom 1000 at the target, got %g at (%g, %g)", zoom, x, y)
+	}
+
+	// The target stays within the view in every frame
+	for i := range 4 {
+		zoom, x, _ := m.zoomFrame(target, 1, 1000, i, 4)
+		if math.Abs(target.X-x) > 2/zoom {
+			t.Errorf("Frame %d: target is off screen", i)
+		}
+	}
+}
+
+// Test zoom sequence argument validation
+func TestRenderZoomSequenceInvalid(t *testing.T) {
+	m := NewMandelbrotSet(DefaultConfig)
+	dir := t.TempDir()
+	target := Point{}
+
+	if err := m.RenderZoomSequence(target, 1, 10, 0, 32, 18, dir); err == nil {
+		t.Error("Expected error for zero frames")
+	}
+	if err := m.RenderZoomSequence(target, 0, 10, 3, 32, 18, dir); err == nil {
+		t.Error("Expected error for zero start zoom")
+	}
+	if err := m.RenderZoomSequence(target, 1, 10, 3, 0, 18, dir); err == nil {
+		t.Error("Expected error for zero width")
+	}
+}
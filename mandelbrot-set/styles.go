@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -54,15 +55,32 @@ const (
 	ColorLabelCN = "🎨 配色: %s"
 	ColorLabelEN = "🎨 Color: %s"
 
+	TrapLabelCN = "🪤 轨道陷阱: %s"
+	TrapLabelEN = "🪤 Trap: %s"
+
+	DistanceLabelCN = "📏 距离估计"
+	DistanceLabelEN = "📏 Distance Estimate"
+
+	LocationInputLabelCN = "⌨️ 跳转 (x,y,缩放): %s_"
+	LocationInputLabelEN = "⌨️ Go to (x,y,zoom): %s_"
+
+	InvalidLocationLabelCN = "⚠️ 无效位置: %s"
+	InvalidLocationLabelEN = "⚠️ Invalid location: %s"
+
+	RenderLabelCN = "⏱️ 渲染: %s (%s 像素/秒)"
+	RenderLabelEN = "⏱️ Render: %s (%s px/s)"
+
 	StatusLabelCalculatingCN = "⚡ 计算中"
 	StatusLabelCalculatingEN = "⚡ Calculating"
 	StatusLabelReadyCN       = "✅ 就绪"
 	StatusLabelReadyEN       = "✅ Ready"
 
-	ModeNameMandelbrotCN = "曼德博"
-	ModeNameMandelbrotEN = "Mandelbrot"
-	ModeNameJuliaCN      = "朱利亚"
-	ModeNameJuliaEN      = "Julia"
+	SavingLabelCN     = "💾 正在保存图像..."
+	SavingLabelEN     = "💾 Saving image..."
+	SavedLabelCN      = "💾 已保存: %s"
+	SavedLabelEN      = "💾 Saved: %s"
+	SaveFailedLabelCN = "⚠️ 保存失败: %v"
+	SaveFailedLabelEN = "⚠️ Save failed: %v"
 
 	// Control Line
 	MoveControlLabelCN = "WASD/方向键 移动"
@@ -71,18 +89,36 @@ const (
 	ZoomControlLabelCN = "+/- 缩放"
 	ZoomControlLabelEN = "+/- Zoom"
 
-	ModeControlLabelCN = "M 切换模式"
-	ModeControlLabelEN = "M Toggle Mode"
+	MouseControlLabelCN = "鼠标 点击居中/滚轮缩放"
+	MouseControlLabelEN = "Mouse Click Center/Wheel Zoom"
+
+	ModeControlLabelCN = "M 切换分形"
+	ModeControlLabelEN = "M Next Fractal"
 
 	ColorControlLabelCN = "C 切换配色"
 	ColorControlLabelEN = "C Toggle Color"
 
+	TrapControlLabelCN = "T 轨道陷阱"
+	TrapControlLabelEN = "T Orbit Trap"
+
+	DistanceControlLabelCN = "E 距离估计"
+	DistanceControlLabelEN = "E Distance Estimate"
+
+	JuliaControlLabelCN = "J 朱利亚变形"
+	JuliaControlLabelEN = "J Julia Morph"
+
 	IterControlLabelCN = "I/K 迭代+/-"
 	IterControlLabelEN = "I/K Iter +/-"
 
 	PresetControlLabelCN = "P 预设位置"
 	PresetControlLabelEN = "P Preset Location"
 
+	GoToLabelCN = ": 输入坐标"
+	GoToLabelEN = ": Go To"
+
+	SaveImageLabelCN = "O 保存图像"
+	SaveImageLabelEN = "O Save Image"
+
 	LanguageLabelCN = "L 切换语言"
 	LanguageLabelEN = "L Switch Language"
 
@@ -95,6 +131,8 @@ const (
 	// Julia parameter line
 	JuliaParamLabelCN = "🔢 朱利亚参数: %v"
 	JuliaParamLabelEN = "🔢 Julia Parameter: %v"
+	JuliaMorphLabelCN = "🌀 变形中 (半径 %g)"
+	JuliaMorphLabelEN = "🌀 Morphing (radius %g)"
 )
 
 // RenderOptions holds rendering configuration
@@ -252,8 +290,8 @@ func (m Model) HeaderLineView() string {
 
 // StatusLineView returns the status display string
 func (m Model) StatusLineView() string {
-	var status, modeLabel, zoomLabel, centerLabel, iterLabel, colorLabel string
-	var modeName string
+	var status, modeLabel, zoomLabel, centerLabel, iterLabel, colorLabel, trapLabel, distanceLabel, renderLabel, locationInputLabel, invalidLocationLabel string
+	modeName := m.mandelbrotSet.GetCurrentMode().ToString(m.language)
 
 	if m.language == Chinese {
 		status = StatusLabelReadyCN
@@ -265,11 +303,11 @@ func (m Model) StatusLineView() string {
 		centerLabel = CenterLabelCN
 		iterLabel = IterLabelCN
 		colorLabel = ColorLabelCN
-		if m.mandelbrotSet.GetCurrentMode() {
-			modeName = ModeNameJuliaCN
-		} else {
-			modeName = ModeNameMandelbrotCN
-		}
+		trapLabel = TrapLabelCN
+		distanceLabel = DistanceLabelCN
+		renderLabel = RenderLabelCN
+		locationInputLabel = LocationInputLabelCN
+		invalidLocationLabel = InvalidLocationLabelCN
 	} else {
 		status = StatusLabelReadyEN
 		if m.calculating {
@@ -280,11 +318,11 @@ func (m Model) StatusLineView() string {
 		centerLabel = CenterLabelEN
 		iterLabel = IterLabelEN
 		colorLabel = ColorLabelEN
-		if m.mandelbrotSet.GetCurrentMode() {
-			modeName = ModeNameJuliaEN
-		} else {
-			modeName = ModeNameMandelbrotEN
-		}
+		trapLabel = TrapLabelEN
+		distanceLabel = DistanceLabelEN
+		renderLabel = RenderLabelEN
+		locationInputLabel = LocationInputLabelEN
+		invalidLocationLabel = InvalidLocationLabelEN
 	}
 
 	centerX, centerY := m.mandelbrotSet.GetCenter()
@@ -294,53 +332,113 @@ func (m Model) StatusLineView() string {
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(zoomLabel, m.mandelbrotSet.GetZoom())))
 	tableBuilder.WriteString(" | ")
-	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(centerLabel, centerX, centerY)))
+	switch {
+	case m.enteringLocation:
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(locationInputLabel, m.locationInput)))
+	case m.invalidLocation != "":
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(invalidLocationLabel, m.invalidLocation)))
+	default:
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(centerLabel, centerX, centerY)))
+	}
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(iterLabel, m.mandelbrotSet.GetMaxIterations())))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(colorLabel, m.mandelbrotSet.GetColorScheme().ToString(m.language))))
 	tableBuilder.WriteString(" | ")
+	if trap := m.mandelbrotSet.GetTrap(); trap != TrapNone {
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(trapLabel, trap.ToString(m.language))))
+		tableBuilder.WriteString(" | ")
+	} else if m.mandelbrotSet.GetDistanceEstimate() {
+		tableBuilder.WriteString(labelStyle.Render(distanceLabel))
+		tableBuilder.WriteString(" | ")
+	}
+	if m.renderTime > 0 {
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(renderLabel, formatRenderTime(m.renderTime), formatRate(m.renderRate))))
+		tableBuilder.WriteString(" | ")
+	}
 	tableBuilder.WriteString(labelStyle.Render(status))
+	if m.notice != "" {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(m.notice))
+	}
 
 	statusLine := lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
 
 	// Julia parameter line (if in Julia mode)
-	if m.mandelbrotSet.GetCurrentMode() {
-		var juliaParamLabel string
+	if m.mandelbrotSet.GetCurrentMode() == FractalJulia {
+		var juliaParamLabel, juliaMorphLabel string
 		if m.language == Chinese {
 			juliaParamLabel = JuliaParamLabelCN
+			juliaMorphLabel = JuliaMorphLabelCN
 		} else {
 			juliaParamLabel = JuliaParamLabelEN
+			juliaMorphLabel = JuliaMorphLabelEN
 		}
 		juliaParam := m.mandelbrotSet.GetJuliaParameter()
-		juliaLine := lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(
-			labelStyle.Render(fmt.Sprintf(juliaParamLabel, juliaParam)))
+		juliaText := labelStyle.Render(fmt.Sprintf(juliaParamLabel, juliaParam))
+		if enabled, radius := m.mandelbrotSet.GetJuliaAnimation(); enabled {
+			juliaText += " | " + labelStyle.Render(fmt.Sprintf(juliaMorphLabel, radius))
+		}
+		juliaLine := lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(juliaText)
 		statusLine += "\n" + juliaLine
 	}
 
 	return statusLine
 }
 
+// formatRenderTime formats a render duration with millisecond resolution,
+// or microseconds for renders under a millisecond
+func formatRenderTime(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// formatRate formats a per-second rate with a k or M suffix
+func formatRate(rate float64) string {
+	switch {
+	case rate >= 1e6:
+		return fmt.Sprintf("%.1fM", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%.1fk", rate/1e3)
+	default:
+		return fmt.Sprintf("%.0f", rate)
+	}
+}
+
 // ControlLineView returns the control display string
 func (m Model) ControlLineView() string {
-	var moveControl, zoomControl, modeControl, colorControl, iterControl, presetControl, language, reset, quit string
+	var moveControl, zoomControl, mouseControl, modeControl, colorControl, trapControl, distanceControl, juliaControl, iterControl, presetControl, goTo, saveImage, language, reset, quit string
 	if m.language == Chinese {
 		moveControl = MoveControlLabelCN
 		zoomControl = ZoomControlLabelCN
+		mouseControl = MouseControlLabelCN
 		modeControl = ModeControlLabelCN
 		colorControl = ColorControlLabelCN
+		trapControl = TrapControlLabelCN
+		distanceControl = DistanceControlLabelCN
+		juliaControl = JuliaControlLabelCN
 		iterControl = IterControlLabelCN
 		presetControl = PresetControlLabelCN
+		goTo = GoToLabelCN
+		saveImage = SaveImageLabelCN
 		language = LanguageLabelCN
 		reset = ResetLabelCN
 		quit = QuitLabelCN
 	} else {
 		moveControl = MoveControlLabelEN
 		zoomControl = ZoomControlLabelEN
+		mouseControl = MouseControlLabelEN
 		modeControl = ModeControlLabelEN
 		colorControl = ColorControlLabelEN
+		trapControl = TrapControlLabelEN
+		distanceControl = DistanceControlLabelEN
+		juliaControl = JuliaControlLabelEN
 		iterControl = IterControlLabelEN
 		presetControl = PresetControlLabelEN
+		goTo = GoToLabelEN
+		saveImage = SaveImageLabelEN
 		language = LanguageLabelEN
 		reset = ResetLabelEN
 		quit = QuitLabelEN
@@ -351,14 +449,28 @@ func (m Model) ControlLineView() string {
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(zoomControl))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(mouseControl))
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(modeControl))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(colorControl))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(trapControl))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(distanceControl))
+	tableBuilder.WriteString(" | ")
+	if m.mandelbrotSet.GetCurrentMode() == FractalJulia {
+		tableBuilder.WriteString(labelStyle.Render(juliaControl))
+		tableBuilder.WriteString(" | ")
+	}
 	tableBuilder.WriteString(labelStyle.Render(iterControl))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(presetControl))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(goTo))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(saveImage))
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(language))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(reset))
@@ -374,3 +486,27 @@ func (m Model) ControlLineView() string {
 
 	return controlLine
 }
+
+// savingLabel returns the image export progress message
+func (m Model) savingLabel() string {
+	if m.language == Chinese {
+		return SavingLabelCN
+	}
+	return SavingLabelEN
+}
+
+// savedLabel returns the image export success message format
+func (m Model) savedLabel() string {
+	if m.language == Chinese {
+		return SavedLabelCN
+	}
+	return SavedLabelEN
+}
+
+// saveFailedLabel returns the image export failure message format
+func (m Model) saveFailedLabel() string {
+	if m.language == Chinese {
+		return SaveFailedLabelCN
+	}
+	return SaveFailedLabelEN
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 var (
@@ -32,6 +34,23 @@ type Model struct {
 	gridBuffer    strings.Builder
 	renderOptions RenderOptions
 	logger        *slog.Logger
+
+	saving bool   // Whether an image export is running
+	notice string // Result of the last image export, shown in the status line
+
+	// Coordinate entry
+	enteringLocation bool   // Whether keys are accumulating a location
+	locationInput    string // Text typed so far
+	invalidLocation  string // Last rejected input, shown in the status line until the next key
+
+	// Progressive rendering; see recalculate
+	renderGen    int                // Incremented for every view change
+	renderCtx    context.Context    // Context of the in-flight render
+	cancelRender context.CancelFunc // Cancels the in-flight render
+	renderTime   time.Duration      // Computation time of the displayed grid
+	renderRate   float64            // Grid cells computed per second for the displayed grid
+
+	juliaGen int // Incremented whenever Julia morphing is toggled; see juliaTick
 }
 
 // NewModel creates a new model with the given configuration
@@ -41,8 +60,12 @@ func NewModel(cfg Config) Model {
 	gridHeight := DefaultRows - keepHeight
 	gridWidth := DefaultCols - keepWidth
 
+	// The model renders progressively, so setters must not block on a full calculation
+	mandelbrotSet := NewMandelbrotSet(cfg)
+	mandelbrotSet.SetAutoCalculate(false)
+
 	model := Model{
-		mandelbrotSet: NewMandelbrotSet(cfg),
+		mandelbrotSet: mandelbrotSet,
 		width:         DefaultCols,
 		gridHeight:    gridHeight,
 		gridWidth:     gridWidth,
@@ -57,11 +80,30 @@ func NewModel(cfg Config) Model {
 	return model
 }
 
-// calculationMsg is sent when calculation is complete
-type calculationMsg time.Time
+// renderPassMsg is sent when a progressive rendering pass finishes
+type renderPassMsg struct {
+	gen  int            // Render generation the pass belongs to
+	pass int            // Index into ProgressiveStrides
+	set  *MandelbrotSet // Private copy holding the pass result
+	err  error          // Non-nil if the pass was cancelled
+}
+
+// juliaTickMsg is sent every frame while Julia morphing is enabled
+type juliaTickMsg struct {
+	gen int // Julia morphing generation the tick belongs to
+}
+
+// imageSavedMsg is sent when an image export finishes
+type imageSavedMsg struct {
+	path string
+	err  error
+}
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
+	if enabled, _ := m.mandelbrotSet.GetJuliaAnimation(); enabled {
+		return m.juliaTick()
+	}
 	return nil
 }
 
@@ -71,12 +113,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		m.logger.Debug("Key pressed", "key", msg.String())
 		return m.handleKeyPress(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.WindowSizeMsg:
 		m.logger.Debug("Window size", "width", msg.Width, "height", msg.Height)
 		return m.handleWindowResize(msg)
-	case calculationMsg:
-		m.logger.Debug("Calculation complete", "time", msg)
-		m.calculating = false
+	case renderPassMsg:
+		return m.handleRenderPass(msg)
+	case juliaTickMsg:
+		return m.handleJuliaTick(msg)
+	case imageSavedMsg:
+		m.saving = false
+		if msg.err != nil {
+			m.logger.Error("Failed to save image", "path", msg.path, "error", msg.err)
+			m.notice = fmt.Sprintf(m.saveFailedLabel(), msg.err)
+		} else {
+			m.logger.Debug("Image saved", "path", msg.path)
+			m.notice = fmt.Sprintf(m.savedLabel(), msg.path)
+		}
 		return m, nil
 	}
 	return m, nil
@@ -101,36 +155,34 @@ func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.gridWidth = msg.Width - keepWidth
 	m.gridHeight = msg.Height - keepHeight
 	m.mandelbrotSet.Reset(m.gridHeight, m.gridWidth)
-	return m, nil
+	return m.recalculate()
 }
 
 // handleKeyPress processes keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if m.calculating {
-		// Ignore input while calculating except quit
-		if msg.String() == "ctrl+c" || msg.String() == "q" {
-			return m, tea.Quit
-		}
-		return m, nil
+	m.invalidLocation = ""
+	if m.enteringLocation {
+		return m.handleLocationInput(msg.String())
 	}
 
 	switch msg.String() {
 	case "ctrl+c", "q", "esc":
 		return m, tea.Quit
 
+	// Coordinate entry
+	case ":":
+		m.enteringLocation = true
+		m.locationInput = ""
+
 	// Pan controls
 	case "up", "w":
-		m.mandelbrotSet.Pan(0, -5)
-		return m.recalculate()
+		return m.pan(0, -5)
 	case "down", "s":
-		m.mandelbrotSet.Pan(0, 5)
-		return m.recalculate()
+		return m.pan(0, 5)
 	case "left", "a":
-		m.mandelbrotSet.Pan(-5, 0)
-		return m.recalculate()
+		return m.pan(-5, 0)
 	case "right", "d":
-		m.mandelbrotSet.Pan(5, 0)
-		return m.recalculate()
+		return m.pan(5, 0)
 
 	// Zoom controls
 	case "+", "=":
@@ -145,6 +197,25 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.mandelbrotSet.ToggleMode()
 		return m.recalculate()
 
+	// Orbit trap controls
+	case "t", "T":
+		m.mandelbrotSet.SetTrap(m.mandelbrotSet.GetTrap().Next())
+		return m.recalculate()
+
+	// Distance estimate controls
+	case "e", "E":
+		m.mandelbrotSet.SetDistanceEstimate(!m.mandelbrotSet.GetDistanceEstimate())
+		return m.recalculate()
+
+	// Julia morphing controls
+	case "j", "J":
+		enabled, radius := m.mandelbrotSet.GetJuliaAnimation()
+		m.mandelbrotSet.SetJuliaAnimation(!enabled, radius)
+		m.juliaGen++
+		if !enabled {
+			return m, m.juliaTick()
+		}
+
 	// Color scheme controls
 	case "c", "C":
 		currentScheme := m.mandelbrotSet.GetColorScheme()
@@ -168,6 +239,10 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "p", "P":
 		return m.goToNextPreset()
 
+	// Image export
+	case "o", "O":
+		return m.saveImage()
+
 	// Language toggle
 	case "l", "L":
 		if m.language == English {
@@ -184,30 +259,209 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Fine pan controls
 	case "shift+up":
-		m.mandelbrotSet.Pan(0, -1)
-		return m.recalculate()
+		return m.pan(0, -1)
 	case "shift+down":
-		m.mandelbrotSet.Pan(0, 1)
-		return m.recalculate()
+		return m.pan(0, 1)
 	case "shift+left":
-		m.mandelbrotSet.Pan(-1, 0)
-		return m.recalculate()
+		return m.pan(-1, 0)
 	case "shift+right":
-		m.mandelbrotSet.Pan(1, 0)
+		return m.pan(1, 0)
+	}
+
+	return m, nil
+}
+
+// pan moves the view, recalculating unless the set could shift its finished
+// grid
+func (m Model) pan(dx, dy int) (tea.Model, tea.Cmd) {
+	if !m.mandelbrotSet.Pan(dx, dy) {
 		return m.recalculate()
 	}
+	// A render still in flight was started for an older view
+	if m.cancelRender != nil {
+		m.cancelRender()
+		m.renderCtx, m.cancelRender = nil, nil
+	}
+	m.renderGen++
+	m.calculating = false
+	m.renderTime = m.mandelbrotSet.LastRenderDuration()
+	m.renderRate = m.mandelbrotSet.PixelsPerSecond()
+	return m, nil
+}
 
+// handleLocationInput processes keys while a location is being typed:
+// numbers, commas and signs accumulate, backspace deletes, enter jumps to the
+// location and esc cancels
+func (m Model) handleLocationInput(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.enteringLocation = false
+		m.locationInput = ""
+
+	case "backspace":
+		if len(m.locationInput) > 0 {
+			m.locationInput = m.locationInput[:len(m.locationInput)-1]
+		}
+
+	case "enter":
+		m.enteringLocation = false
+		input := m.locationInput
+		m.locationInput = ""
+		if input == "" {
+			return m, nil
+		}
+
+		x, y, zoom, err := ParseLocation(input)
+		if err != nil {
+			m.logger.Debug("Invalid location", "input", input, "error", err)
+			m.invalidLocation = input
+			return m, nil
+		}
+		m.mandelbrotSet.SetCenter(x, y)
+		if zoom > 0 {
+			m.mandelbrotSet.SetZoom(zoom)
+		}
+		return m.recalculate()
+
+	default:
+		if len(key) == 1 && strings.Contains(LocationInputChars, key) && len(m.locationInput) < MaxLocationInput {
+			m.locationInput += key
+		}
+	}
 	return m, nil
 }
 
-// recalculate starts a new calculation
+// handleMouse processes mouse input: a left click recenters the view on the
+// clicked point and the wheel zooms around the point under the cursor
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	x, y, ok := m.gridCell(msg.X, msg.Y)
+	if !ok {
+		return m, nil
+	}
+	cx, cy := m.mandelbrotSet.ScreenToComplex(x, y, m.gridWidth, m.gridHeight)
+	m.logger.Debug("Mouse pressed", "button", msg.Button, "x", x, "y", y, "real", cx, "imag", cy)
+
+	switch msg.Button {
+	case tea.MouseButtonLeft:
+		m.mandelbrotSet.CenterOnCell(x, y, m.gridWidth, m.gridHeight)
+	case tea.MouseButtonWheelUp:
+		m.mandelbrotSet.ZoomAtCell(x, y, m.gridWidth, m.gridHeight, 2.0)
+	case tea.MouseButtonWheelDown:
+		m.mandelbrotSet.ZoomAtCell(x, y, m.gridWidth, m.gridHeight, 0.5)
+	default:
+		return m, nil
+	}
+	return m.recalculate()
+}
+
+// gridCell converts terminal coordinates to a fractal grid cell, reporting
+// false when the point lies outside the grid
+func (m Model) gridCell(screenX, screenY int) (int, int, bool) {
+	// The grid follows the header, the status line(s), and one blank line
+	top := lipgloss.Height(m.HeaderLineView()) + lipgloss.Height(m.StatusLineView()) + 1
+	x, y := screenX, screenY-top
+	if x < 0 || x >= m.gridWidth || y < 0 || y >= m.gridHeight {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+// recalculate starts a progressive render of the current view, cancelling
+// any render still in flight. Each pass of ProgressiveStrides runs on a
+// snapshot of the set in the background and reports back with a
+// renderPassMsg, so the coarse preview shows up quickly and key presses are
+// handled between passes.
 func (m Model) recalculate() (tea.Model, tea.Cmd) {
+	if m.cancelRender != nil {
+		m.cancelRender()
+	}
+	m.renderCtx, m.cancelRender = context.WithCancel(context.Background())
+	m.renderGen++
 	m.calculating = true
-	return m, tea.Tick(m.refreshRate, func(t time.Time) tea.Msg {
-		return calculationMsg(t)
+	return m, m.renderPass(m.mandelbrotSet.Snapshot(), 0)
+}
+
+// renderPass returns a command that computes one progressive pass on set
+func (m Model) renderPass(set *MandelbrotSet, pass int) tea.Cmd {
+	ctx, gen := m.renderCtx, m.renderGen
+	return func() tea.Msg {
+		prevStride := 0
+		if pass > 0 {
+			prevStride = ProgressiveStrides[pass-1]
+		}
+		err := set.CalculatePass(ctx, ProgressiveStrides[pass], prevStride)
+		return renderPassMsg{gen: gen, pass: pass, set: set, err: err}
+	}
+}
+
+// handleRenderPass shows a finished pass and starts the next, finer one
+func (m Model) handleRenderPass(msg renderPassMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.renderGen || msg.err != nil {
+		// Superseded by a newer view change
+		return m, nil
+	}
+	m.logger.Debug("Render pass complete", "pass", msg.pass, "stride", ProgressiveStrides[msg.pass])
+	m.mandelbrotSet.SetGridFrom(msg.set)
+	m.renderTime = msg.set.LastRenderDuration()
+	m.renderRate = msg.set.PixelsPerSecond()
+
+	if next := msg.pass + 1; next < len(ProgressiveStrides) {
+		// The displayed grid must not be written to, so refine a copy
+		return m, m.renderPass(msg.set.Snapshot(), next)
+	}
+
+	m.calculating = false
+	m.cancelRender()
+	m.renderCtx, m.cancelRender = nil, nil
+	return m, nil
+}
+
+// juliaTick returns a command that sends the next Julia morphing frame
+func (m Model) juliaTick() tea.Cmd {
+	gen := m.juliaGen
+	return tea.Tick(JuliaAnimationRate, func(time.Time) tea.Msg {
+		return juliaTickMsg{gen: gen}
 	})
 }
 
+// handleJuliaTick moves the Julia parameter one step and recalculates. A
+// frame is skipped while the previous one is still rendering, so slow views
+// morph more slowly instead of never finishing a render.
+func (m Model) handleJuliaTick(msg juliaTickMsg) (tea.Model, tea.Cmd) {
+	if enabled, _ := m.mandelbrotSet.GetJuliaAnimation(); !enabled || msg.gen != m.juliaGen {
+		// Morphing was stopped or restarted with a newer tick
+		return m, nil
+	}
+	if m.calculating || !m.mandelbrotSet.AdvanceJuliaAnimation(JuliaAnimationStep) {
+		return m, m.juliaTick()
+	}
+	updated, cmd := m.recalculate()
+	return updated, tea.Batch(cmd, m.juliaTick())
+}
+
+// saveImage exports the current view as a PNG image in the background
+func (m Model) saveImage() (tea.Model, tea.Cmd) {
+	if m.saving {
+		return m, nil
+	}
+	m.saving = true
+	m.notice = m.savingLabel()
+
+	// Render from a copy so later key presses do not race with the export
+	snapshot := *m.mandelbrotSet
+	path := snapshot.ImageFileName(time.Now())
+	return m, func() tea.Msg {
+		return imageSavedMsg{path: path, err: snapshot.SaveImage(path, ImageWidth, ImageHeight)}
+	}
+}
+
 // goToNextPreset goes to the next interesting preset location
 func (m Model) goToNextPreset() (tea.Model, tea.Cmd) {
 	presets := m.mandelbrotSet.GetInterestingPoints()
@@ -220,6 +474,9 @@ func (m Model) goToNextPreset() (tea.Model, tea.Cmd) {
 
 	m.mandelbrotSet.SetCenter(preset.X, preset.Y)
 	m.mandelbrotSet.SetZoom(preset.Zoom)
+	if preset.MaxIter > 0 {
+		m.mandelbrotSet.SetMaxIterations(preset.MaxIter)
+	}
 	return m.recalculate()
 }
 
@@ -241,11 +498,7 @@ func (m Model) RenderMode() string {
 
 // RenderGrid renders the fractal grid
 func (m Model) RenderGrid() string {
-	// Main fractal grid
-	if m.calculating {
-		return m.renderCalculatingMessage()
-	}
-
+	// While calculating, this is the latest (possibly coarse) pass
 	m.gridBuffer.Reset()
 	grid := m.mandelbrotSet.GetGrid()
 	maxIter := m.mandelbrotSet.GetMaxIterations()
@@ -258,7 +511,7 @@ func (m Model) RenderGrid() string {
 				color := m.renderOptions.GetColorForIteration(iter, maxIter)
 
 				// Create styled character
-				style := lipgloss.NewStyle().Foreground(color)
+				style := lipgloss.NewStyle().Foreground(pkg.AdaptColor(string(color)))
 				m.gridBuffer.WriteString(style.Render(char))
 			} else {
 				m.gridBuffer.WriteString(" ")
@@ -272,35 +525,6 @@ func (m Model) RenderGrid() string {
 	return m.gridBuffer.String()
 }
 
-// renderCalculatingMessage renders the calculating message
-func (m Model) renderCalculatingMessage() string {
-	var msg string
-	if m.language == Chinese {
-		msg = "正在计算分形图案..."
-	} else {
-		msg = "Calculating fractal pattern..."
-	}
-
-	// Center the message in the grid area
-	lines := make([]string, m.gridHeight)
-	midRow := m.gridHeight / 2
-
-	for i := range lines {
-		if i == midRow {
-			padding := (m.width - len(msg)) / 2
-			if padding > 0 {
-				lines[i] = strings.Repeat(" ", padding) + msg
-			} else {
-				lines[i] = msg
-			}
-		} else {
-			lines[i] = strings.Repeat(" ", m.width)
-		}
-	}
-
-	return strings.Join(lines, "\n")
-}
-
 // getCurrentPresetInfo returns information about the current preset
 func (m Model) getCurrentPresetInfo() string {
 	presets := m.mandelbrotSet.GetInterestingPoints()
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runRender feeds render pass messages back into the model until the
+// progressive render finishes, returning the number of passes shown
+func runRender(t *testing.T, m Model, cmd tea.Cmd) (Model, int) {
+	t.Helper()
+	passes := 0
+	for cmd != nil {
+		msg, ok := cmd().(renderPassMsg)
+		if !ok {
+			t.Fatal("Expected a render pass message")
+		}
+		passes++
+		model, next := m.Update(msg)
+		m, cmd = model.(Model), next
+	}
+	return m, passes
+}
+
+// Test that a view change renders progressively to the full-resolution grid
+func TestModel_ProgressiveRender(t *testing.T) {
+	model, _ := NewModel(DefaultConfig).Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	model, cmd := model.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m := model.(Model)
+	if !m.calculating {
+		t.Fatal("Expected calculating after a view change")
+	}
+
+	m, passes := runRender(t, m, cmd)
+	if passes != len(ProgressiveStrides) {
+		t.Errorf("Expected %d passes, got %d", len(ProgressiveStrides), passes)
+	}
+	if m.calculating {
+		t.Error("Expected calculating to be cleared after the last pass")
+	}
+	if m.renderTime <= 0 || m.renderRate <= 0 {
+		t.Errorf("Expected render timing after the last pass, got %v and %v", m.renderTime, m.renderRate)
+	}
+
+	expected := m.mandelbrotSet.Snapshot()
+	expected.calculateSerial(expected.viewport())
+	grid := m.mandelbrotSet.GetGrid()
+	for y := range expected.grid {
+		for x := range expected.grid[y] {
+			if grid[y][x] != expected.grid[y][x] {
+				t.Fatalf("cell (%d,%d) is %d, expected %d", y, x, grid[y][x], expected.grid[y][x])
+			}
+		}
+	}
+}
+
+// Test that a view change discards passes of the render it supersedes
+func TestModel_ProgressiveRenderCancelled(t *testing.T) {
+	model, stale := NewModel(DefaultConfig).Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	model, cmd := model.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m := model.(Model)
+
+	model, next := m.Update(stale())
+	if next != nil {
+		t.Error("A superseded pass should not start another pass")
+	}
+	m = model.(Model)
+	if !m.calculating {
+		t.Error("A superseded pass should not finish the current render")
+	}
+
+	if m, _ = runRender(t, m, cmd); m.calculating {
+		t.Error("Expected the current render to finish")
+	}
+}
+
+// Test that panning a finished view shifts the grid instead of rendering
+func TestModel_PanShiftsFinishedGrid(t *testing.T) {
+	model, cmd := NewModel(DefaultConfig).Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	m, _ := runRender(t, model.(Model), cmd)
+
+	model, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = model.(Model)
+	if cmd != nil || m.calculating {
+		t.Error("Expected a pan of a finished view to need no render")
+	}
+
+	// While a render is in flight the grid is not final, so panning renders again
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	model, cmd = model.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = model.(Model)
+	if cmd == nil || !m.calculating {
+		t.Error("Expected a pan during a render to start a new render")
+	}
+}
+
+// typeKeys sends each rune of s followed by the given special keys
+func typeKeys(m Model, s string, keys ...tea.KeyType) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, r := range s {
+		var model tea.Model
+		model, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = model.(Model)
+	}
+	for _, k := range keys {
+		var model tea.Model
+		model, cmd = m.Update(tea.KeyMsg{Type: k})
+		m = model.(Model)
+	}
+	return m, cmd
+}
+
+// Test typing a location, rejecting bad input and cancelling with esc
+func TestModel_LocationEntry(t *testing.T) {
+	model, _ := NewModel(DefaultConfig).Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	m := model.(Model)
+
+	m, _ = typeKeys(m, ":-0.75,0.1x,50")
+	if !m.enteringLocation || m.locationInput != "-0.75,0.1,50" {
+		t.Fatalf("Expected input %q while entering, got %q (entering %v)", "-0.75,0.1,50", m.locationInput, m.enteringLocation)
+	}
+
+	m, cmd := typeKeys(m, "", tea.KeyEnter)
+	if m.enteringLocation {
+		t.Error("Expected enter to leave coordinate entry")
+	}
+	if x, y := m.mandelbrotSet.GetCenter(); x != -0.75 || y != 0.1 || m.mandelbrotSet.GetZoom() != 50 {
+		t.Errorf("Expected center (-0.75, 0.1) at zoom 50, got (%v, %v) at zoom %v", x, y, m.mandelbrotSet.GetZoom())
+	}
+	if !m.calculating || cmd == nil {
+		t.Error("Expected a render after jumping to the location")
+	}
+	m, _ = runRender(t, m, cmd)
+
+	m, cmd = typeKeys(m, ":1,2,0", tea.KeyEnter)
+	if cmd != nil || m.invalidLocation != "1,2,0" {
+		t.Errorf("Expected %q to be rejected, got invalid input %q", "1,2,0", m.invalidLocation)
+	}
+	if m.mandelbrotSet.GetZoom() != 50 {
+		t.Errorf("Expected a rejected location to keep zoom 50, got %v", m.mandelbrotSet.GetZoom())
+	}
+
+	// The next key clears the message, and esc cancels without quitting
+	m, cmd = typeKeys(m, ":3,4", tea.KeyBackspace, tea.KeyEscape)
+	if m.invalidLocation != "" || m.enteringLocation || cmd != nil {
+		t.Errorf("Expected esc to cancel entry, got entering %v, invalid %q", m.enteringLocation, m.invalidLocation)
+	}
+	if x, _ := m.mandelbrotSet.GetCenter(); x != -0.75 {
+		t.Errorf("Expected a cancelled entry to keep the center, got x = %v", x)
+	}
+}
+
+// Test that Julia morphing ticks recalculate only in Julia mode and stop once
+// toggled off
+func TestModel_JuliaAnimation(t *testing.T) {
+	model, _ := NewModel(DefaultConfig).Update(tea.WindowSizeMsg{Width: 60, Height: 30})
+	model, cmd := model.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	m, _ := runRender(t, model.(Model), cmd)
+	if m.mandelbrotSet.GetCurrentMode() != FractalJulia {
+		t.Fatal("Expected M to switch to the Julia set")
+	}
+	start := m.mandelbrotSet.GetJuliaParameter()
+
+	model, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = model.(Model)
+	if enabled, _ := m.mandelbrotSet.GetJuliaAnimation(); !enabled || cmd == nil {
+		t.Fatal("Expected J to start morphing")
+	}
+
+	// A tick moves the parameter and starts a render
+	model, _ = m.Update(juliaTickMsg{gen: m.juliaGen})
+	m = model.(Model)
+	if m.mandelbrotSet.GetJuliaParameter() == start || !m.calculating {
+		t.Error("Expected a tick to move the parameter and recalculate")
+	}
+
+	// Ticks from before a toggle are dropped
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = model.(Model)
+	param := m.mandelbrotSet.GetJuliaParameter()
+	if _, cmd = m.Update(juliaTickMsg{gen: m.juliaGen - 1}); cmd != nil {
+		t.Error("Expected a stale tick to end the tick chain")
+	}
+	if _, cmd = m.Update(juliaTickMsg{gen: m.juliaGen}); cmd != nil || m.mandelbrotSet.GetJuliaParameter() != param {
+		t.Error("Expected no morphing after J stops it")
+	}
+}
@@ -0,0 +1,136 @@
+package pkg
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ColorProfile is the range of colors a terminal can display
+type ColorProfile int32
+
+// Color profiles, from most to fewest colors
+const (
+	ProfileTrueColor ColorProfile = iota // 24-bit colors, used as they are
+	ProfileANSI256                       // The xterm 256-color palette
+	ProfileANSI16                        // The 16 basic ANSI colors
+)
+
+// colorProfile is the profile AdaptColor converts to. Renders may run on
+// background goroutines, so it is read atomically.
+var colorProfile atomic.Int32
+
+// cubeLevels are the channel values of the 6×6×6 color cube of the 256-color
+// palette, which starts at index 16
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// ansi16 are the xterm default RGB values of the 16 basic colors
+var ansi16 = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// DetectColorProfile guesses the color profile of the terminal from its
+// environment, read with getenv (usually os.Getenv). COLORTERM announces
+// truecolor; otherwise TERM tells 256-color terminals from basic ones. An
+// unset TERM gives no hint, so colors are left to lipgloss.
+func DetectColorProfile(getenv func(string) string) ColorProfile {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ProfileTrueColor
+	}
+
+	term := strings.ToLower(getenv("TERM"))
+	switch {
+	case term == "" || strings.Contains(term, "direct"):
+		return ProfileTrueColor
+	case strings.Contains(term, "256color"):
+		return ProfileANSI256
+	default:
+		return ProfileANSI16
+	}
+}
+
+// SetColorProfile sets the profile AdaptColor converts colors to. Programs
+// set it once at startup; tests use it to force a profile.
+func SetColorProfile(profile ColorProfile) {
+	colorProfile.Store(int32(profile))
+}
+
+// GetColorProfile returns the profile AdaptColor converts colors to
+func GetColorProfile() ColorProfile {
+	return ColorProfile(colorProfile.Load())
+}
+
+// AdaptColor returns the #RRGGBB color as the current color profile shows
+// it: unchanged for truecolor, otherwise the index of the nearest palette
+// color. Invalid colors are returned unchanged.
+func AdaptColor(color string) lipgloss.Color {
+	rgb, ok := HexToRGB(color)
+	if !ok {
+		return lipgloss.Color(color)
+	}
+	switch GetColorProfile() {
+	case ProfileANSI256:
+		return lipgloss.Color(strconv.Itoa(ANSI256Index(rgb)))
+	case ProfileANSI16:
+		return lipgloss.Color(strconv.Itoa(ANSI16Index(rgb)))
+	default:
+		return lipgloss.Color(color)
+	}
+}
+
+// ANSI256Index returns the index of the 256-color palette entry nearest to
+// rgb, from the color cube or the grayscale ramp. The first 16 entries vary
+// between terminal themes and are never chosen.
+func ANSI256Index(rgb [3]byte) int {
+	var cube [3]int
+	var cubeIndex int
+	for i, c := range rgb {
+		cube[i] = cubeLevels[nearestCubeLevel(int(c))]
+		cubeIndex = cubeIndex*6 + nearestCubeLevel(int(c))
+	}
+
+	// The ramp runs from 8 to 238 in steps of 10, at indexes 232-255
+	gray := (int(rgb[0]) + int(rgb[1]) + int(rgb[2])) / 3
+	step := max(min((gray-8+5)/10, 23), 0)
+	level := 8 + step*10
+
+	if distance(rgb, [3]int{level, level, level}) < distance(rgb, cube) {
+		return 232 + step
+	}
+	return 16 + cubeIndex
+}
+
+// ANSI16Index returns the index of the basic ANSI color nearest to rgb,
+// assuming the xterm default palette
+func ANSI16Index(rgb [3]byte) int {
+	best := 0
+	for i, c := range ansi16 {
+		if distance(rgb, c) < distance(rgb, ansi16[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// nearestCubeLevel returns the index of the cube level nearest to c
+func nearestCubeLevel(c int) int {
+	if c < 48 {
+		return 0
+	}
+	if c < 115 {
+		return 1
+	}
+	return (c - 35) / 40
+}
+
+// distance returns the squared distance between two RGB colors
+func distance(rgb [3]byte, c [3]int) int {
+	dr, dg, db := int(rgb[0])-c[0], int(rgb[1])-c[1], int(rgb[2])-c[2]
+	return dr*dr + dg*dg + db*db
+}
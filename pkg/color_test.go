@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestANSI256Index(t *testing.T) {
+	tests := []struct {
+		color string
+		want  int
+	}{
+		{"#000000", 16},  // Cube black
+		{"#FFFFFF", 231}, // Cube white
+		{"#FF0000", 196},
+		{"#5F87AF", 67},  // Exactly on the cube
+		{"#808080", 244}, // Exactly on the grayscale ramp
+		{"#7F0000", 88},
+		{"#121212", 233},
+	}
+	for _, tt := range tests {
+		rgb, _ := HexToRGB(tt.color)
+		if got := ANSI256Index(rgb); got != tt.want {
+			t.Errorf("ANSI256Index(%s) = %d, want %d", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestANSI16Index(t *testing.T) {
+	tests := []struct {
+		color string
+		want  int
+	}{
+		{"#000000", 0},
+		{"#CD0000", 1},
+		{"#FF0000", 9},
+		{"#0000F0", 4},
+		{"#00FF00", 10},
+		{"#808080", 8},
+		{"#FFFFFF", 15},
+	}
+	for _, tt := range tests {
+		rgb, _ := HexToRGB(tt.color)
+		if got := ANSI16Index(rgb); got != tt.want {
+			t.Errorf("ANSI16Index(%s) = %d, want %d", tt.color, got, tt.want)
+		}
+	}
+}
+
+// Test that colors follow the forced profile
+func TestAdaptColor(t *testing.T) {
+	defer SetColorProfile(GetColorProfile())
+
+	SetColorProfile(ProfileTrueColor)
+	if got := AdaptColor("#FF8800"); got != lipgloss.Color("#FF8800") {
+		t.Errorf("Expected truecolor to keep the color, got %s", got)
+	}
+	SetColorProfile(ProfileANSI256)
+	if got := AdaptColor("#FF8700"); got != lipgloss.Color("208") {
+		t.Errorf("Expected 256-color index 208, got %s", got)
+	}
+	SetColorProfile(ProfileANSI16)
+	if got := AdaptColor("#00FF00"); got != lipgloss.Color("10") {
+		t.Errorf("Expected 16-color index 10, got %s", got)
+	}
+	if got := AdaptColor("green"); got != lipgloss.Color("green") {
+		t.Errorf("Expected an invalid color to be kept, got %s", got)
+	}
+}
+
+func TestDetectColorProfile(t *testing.T) {
+	tests := []struct {
+		colorTerm, term string
+		want            ColorProfile
+	}{
+		{"truecolor", "xterm-256color", ProfileTrueColor},
+		{"24bit", "screen", ProfileTrueColor},
+		{"", "xterm-256color", ProfileANSI256},
+		{"", "screen-256color", ProfileANSI256},
+		{"", "xterm-direct", ProfileTrueColor},
+		{"", "xterm", ProfileANSI16},
+		{"", "linux", ProfileANSI16},
+		{"", "", ProfileTrueColor},
+	}
+	for _, tt := range tests {
+		env := map[string]string{"COLORTERM": tt.colorTerm, "TERM": tt.term}
+		got := DetectColorProfile(func(key string) string { return env[key] })
+		if got != tt.want {
+			t.Errorf("COLORTERM=%q TERM=%q: got profile %d, want %d", tt.colorTerm, tt.term, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package pkg
+
+// Describable is implemented by simulations that can report their current
+// state as structured data, so tests and headless runs can inspect it
+// without parsing the rendered view.
+type Describable interface {
+	// Describe returns a snapshot of the current state, keyed by field name
+	// (e.g. "generation", "population", "rule")
+	Describe() map[string]any
+}
@@ -0,0 +1,58 @@
+// Package golden compares rendered simulation frames against recorded golden
+// files, to catch visual regressions in tests. It is a separate package so the
+// -update flag it registers only exists in test binaries that import it.
+package golden
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate golden files instead of comparing against them")
+
+// Engine is a deterministic simulation whose rendered frames are compared
+type Engine interface {
+	// Step advances the simulation by one tick
+	Step()
+	// View returns the rendered current frame
+	View() string
+}
+
+// AssertGoldenFrames compares the first frames views of engine, starting with
+// the initial one and stepping once between frames, against frame_N.golden in
+// goldenDir. Run the test with -update to write the golden files instead.
+func AssertGoldenFrames(t testing.TB, engine Engine, frames int, goldenDir string) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(goldenDir, 0o750); err != nil {
+			t.Fatalf("Failed to create golden directory: %v", err)
+		}
+	}
+
+	for i := range frames {
+		if i > 0 {
+			engine.Step()
+		}
+		got := engine.View()
+		path := filepath.Join(goldenDir, fmt.Sprintf("frame_%d.golden", i))
+
+		if *update {
+			if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+				t.Fatalf("Failed to write golden file: %v", err)
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(path) //nolint:gosec
+		if err != nil {
+			t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+		}
+		if got != string(want) {
+			t.Errorf("Frame %d differs from %s\ngot:\n%s\nwant:\n%s", i, path, got, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package golden
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// counter renders the number of steps taken
+type counter struct{ steps int }
+
+func (c *counter) Step()        { c.steps++ }
+func (c *counter) View() string { return strconv.Itoa(c.steps) + "\n" }
+
+// recorder captures failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Errorf(string, ...any) { r.failed = true }
+
+func TestAssertGoldenFrames(t *testing.T) {
+	dir := t.TempDir()
+	for i, view := range []string{"0\n", "1\n", "2\n"} {
+		if err := os.WriteFile(filepath.Join(dir, "frame_"+strconv.Itoa(i)+".golden"), []byte(view), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	AssertGoldenFrames(t, &counter{}, 3, dir)
+
+	// A different frame is reported
+	r := &recorder{TB: t}
+	AssertGoldenFrames(r, &counter{steps: 1}, 1, dir)
+	if !r.failed {
+		t.Error("Expected a mismatching frame to be reported")
+	}
+}
+
+func TestAssertGoldenFramesUpdate(t *testing.T) {
+	*update = true
+	defer func() { *update = false }()
+
+	dir := filepath.Join(t.TempDir(), "frames")
+	AssertGoldenFrames(t, &counter{}, 2, dir)
+
+	got, err := os.ReadFile(filepath.Join(dir, "frame_1.golden"))
+	if err != nil || string(got) != "1\n" {
+		t.Errorf("Expected frame_1.golden to hold %q, got %q (%v)", "1\n", got, err)
+	}
+}
@@ -0,0 +1,133 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// ANSI colors used by the console handler for each level
+const (
+	colorReset  = "\033[0m"
+	colorGray   = "\033[90m"
+	colorBlue   = "\033[34m"
+	colorYellow = "\033[33m"
+	colorRed    = "\033[31m"
+)
+
+// MultiHandler fans each record out to several slog handlers.
+type MultiHandler struct {
+	handlers []slog.Handler
+}
+
+// NewMultiHandler creates a handler that forwards to all of handlers.
+func NewMultiHandler(handlers ...slog.Handler) *MultiHandler {
+	return &MultiHandler{handlers: handlers}
+}
+
+// Enabled reports whether any child handler is enabled for level.
+func (h *MultiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, child := range h.handlers {
+		if child.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes the record to every child handler enabled for its level.
+func (h *MultiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, child := range h.handlers {
+		if child.Enabled(ctx, r.Level) {
+			if err := child.Handle(ctx, r.Clone()); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a MultiHandler whose children all carry attrs.
+func (h *MultiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, child := range h.handlers {
+		handlers[i] = child.WithAttrs(attrs)
+	}
+	return &MultiHandler{handlers: handlers}
+}
+
+// WithGroup returns a MultiHandler whose children all open the group name.
+func (h *MultiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, child := range h.handlers {
+		handlers[i] = child.WithGroup(name)
+	}
+	return &MultiHandler{handlers: handlers}
+}
+
+// consoleHandler writes a colored level prefix followed by the record in
+// text format, for reading in a terminal.
+type consoleHandler struct {
+	mu    *sync.Mutex
+	w     io.Writer
+	inner slog.Handler
+}
+
+// NewConsoleHandler creates a text handler that prints a colored level.
+func NewConsoleHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	textOpts := *opts
+	textOpts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		// The level is printed as the colored prefix instead
+		if len(groups) == 0 && a.Key == slog.LevelKey {
+			return slog.Attr{}
+		}
+		if opts.ReplaceAttr != nil {
+			return opts.ReplaceAttr(groups, a)
+		}
+		return a
+	}
+	return &consoleHandler{mu: &sync.Mutex{}, w: w, inner: slog.NewTextHandler(w, &textOpts)}
+}
+
+// Enabled reports whether the text handler is enabled for level.
+func (h *consoleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+// Handle writes the colored level and then the text record.
+func (h *consoleHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, err := fmt.Fprintf(h.w, "%s%-5s%s ", levelColor(r.Level), r.Level, colorReset); err != nil {
+		return err
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+// WithAttrs returns a console handler whose records carry attrs.
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &consoleHandler{mu: h.mu, w: h.w, inner: h.inner.WithAttrs(attrs)}
+}
+
+// WithGroup returns a console handler that opens the group name.
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	return &consoleHandler{mu: h.mu, w: h.w, inner: h.inner.WithGroup(name)}
+}
+
+// levelColor returns the ANSI color for a log level
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return colorRed
+	case level >= slog.LevelWarn:
+		return colorYellow
+	case level >= slog.LevelInfo:
+		return colorBlue
+	default:
+		return colorGray
+	}
+}
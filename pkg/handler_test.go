@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// Test that every child handler receives a logged record
+func TestMultiHandler(t *testing.T) {
+	var jsonBuf, textBuf bytes.Buffer
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	logger := slog.New(NewMultiHandler(
+		slog.NewJSONHandler(&jsonBuf, opts),
+		NewConsoleHandler(&textBuf, opts),
+	))
+
+	logger.With("module", "ui").WithGroup("grid").Info("resized", "width", 80)
+
+	var record map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %q: %v", jsonBuf.String(), err)
+	}
+	if record["msg"] != "resized" || record["module"] != "ui" {
+		t.Errorf("Unexpected JSON record %v", record)
+	}
+	if grid, ok := record["grid"].(map[string]any); !ok || grid["width"] != float64(80) {
+		t.Errorf("Expected grid.width in JSON record, got %v", record["grid"])
+	}
+
+	text := textBuf.String()
+	for _, want := range []string{"msg=resized", "module=ui", "grid.width=80", colorBlue + "INFO " + colorReset} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected console output to contain %q, got %q", want, text)
+		}
+	}
+}
+
+// Test that Enabled is true when any child is enabled
+func TestMultiHandlerEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewMultiHandler(
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}),
+		slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}),
+	)
+	if !h.Enabled(t.Context(), slog.LevelInfo) {
+		t.Error("Expected info to be enabled")
+	}
+	if h.Enabled(t.Context(), slog.LevelDebug) {
+		t.Error("Expected debug to be disabled")
+	}
+
+	slog.New(h).Info("only once")
+	if count := strings.Count(buf.String(), "only once"); count != 1 {
+		t.Errorf("Expected the record once, got %d", count)
+	}
+}
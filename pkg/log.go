@@ -2,7 +2,7 @@
 package pkg
 
 import (
-	"fmt"
+	"errors"
 	"io"
 	"log/slog"
 	"os"
@@ -10,7 +10,15 @@ import (
 )
 
 // InitLog initializes the logging system with the provided configuration.
-func InitLog(level string, format string, file string) error {
+// Format is "text", "json" or "dual"; "dual" writes JSON to file and
+// colored text to stderr at the same time. The returned logger is also installed as the slog default.
+func InitLog(level string, format string, file string) (*slog.Logger, error) {
+	return InitLogWithRotation(level, format, file, RotateOptions{})
+}
+
+// InitLogWithRotation is like InitLog but rotates the log file by size.
+// Rotation only applies when logging to a file.
+func InitLogWithRotation(level string, format string, file string, rotate RotateOptions) (*slog.Logger, error) {
 	opts := &slog.HandlerOptions{
 		Level: slog.LevelInfo, // Default log level
 	}
@@ -29,21 +37,29 @@ func InitLog(level string, format string, file string) error {
 		opts.Level = slog.LevelInfo
 	}
 
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "dual" && file == "" {
+		return nil, errors.New("dual log format requires a log file")
+	}
+
 	var w io.Writer
 	var err error
 
 	if file == "" {
 		w = os.Stdout
 	} else {
-		w, err = os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644) //nolint:gosec
+		w, err = NewRotatingWriter(file, rotate)
 		if err != nil {
-			return fmt.Errorf("failed to open log file: %w", err)
+			return nil, err
 		}
 	}
 
 	var logger *slog.Logger
 	// Configure log format
-	switch strings.ToLower(strings.TrimSpace(format)) {
+	switch format {
+	case "dual":
+		h := NewMultiHandler(slog.NewJSONHandler(w, opts), NewConsoleHandler(os.Stderr, opts))
+		logger = slog.New(h)
 	case "json":
 		h := slog.NewJSONHandler(w, opts)
 		logger = slog.New(h)
@@ -56,5 +72,5 @@ func InitLog(level string, format string, file string) error {
 	}
 
 	slog.SetDefault(logger)
-	return nil
+	return logger, nil
 }
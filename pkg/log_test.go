@@ -0,0 +1,42 @@
+package pkg
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test that InitLog returns the logger it installs as the default
+func TestInitLogReturnsLogger(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := InitLog("info", "json", path)
+	if err != nil {
+		t.Fatalf("InitLog failed: %v", err)
+	}
+	if logger == nil {
+		t.Fatal("Expected a logger, got nil")
+	}
+	if slog.Default() != logger {
+		t.Error("Expected the returned logger to be the default")
+	}
+
+	logger.Info("hello", "key", "value")
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), `"msg":"hello"`) {
+		t.Errorf("Expected a JSON record in the log file, got %q", data)
+	}
+}
+
+// Test that the dual format needs a file
+func TestInitLogDualRequiresFile(t *testing.T) {
+	if _, err := InitLog("info", "dual", ""); err == nil {
+		t.Error("Expected error for dual format without a file")
+	}
+}
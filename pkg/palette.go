@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Color roles shared by the simulations
+const (
+	RoleAlive  = "alive"  // Live cells
+	RoleDead   = "dead"   // Dead or empty cells
+	RoleTrail  = "trail"  // Fading trails left behind moving cells
+	RoleCursor = "cursor" // The edit cursor
+)
+
+// Palette maps named color roles to terminal colors, so simulations parse and
+// validate hex colors in one place. Each role keeps a cached foreground style
+// in the current color profile; see AdaptColor.
+type Palette struct {
+	colors map[string]lipgloss.Color
+	styles map[string]lipgloss.Style
+}
+
+// NewPalette creates an empty palette
+func NewPalette() *Palette {
+	return &Palette{
+		colors: make(map[string]lipgloss.Color),
+		styles: make(map[string]lipgloss.Style),
+	}
+}
+
+// Set assigns a #RRGGBB color to role. An invalid color is replaced by
+// fallback and reported as an error, so callers can warn and carry on.
+func (p *Palette) Set(role, color, fallback string) error {
+	var err error
+	if !IsValidHexColor(color) {
+		err = fmt.Errorf("invalid %s color %q, using %s", role, color, fallback)
+		color = fallback
+	}
+	p.colors[role] = lipgloss.Color(color)
+	p.styles[role] = lipgloss.NewStyle().Foreground(AdaptColor(color))
+	return err
+}
+
+// Color returns the color of role, or an empty color if it is unset
+func (p *Palette) Color(role string) lipgloss.Color {
+	return p.colors[role]
+}
+
+// Style returns the cached foreground style of role, or a plain style if it
+// is unset
+func (p *Palette) Style(role string) lipgloss.Style {
+	if style, ok := p.styles[role]; ok {
+		return style
+	}
+	return lipgloss.NewStyle()
+}
+
+// RGB returns the components of the color of role, black if it is unset
+func (p *Palette) RGB(role string) [3]byte {
+	rgb, _ := HexToRGB(string(p.colors[role]))
+	return rgb
+}
+
+// IsValidHexColor checks if a string is a #RRGGBB hex color
+func IsValidHexColor(color string) bool {
+	if len(color) != 7 || color[0] != '#' {
+		return false
+	}
+	for _, c := range color[1:] {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'F') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// HexToRGB converts a #RRGGBB hex color to its components. It returns black
+// and false if the color is invalid.
+func HexToRGB(color string) ([3]byte, bool) {
+	if !IsValidHexColor(color) {
+		return [3]byte{}, false
+	}
+	v, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return [3]byte{}, false
+	}
+	return [3]byte{byte(v >> 16), byte(v >> 8), byte(v)}, true
+}
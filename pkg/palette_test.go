@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Test that the palette validates colors and falls back on invalid ones
+func TestPalette(t *testing.T) {
+	p := NewPalette()
+	if err := p.Set(RoleAlive, "#00ff00", "#FFFFFF"); err != nil {
+		t.Fatalf("Expected a valid color, got %v", err)
+	}
+	if err := p.Set(RoleDead, "black", "#000000"); err == nil {
+		t.Error("Expected an error for an invalid color")
+	}
+
+	if got := p.Color(RoleAlive); got != lipgloss.Color("#00ff00") {
+		t.Errorf("Expected alive color #00ff00, got %s", got)
+	}
+	if got := p.Color(RoleDead); got != lipgloss.Color("#000000") {
+		t.Errorf("Expected the fallback dead color, got %s", got)
+	}
+	if got := p.Color(RoleTrail); got != "" {
+		t.Errorf("Expected an unset role to have no color, got %s", got)
+	}
+	if got := p.RGB(RoleAlive); got != [3]byte{0, 0xFF, 0} {
+		t.Errorf("Expected alive RGB 0,255,0, got %v", got)
+	}
+
+	if got := p.Style(RoleAlive).GetForeground(); got != lipgloss.Color("#00ff00") {
+		t.Errorf("Expected the alive style foreground to be #00ff00, got %v", got)
+	}
+	if got := p.Style(RoleCursor).Render("x"); got != "x" {
+		t.Errorf("Expected an unset role to render plainly, got %q", got)
+	}
+}
+
+func TestHexToRGB(t *testing.T) {
+	tests := []struct {
+		color string
+		want  [3]byte
+		ok    bool
+	}{
+		{"#FF8800", [3]byte{0xFF, 0x88, 0x00}, true},
+		{"#abcdef", [3]byte{0xAB, 0xCD, 0xEF}, true},
+		{"FF8800", [3]byte{}, false},
+		{"#FF88", [3]byte{}, false},
+		{"#GG8800", [3]byte{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := HexToRGB(tt.color)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("HexToRGB(%q) = %v, %v; want %v, %v", tt.color, got, ok, tt.want, tt.ok)
+		}
+		if IsValidHexColor(tt.color) != tt.ok {
+			t.Errorf("IsValidHexColor(%q) = %v, want %v", tt.color, !tt.ok, tt.ok)
+		}
+	}
+}
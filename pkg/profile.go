@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,10 +11,56 @@ import (
 	"time"
 )
 
-// StartProfile starts a pprof server and handles graceful shutdown
+// RuntimeStats is a snapshot of the runtime statistics the watchdog logs
+type RuntimeStats struct {
+	Goroutines   int    `json:"goroutines"`
+	AllocMB      uint64 `json:"alloc_mb"`
+	HeapAlloc    uint64 `json:"heap_alloc_bytes"`
+	TotalAllocMB uint64 `json:"total_alloc_mb"`
+	SysMB        uint64 `json:"sys_mb"`
+	NumGC        uint32 `json:"num_gc"`
+	NextGCMB     uint64 `json:"next_gc_mb"`
+}
+
+// ReadRuntimeStats collects the current runtime statistics
+func ReadRuntimeStats() RuntimeStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	return RuntimeStats{
+		Goroutines:   runtime.NumGoroutine(),
+		AllocMB:      bToMb(m.Alloc),
+		HeapAlloc:    m.HeapAlloc,
+		TotalAllocMB: bToMb(m.TotalAlloc),
+		SysMB:        bToMb(m.Sys),
+		NumGC:        m.NumGC,
+		NextGCMB:     bToMb(m.NextGC),
+	}
+}
+
+// newProfileMux serves /metrics and falls back to the default mux, where
+// net/http/pprof registers its handlers
+func newProfileMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", handleMetrics)
+	mux.Handle("/", http.DefaultServeMux)
+	return mux
+}
+
+// handleMetrics writes the current runtime statistics as JSON
+func handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(ReadRuntimeStats()); err != nil {
+		slog.Error("Failed to encode runtime stats", "error", err)
+	}
+}
+
+// StartProfile starts a pprof server, with runtime statistics as JSON at
+// /metrics, and handles graceful shutdown
 func StartProfile(ctx context.Context, port int) {
 	server := &http.Server{ //nolint:gosec
-		Addr: fmt.Sprintf(":%d", port),
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: newProfileMux(),
 	}
 
 	go func() {
@@ -39,38 +86,96 @@ func StartProfile(ctx context.Context, port int) {
 	}
 }
 
+// DefaultGrowthIntervals is how many consecutive intervals of growth make
+// the watchdog suspect a leak
+const DefaultGrowthIntervals = 5
+
+// WatchdogOptions configures the leak-suspicion checks of the watchdog.
+// A zero interval count disables the corresponding check.
+type WatchdogOptions struct {
+	HeapGrowthIntervals      int // Warn when HeapAlloc grows this many intervals in a row
+	GoroutineGrowthIntervals int // Warn when the goroutine count grows this many intervals in a row
+}
+
+// DefaultWatchdogOptions are the options used by StartWatchdog
+var DefaultWatchdogOptions = WatchdogOptions{
+	HeapGrowthIntervals:      DefaultGrowthIntervals,
+	GoroutineGrowthIntervals: DefaultGrowthIntervals,
+}
+
 // StartWatchdog periodically prints runtime profile information
 func StartWatchdog(ctx context.Context, interval time.Duration) {
+	StartWatchdogWithOptions(ctx, interval, DefaultWatchdogOptions)
+}
+
+// StartWatchdogWithOptions is like StartWatchdog but also warns when heap
+// usage or the goroutine count keeps growing, as configured by opts
+func StartWatchdogWithOptions(ctx context.Context, interval time.Duration, opts WatchdogOptions) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	slog.Info("Starting watchdog with interval", "interval", interval)
 
+	heap := growthTracker{limit: opts.HeapGrowthIntervals}
+	goroutines := growthTracker{limit: opts.GoroutineGrowthIntervals}
+	logger := slog.With("module", "watchdog")
+
 	for {
 		select {
 		case <-ctx.Done():
 			slog.Info("Stopping watchdog")
 			return
 		case <-ticker.C:
-			printRuntimeStats()
+			stats := printRuntimeStats()
+			if heap.observe(stats.HeapAlloc) {
+				logger.Warn("Suspected memory leak: heap keeps growing",
+					"heap_alloc_bytes", stats.HeapAlloc,
+					"intervals", heap.streak)
+			}
+			if goroutines.observe(uint64(stats.Goroutines)) { //nolint:gosec // goroutine count is never negative
+				logger.Warn("Suspected goroutine leak: goroutine count keeps growing",
+					"goroutines", stats.Goroutines,
+					"intervals", goroutines.streak)
+			}
 		}
 	}
 }
 
-// printRuntimeStats prints current runtime statistics
-func printRuntimeStats() {
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
+// growthTracker counts consecutive intervals in which a value grew
+type growthTracker struct {
+	limit  int // Streak length that triggers a warning, 0 disables
+	streak int
+	prev   uint64
+	seen   bool
+}
+
+// observe records the value for this interval and reports whether it has
+// now grown for at least limit intervals in a row
+func (g *growthTracker) observe(value uint64) bool {
+	if g.seen && value > g.prev {
+		g.streak++
+	} else {
+		g.streak = 0
+	}
+	g.prev = value
+	g.seen = true
+	return g.limit > 0 && g.streak >= g.limit
+}
+
+// printRuntimeStats prints current runtime statistics and returns them
+func printRuntimeStats() RuntimeStats {
+	stats := ReadRuntimeStats()
 
 	logger := slog.With("module", "watchdog")
 	logger.Info("Runtime Stats",
-		"goroutines", runtime.NumGoroutine(),
-		"alloc_mb", bToMb(m.Alloc),
-		"total_alloc_mb", bToMb(m.TotalAlloc),
-		"sys_mb", bToMb(m.Sys),
-		"num_gc", m.NumGC,
-		"next_gc_mb", bToMb(m.NextGC),
+		"goroutines", stats.Goroutines,
+		"alloc_mb", stats.AllocMB,
+		"total_alloc_mb", stats.TotalAllocMB,
+		"sys_mb", stats.SysMB,
+		"num_gc", stats.NumGC,
+		"next_gc_mb", stats.NextGCMB,
 	)
+	return stats
 }
 
 // bToMb converts bytes to megabytes
@@ -0,0 +1,134 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test that /metrics serves the runtime statistics as JSON
+func TestMetricsEndpoint(t *testing.T) {
+	server := httptest.NewServer(newProfileMux())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", ct)
+	}
+
+	var stats RuntimeStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		t.Fatalf("Failed to decode metrics: %v", err)
+	}
+	if stats.Goroutines < 1 {
+		t.Errorf("Expected at least one goroutine, got %d", stats.Goroutines)
+	}
+	if stats.SysMB == 0 {
+		t.Error("Expected non-zero sys_mb")
+	}
+}
+
+// Test that pprof is still served alongside /metrics
+func TestPprofEndpoint(t *testing.T) {
+	server := httptest.NewServer(newProfileMux())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/debug/pprof/")
+	if err != nil {
+		t.Fatalf("GET /debug/pprof/ failed: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+// Test that a warning fires only after enough consecutive growth
+func TestGrowthTracker(t *testing.T) {
+	g := growthTracker{limit: 3}
+	values := []uint64{10, 11, 12, 12, 13, 14, 15, 16}
+	expected := []bool{false, false, false, false, false, false, true, true}
+	for i, v := range values {
+		if got := g.observe(v); got != expected[i] {
+			t.Errorf("observe(%d) at step %d: expected %v, got %v", v, i, expected[i], got)
+		}
+	}
+
+	disabled := growthTracker{}
+	for v := uint64(0); v < 10; v++ {
+		if disabled.observe(v) {
+			t.Fatal("Expected no warning with a zero limit")
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use by a logger
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// Test that the watchdog warns while goroutines and heap keep growing
+func TestWatchdogWarnsOnGrowth(t *testing.T) {
+	var logs syncBuffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		StartWatchdogWithOptions(ctx, 20*time.Millisecond, WatchdogOptions{
+			HeapGrowthIntervals:      3,
+			GoroutineGrowthIntervals: 3,
+		})
+		close(done)
+	}()
+
+	// Leak goroutines and retained memory faster than the watchdog samples
+	release := make(chan struct{})
+	var retained [][]byte
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && !strings.Contains(logs.String(), "Suspected goroutine leak") {
+		go func() { <-release }()
+		retained = append(retained, make([]byte, 64*1024))
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+	close(release)
+	runtime.KeepAlive(retained)
+
+	if !strings.Contains(logs.String(), "Suspected goroutine leak") {
+		t.Errorf("Expected a goroutine leak warning, got logs:\n%s", logs.String())
+	}
+}
@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RotateOptions configures size-based rotation of the log file.
+// A zero MaxSizeMB disables rotation.
+type RotateOptions struct {
+	MaxSizeMB  int // Rotate once the file would grow past this many megabytes
+	MaxBackups int // Number of rotated files (x.log.1 ... x.log.N) to keep
+}
+
+// RotatingWriter is an io.Writer that writes to a file and renames it to
+// x.log.1 (shifting older backups up) once it exceeds the size limit.
+type RotatingWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotatingWriter opens (or creates) the file at path for appending.
+func NewRotatingWriter(path string, opts RotateOptions) (*RotatingWriter, error) {
+	w := &RotatingWriter{
+		path:       path,
+		maxBytes:   int64(opts.MaxSizeMB) * 1024 * 1024,
+		maxBackups: max(opts.MaxBackups, 1),
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the current file, rotating first if p would push the
+// file past the size limit. If rotation fails, p still goes to the unrotated
+// file and rotation is retried on the next write.
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.maxBytes > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil && w.file == nil {
+			return 0, err
+		}
+	}
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
+
+// open opens the log file and records its current size.
+func (w *RotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// rotate shifts x.log.N-1 → x.log.N ... x.log → x.log.1 and reopens x.log.
+// If x.log cannot be renamed it is reopened as it is, so logging continues.
+func (w *RotatingWriter) rotate() error {
+	if w.file != nil {
+		err := w.file.Close()
+		w.file = nil
+		if err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+	}
+
+	for i := w.maxBackups - 1; i >= 1; i-- {
+		src := fmt.Sprintf("%s.%d", w.path, i)
+		if _, err := os.Stat(src); err == nil {
+			_ = os.Rename(src, fmt.Sprintf("%s.%d", w.path, i+1))
+		}
+	}
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		err = fmt.Errorf("failed to rotate log file: %w", err)
+		if openErr := w.open(); openErr != nil {
+			return errors.Join(err, openErr)
+		}
+		return err
+	}
+
+	return w.open()
+}
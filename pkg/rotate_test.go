@@ -0,0 +1,110 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test that writing past the size limit rotates the file
+func TestRotatingWriterRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	w, err := NewRotatingWriter(path, RotateOptions{MaxSizeMB: 1, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingWriter failed: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+	w.maxBytes = 100 // Keep the test small
+
+	line := strings.Repeat("a", 59) + "\n"
+	for i := 0; i < 5; i++ {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	// 5 lines of 60 bytes with a 100 byte limit: one line per file
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		data, err := os.ReadFile(name) //nolint:gosec
+		if err != nil {
+			t.Fatalf("Expected %s to exist: %v", name, err)
+		}
+		if string(data) != line {
+			t.Errorf("Expected %s to hold one line, got %d bytes", name, len(data))
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected at most 2 backups, found %s.3", path)
+	}
+}
+
+// Test that rotation is disabled without a size limit
+func TestRotatingWriterNoLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	w, err := NewRotatingWriter(path, RotateOptions{})
+	if err != nil {
+		t.Fatalf("NewRotatingWriter failed: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	for i := 0; i < 10; i++ {
+		if _, err := w.Write([]byte("hello\n")); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Error("Expected no rotated file without a size limit")
+	}
+}
+
+// Test that an existing file's size counts toward the limit
+func TestRotatingWriterExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 90)), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	w, err := NewRotatingWriter(path, RotateOptions{MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("NewRotatingWriter failed: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+	w.maxBytes = 100
+
+	if _, err := w.Write([]byte(strings.Repeat("y", 20))); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if data, err := os.ReadFile(path + ".1"); err != nil || len(data) != 90 { //nolint:gosec
+		t.Errorf("Expected the old 90 byte file to be rotated, got %d bytes (%v)", len(data), err)
+	}
+}
+
+// Test that logging continues in the unrotated file when renaming it fails
+func TestRotatingWriterRenameFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	// A non-empty directory where the backup should go makes the rename fail
+	if err := os.MkdirAll(filepath.Join(path+".1", "keep"), 0o750); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	w, err := NewRotatingWriter(path, RotateOptions{MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("NewRotatingWriter failed: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+	w.maxBytes = 100 // Keep the test small
+
+	line := strings.Repeat("a", 59) + "\n"
+	for i := 0; i < 3; i++ {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write %d failed: %v", i, err)
+		}
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(data) != strings.Repeat(line, 3) {
+		t.Errorf("Expected all 3 lines in the unrotated file, got %d bytes", len(data))
+	}
+}
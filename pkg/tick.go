@@ -0,0 +1,12 @@
+package pkg
+
+import "time"
+
+// NextTickDelay returns how long to wait before the next tick so that ticks
+// fire every interval however long handling one takes. elapsed is the time
+// since the current tick fired. When handling overran the interval the next
+// tick is due immediately instead of drifting, but ticks are never closer
+// together than minInterval, the frame time of the maximum frame rate.
+func NextTickDelay(interval, minInterval, elapsed time.Duration) time.Duration {
+	return max(max(interval, minInterval)-elapsed, 0)
+}
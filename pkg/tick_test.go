@@ -0,0 +1,29 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextTickDelay(t *testing.T) {
+	tests := []struct {
+		name        string
+		interval    time.Duration
+		minInterval time.Duration
+		elapsed     time.Duration
+		want        time.Duration
+	}{
+		{"fast step waits out the interval", 50 * time.Millisecond, 10 * time.Millisecond, 5 * time.Millisecond, 45 * time.Millisecond},
+		{"step as long as the interval", 50 * time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond, 0},
+		{"slow step catches up immediately", 50 * time.Millisecond, 10 * time.Millisecond, 80 * time.Millisecond, 0},
+		{"interval below the frame cap", 2 * time.Millisecond, 10 * time.Millisecond, 3 * time.Millisecond, 7 * time.Millisecond},
+		{"slow step below the frame cap", 2 * time.Millisecond, 10 * time.Millisecond, 15 * time.Millisecond, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextTickDelay(tt.interval, tt.minInterval, tt.elapsed); got != tt.want {
+				t.Errorf("NextTickDelay(%v, %v, %v) = %v, want %v", tt.interval, tt.minInterval, tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
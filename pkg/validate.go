@@ -0,0 +1,60 @@
+package pkg
+
+import "fmt"
+
+// LanguageCodes names the interface languages shared by the simulations,
+// indexed by their Language constants: English is 0 and Chinese is 1
+var LanguageCodes = []string{"en", "cn"}
+
+// Correction checks one configuration field, replacing an invalid value with
+// its default. It returns a message describing the change, or "" if the value
+// was valid.
+type Correction func() string
+
+// Validate runs the checks in order and returns the messages of those that
+// corrected their field, so a Config.Check can report what it changed
+func Validate(checks ...Correction) []string {
+	var messages []string
+	for _, check := range checks {
+		if msg := check(); msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+// HexColor checks that the named color is a #RRGGBB color
+func HexColor(name string, color *string, fallback string) Correction {
+	return func() string {
+		if IsValidHexColor(*color) {
+			return ""
+		}
+		msg := fmt.Sprintf("invalid %s color format: %s, using default", name, *color)
+		*color = fallback
+		return msg
+	}
+}
+
+// Char checks that the named character is a single rune
+func Char(name string, char *string, fallback string) Correction {
+	return func() string {
+		if len([]rune(*char)) == 1 {
+			return ""
+		}
+		msg := fmt.Sprintf("invalid %s character format: %s, using default", name, *char)
+		*char = fallback
+		return msg
+	}
+}
+
+// Language checks that lang is one of LanguageCodes
+func Language[L ~int](lang *L, fallback L) Correction {
+	return func() string {
+		if *lang >= 0 && int(*lang) < len(LanguageCodes) {
+			return ""
+		}
+		msg := fmt.Sprintf("invalid language %d, must be en or cn, using default language %s", *lang, LanguageCodes[fallback])
+		*lang = fallback
+		return msg
+	}
+}
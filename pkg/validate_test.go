@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test that Validate corrects invalid fields and reports only those
+func TestValidate(t *testing.T) {
+	type language int
+	alive, dead := "#00FF00", "green"
+	aliveChar, deadChar := "██", "·"
+	lang := language(7)
+
+	messages := Validate(
+		HexColor("alive", &alive, "#FFFFFF"),
+		HexColor("dead", &dead, "#000000"),
+		Char("alive", &aliveChar, "█"),
+		Char("dead", &deadChar, " "),
+		Language(&lang, 0),
+	)
+
+	if alive != "#00FF00" || deadChar != "·" {
+		t.Errorf("Expected valid fields to be kept, got %s and %s", alive, deadChar)
+	}
+	if dead != "#000000" {
+		t.Errorf("Expected an invalid hex color to fall back, got %s", dead)
+	}
+	if aliveChar != "█" {
+		t.Errorf("Expected a multi-rune character to fall back, got %s", aliveChar)
+	}
+	if lang != 0 {
+		t.Errorf("Expected an unknown language to fall back, got %d", lang)
+	}
+
+	want := []string{"dead color", "alive character", "language 7"}
+	if len(messages) != len(want) {
+		t.Fatalf("Expected %d corrections, got %q", len(want), messages)
+	}
+	for i, msg := range messages {
+		if !strings.Contains(msg, want[i]) {
+			t.Errorf("Expected correction %d to mention %q, got %q", i, want[i], msg)
+		}
+	}
+}
+
+func TestValidateNothingToCorrect(t *testing.T) {
+	color, char, lang := "#abcdef", "x", 1
+	if messages := Validate(HexColor("x", &color, ""), Char("x", &char, ""), Language(&lang, 0)); messages != nil {
+		t.Errorf("Expected no corrections, got %q", messages)
+	}
+}
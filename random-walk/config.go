@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/telepair/go-playground/pkg"
 )
 
 // WalkMode represents different walking modes
@@ -18,6 +20,9 @@ const (
 	ModeBrownianMotion                   // Brownian motion simulation
 	ModeSelfAvoidingWalk                 // Self-avoiding walk
 	ModeLevyFlight                       // Lévy flight pattern
+	ModeCorrelated                       // Correlated (persistent) walk
+	ModeDLA                              // Diffusion-limited aggregation
+	ModeWalk3D                           // 3D walk, projected isometrically
 )
 
 // ToString returns the string representation of walk mode
@@ -53,6 +58,21 @@ func (wm WalkMode) ToString(language Language) string {
 			return "莱维飞行"
 		}
 		return "Lévy Flight"
+	case ModeCorrelated:
+		if language == Chinese {
+			return "相关游走"
+		}
+		return "Correlated"
+	case ModeDLA:
+		if language == Chinese {
+			return "扩散限制凝聚"
+		}
+		return "DLA"
+	case ModeWalk3D:
+		if language == Chinese {
+			return "三维游走"
+		}
+		return "3D Walk"
 	default:
 		if language == Chinese {
 			return "单粒子"
@@ -61,6 +81,70 @@ func (wm WalkMode) ToString(language Language) string {
 	}
 }
 
+// BoundaryType represents how walkers behave at the grid edges
+type BoundaryType int
+
+// BoundaryType constants
+const (
+	BoundaryWrap    BoundaryType = iota // Walkers leaving one edge enter at the opposite one (default)
+	BoundaryReflect                     // Walkers bounce off the edges
+	BoundaryAbsorb                      // Walkers leaving the grid respawn where they started
+)
+
+// ToString returns the string representation of boundary type
+func (bt BoundaryType) ToString(language Language) string {
+	switch bt {
+	case BoundaryReflect:
+		if language == Chinese {
+			return "反射"
+		}
+		return "Reflect"
+	case BoundaryAbsorb:
+		if language == Chinese {
+			return "吸收"
+		}
+		return "Absorb"
+	default:
+		if language == Chinese {
+			return "环绕"
+		}
+		return "Wrap"
+	}
+}
+
+// Next returns the boundary type that follows bt in the B key cycle
+func (bt BoundaryType) Next() BoundaryType {
+	return (bt + 1) % (BoundaryAbsorb + 1)
+}
+
+// Connectivity is the set of neighbours a walker can step to
+type Connectivity int
+
+// Connectivity constants
+const (
+	ConnectivityEight Connectivity = iota // Moore neighbourhood: orthogonal and diagonal steps (default)
+	ConnectivityFour                      // Von Neumann neighbourhood: orthogonal steps only
+)
+
+// ToString returns the string representation of connectivity
+func (c Connectivity) ToString(language Language) string {
+	if c == ConnectivityFour {
+		if language == Chinese {
+			return "4 方向"
+		}
+		return "4 Directions"
+	}
+	if language == Chinese {
+		return "8 方向"
+	}
+	return "8 Directions"
+}
+
+// Next returns the connectivity that follows c in the C key cycle
+func (c Connectivity) Next() Connectivity {
+	return (c + 1) % (ConnectivityFour + 1)
+}
+
 // Direction represents movement direction
 type Direction int
 
@@ -117,20 +201,32 @@ const (
 	DefaultRefreshRate = 50 * time.Millisecond // Default refresh rate in milliseconds
 	MinRefreshRate     = 10 * time.Millisecond // Minimum refresh rate in milliseconds
 	DefaultWalkMode    = ModeSingleWalker      // Default walk mode
+	DefaultBoundary    = BoundaryWrap          // Default boundary type
 	DefaultWalkerCount = 3                     // Default number of walkers for multi-walker mode
 	MaxWalkerCount     = 10                    // Maximum number of walkers
 	DefaultTrailLength = 100                   // Default trail length
 	MaxTrailLength     = 500                   // Maximum trail length
+	MaxTrailIntensity  = 255                   // Intensity of the most recent trail cell
+	DefaultPersistence = 0.8                   // Default probability of keeping the previous direction
+	PersistenceStep    = 0.1                   // Persistence change per key press
+	DefaultMaxCluster  = 1000                  // Default maximum DLA cluster size
+	MinDepthIntensity  = 64                    // Trail intensity of the farthest point in 3D mode
+
+	DefaultConnectivity = ConnectivityEight // Default walker connectivity
 
 	// Colors
-	DefaultWalkerColor = "#FF00FF" // Default walker color (magenta)
-	DefaultTrailColor  = "#0088FF" // Default trail color (blue)
-	DefaultEmptyColor  = "#000000" // Default empty cell color (black)
+	DefaultWalkerColor   = "#FF00FF" // Default walker color (magenta)
+	DefaultTrailColor    = "#0088FF" // Default trail color (blue)
+	DefaultClusterColor  = "#FFD700" // DLA cluster color (gold)
+	DefaultObstacleColor = "#808080" // Wall color (gray)
+	DefaultEmptyColor    = "#000000" // Default empty cell color (black)
 
 	// Characters
-	DefaultWalkerChar = "●" // Default walker character
-	DefaultTrailChar  = "·" // Default trail character
-	DefaultEmptyChar  = " " // Default empty cell character
+	DefaultWalkerChar   = "●" // Default walker character
+	DefaultTrailChar    = "·" // Default trail character
+	DefaultClusterChar  = "█" // DLA cluster character
+	DefaultObstacleChar = "▓" // Wall character
+	DefaultEmptyChar    = " " // Default empty cell character
 
 	// Walker colors for multi-walker mode
 	Walker1Color  = "#FF0000" // Red
@@ -170,6 +266,8 @@ var DefaultConfig = Config{
 	WalkerChar:  DefaultWalkerChar,
 	TrailChar:   DefaultTrailChar,
 	EmptyChar:   DefaultEmptyChar,
+	Persistence: DefaultPersistence,
+	MaxCluster:  DefaultMaxCluster,
 	Language:    DefaultLanguage,
 }
 
@@ -181,7 +279,14 @@ type Config struct {
 	WalkerChar  string
 	TrailChar   string
 	EmptyChar   string
+	Persistence float64  // Probability of keeping the previous direction in correlated mode
+	MaxCluster  int      // DLA cluster size at which no new walkers are released
+	Obstacles   [][]bool // Wall layout loaded from a map file, nil for none
+	Seed        int64    // Random seed for reproducible runs, 0 for time-based
 	Language    Language
+
+	Boundary     BoundaryType // How walkers behave at the grid edges
+	Connectivity Connectivity // Whether walkers also step diagonally
 }
 
 // SetLanguage sets the language
@@ -194,47 +299,61 @@ func (c *Config) SetLanguage(lang string) {
 	}
 }
 
-// Check validates the configuration
-func (c *Config) Check() {
-	if !isValidHexColor(c.WalkerColor) {
-		fmt.Printf("invalid walker color format: %s, using default\n", c.WalkerColor)
-		c.WalkerColor = DefaultWalkerColor
-	}
-	if !isValidHexColor(c.TrailColor) {
-		fmt.Printf("invalid trail color format: %s, using default\n", c.TrailColor)
-		c.TrailColor = DefaultTrailColor
-	}
-	if !isValidHexColor(c.EmptyColor) {
-		fmt.Printf("invalid empty color format: %s, using default\n", c.EmptyColor)
-		c.EmptyColor = DefaultEmptyColor
-	}
-	if len([]rune(c.WalkerChar)) != 1 {
-		fmt.Printf("invalid walker character format: %s, using default\n", c.WalkerChar)
-		c.WalkerChar = DefaultWalkerChar
-	}
-	if len([]rune(c.TrailChar)) != 1 {
-		fmt.Printf("invalid trail character format: %s, using default\n", c.TrailChar)
-		c.TrailChar = DefaultTrailChar
+// SetBoundary sets the boundary type from its name
+func (c *Config) SetBoundary(name string) {
+	switch strings.ToLower(name) {
+	case "wrap":
+		c.Boundary = BoundaryWrap
+	case "reflect":
+		c.Boundary = BoundaryReflect
+	case "absorb":
+		c.Boundary = BoundaryAbsorb
+	default:
+		fmt.Printf("invalid boundary %s, must be wrap, reflect or absorb, using default %s\n", name, DefaultBoundary.ToString(English))
+		c.Boundary = DefaultBoundary
 	}
-	if len([]rune(c.EmptyChar)) != 1 {
-		fmt.Printf("invalid empty character format: %s, using default\n", c.EmptyChar)
-		c.EmptyChar = DefaultEmptyChar
+	if c.Connectivity < ConnectivityEight || c.Connectivity > ConnectivityFour {
+		fmt.Printf("invalid connectivity %d, must be between 0 and 1, using default %d\n", c.Connectivity, DefaultConnectivity)
+		c.Connectivity = DefaultConnectivity
 	}
-	if c.Language != English && c.Language != Chinese {
-		fmt.Printf("invalid language %s, must be en or cn, using default language %s\n", c.Language.ToString(c.Language), DefaultLanguage.ToString(c.Language))
-		c.Language = DefaultLanguage
+}
+
+// SetConnectivity sets the connectivity from its name
+func (c *Config) SetConnectivity(name string) {
+	switch strings.ToLower(name) {
+	case "8", "eight":
+		c.Connectivity = ConnectivityEight
+	case "4", "four":
+		c.Connectivity = ConnectivityFour
+	default:
+		fmt.Printf("invalid connectivity %s, must be 4 or 8, using default %s\n", name, DefaultConnectivity.ToString(English))
+		c.Connectivity = DefaultConnectivity
 	}
 }
 
-// isValidHexColor checks if a string is a valid hex color
-func isValidHexColor(color string) bool {
-	if len(color) != 7 || color[0] != '#' {
-		return false
+// Check validates the configuration
+func (c *Config) Check() {
+	for _, msg := range pkg.Validate(
+		pkg.HexColor("walker", &c.WalkerColor, DefaultWalkerColor),
+		pkg.HexColor("trail", &c.TrailColor, DefaultTrailColor),
+		pkg.HexColor("empty", &c.EmptyColor, DefaultEmptyColor),
+		pkg.Char("walker", &c.WalkerChar, DefaultWalkerChar),
+		pkg.Char("trail", &c.TrailChar, DefaultTrailChar),
+		pkg.Char("empty", &c.EmptyChar, DefaultEmptyChar),
+		pkg.Language(&c.Language, DefaultLanguage),
+	) {
+		fmt.Println(msg)
 	}
-	for _, c := range color[1:] {
-		if (c < '0' || c > '9') && (c < 'A' || c > 'F') && (c < 'a' || c > 'f') {
-			return false
-		}
+	if c.Persistence < 0 || c.Persistence > 1 {
+		fmt.Printf("invalid persistence %v, must be between 0 and 1, using default %v\n", c.Persistence, DefaultPersistence)
+		c.Persistence = DefaultPersistence
+	}
+	if c.MaxCluster < 1 {
+		fmt.Printf("invalid max cluster size %d, must be positive, using default %d\n", c.MaxCluster, DefaultMaxCluster)
+		c.MaxCluster = DefaultMaxCluster
+	}
+	if c.Boundary < BoundaryWrap || c.Boundary > BoundaryAbsorb {
+		fmt.Printf("invalid boundary %d, must be between 0 and 2, using default %d\n", c.Boundary, DefaultBoundary)
+		c.Boundary = DefaultBoundary
 	}
-	return true
 }
@@ -24,6 +24,9 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -walker-char '🐾' -trail-char '·' # Custom walker and trail characters\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -walker-color '#FF00FF'          # Custom walker color\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -lang cn                         # Run in Chinese\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -map maze.txt                    # Walk among walls ('#') from a map file\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -boundary reflect                # Bounce off the edges instead of wrapping\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -connectivity 4                  # Orthogonal steps only\n", os.Args[0])
 	}
 
 	// Parse command line flags
@@ -33,6 +36,12 @@ func main() {
 	var walkerChar = flag.String("walker-char", DefaultWalkerChar, "Walker character")
 	var trailChar = flag.String("trail-char", DefaultTrailChar, "Trail character")
 	var emptyChar = flag.String("empty-char", DefaultEmptyChar, "Empty cell character")
+	var persistence = flag.Float64("persistence", DefaultPersistence, "Probability of keeping the previous direction in correlated mode (0-1)")
+	var maxCluster = flag.Int("max-cluster", DefaultMaxCluster, "DLA cluster size at which no new walkers are released")
+	var connectivity = flag.String("connectivity", "8", "Step directions, 4 (orthogonal only) or 8 (with diagonals)")
+	var boundary = flag.String("boundary", "wrap", "Boundary behaviour (wrap/reflect/absorb)")
+	var mapFile = flag.String("map", "", "Obstacle map file ('#' = wall)")
+	var seed = flag.Int64("seed", 0, "Random seed for reproducible runs (0 = time-based)")
 	var lang = flag.String("lang", DefaultLanguage.ToString(DefaultLanguage), "Language (en/cn)")
 	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
 	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
@@ -42,7 +51,7 @@ func main() {
 	flag.Parse()
 
 	if *logFile != "" {
-		_ = pkg.InitLog("debug", "text", *logFile)
+		_, _ = pkg.InitLog("debug", "text", *logFile)
 	}
 	slog.Debug("Random Walk Visualization starting")
 
@@ -63,10 +72,25 @@ func main() {
 		WalkerChar:  *walkerChar,
 		TrailChar:   *trailChar,
 		EmptyChar:   *emptyChar,
+		Persistence: *persistence,
+		MaxCluster:  *maxCluster,
+		Seed:        *seed,
+	}
+	if *mapFile != "" {
+		obstacles, err := LoadObstacleMap(*mapFile)
+		if err != nil {
+			fmt.Printf("failed to load map: %v, running without obstacles\n", err)
+		}
+		config.Obstacles = obstacles
 	}
 	config.SetLanguage(*lang)
+	config.SetBoundary(*boundary)
+	config.SetConnectivity(*connectivity)
 	config.Check()
 
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
 	// Create initial model
 	initialModel := NewModel(config)
 
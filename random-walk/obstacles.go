@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// WallChar marks a wall cell in an obstacle map file
+const WallChar = '#'
+
+// LoadObstacleMap reads a text map where each line is a grid row and '#'
+// marks a wall; any other character is free space. Rows may have different
+// lengths.
+func LoadObstacleMap(path string) ([][]bool, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return parseObstacleMap(bufio.NewScanner(f))
+}
+
+// parseObstacleMap parses the map format of LoadObstacleMap
+func parseObstacleMap(scanner *bufio.Scanner) ([][]bool, error) {
+	var obstacles [][]bool
+	walls := 0
+	for scanner.Scan() {
+		line := []rune(strings.TrimRight(scanner.Text(), "\r"))
+		row := make([]bool, len(line))
+		for i, c := range line {
+			if c == WallChar {
+				row[i] = true
+				walls++
+			}
+		}
+		obstacles = append(obstacles, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if walls == 0 {
+		return nil, fmt.Errorf("map has no walls ('%c')", WallChar)
+	}
+	return obstacles, nil
+}
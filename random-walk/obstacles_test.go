@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadObstacleMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "map.txt")
+	content := "#####\n#  .#\r\n\n# #\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write map: %v", err)
+	}
+
+	obstacles, err := LoadObstacleMap(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := [][]bool{
+		{true, true, true, true, true},
+		{true, false, false, false, true},
+		{},
+		{true, false, true},
+	}
+	if len(obstacles) != len(expected) {
+		t.Fatalf("Expected %d rows, got %d", len(expected), len(obstacles))
+	}
+	for i := range expected {
+		if len(obstacles[i]) != len(expected[i]) {
+			t.Fatalf("Row %d: expected length %d, got %d", i, len(expected[i]), len(obstacles[i]))
+		}
+		for j := range expected[i] {
+			if obstacles[i][j] != expected[i][j] {
+				t.Errorf("Cell (%d,%d): expected %v, got %v", i, j, expected[i][j], obstacles[i][j])
+			}
+		}
+	}
+}
+
+func TestLoadObstacleMapErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadObstacleMap(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte("   \n  \n"), 0o600); err != nil {
+		t.Fatalf("Failed to write map: %v", err)
+	}
+	if _, err := LoadObstacleMap(empty); err == nil {
+		t.Error("Expected error for a map without walls")
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// statsSnapshot is the JSON layout written by ExportStats
+type statsSnapshot struct {
+	Mode        string        `json:"mode"`
+	Steps       int           `json:"steps"`
+	UniqueCells int           `json:"uniqueCells"`
+	MSD         float64       `json:"msd"`
+	Walkers     []walkerStats `json:"walkers"`
+}
+
+// walkerStats is the per-walker part of a statsSnapshot
+type walkerStats struct {
+	ID          int `json:"id"`
+	PathLength  int `json:"pathLength"`
+	UniqueCells int `json:"uniqueCells"`
+	X           int `json:"x"`
+	Y           int `json:"y"`
+	Z           int `json:"z,omitempty"` // Only used by the 3D walk
+}
+
+// GetUniqueCells returns the number of distinct cells visited by any walker
+// since the last reset
+func (rw *RandomWalk) GetUniqueCells() int {
+	if len(rw.walkers) == 1 {
+		return len(rw.walkers[0].Visited)
+	}
+	cells := make(map[Position]bool)
+	for _, walker := range rw.walkers {
+		for pos := range walker.Visited {
+			cells[pos] = true
+		}
+	}
+	return len(cells)
+}
+
+// ExportStats writes a JSON snapshot of the walk statistics to path: steps
+// taken, distinct cells visited, the mean squared displacement, and each
+// walker's path length and current position
+func (rw *RandomWalk) ExportStats(path string) error {
+	snapshot := statsSnapshot{
+		Mode:        rw.mode.ToString(English),
+		Steps:       rw.steps,
+		UniqueCells: rw.GetUniqueCells(),
+		MSD:         rw.GetMSD(),
+		Walkers:     make([]walkerStats, len(rw.walkers)),
+	}
+	for i, walker := range rw.walkers {
+		snapshot.Walkers[i] = walkerStats{
+			ID:          walker.ID,
+			PathLength:  walker.PathLength,
+			UniqueCells: len(walker.Visited),
+			X:           walker.Position.X,
+			Y:           walker.Position.Y,
+			Z:           walker.Position.Z,
+		}
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write stats %s: %w", path, err)
+	}
+	return nil
+}
+
+// StatsFileName returns a default file name for ExportStats, such as
+// "random-walk-20250101-120000.json"
+func (rw *RandomWalk) StatsFileName(now time.Time) string {
+	return fmt.Sprintf("random-walk-%s.json", now.Format("20060102-150405"))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExportStats(t *testing.T) {
+	rw := NewRandomWalk(10, 10, ModeCorrelated, 1, 50)
+
+	// Walk straight right for 4 steps, visiting 5 cells
+	rw.SetPersistence(1)
+	walker := rw.GetWalkers()[0]
+	walker.Heading = DirectionRight
+	for range 4 {
+		rw.Step()
+	}
+
+	path := filepath.Join(t.TempDir(), "stats.json")
+	if err := rw.ExportStats(path); err != nil {
+		t.Fatalf("ExportStats failed: %v", err)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		t.Fatalf("Failed to read stats: %v", err)
+	}
+	var stats statsSnapshot
+	if err := json.Unmarshal(data, &stats); err != nil {
+		t.Fatalf("Failed to parse stats: %v", err)
+	}
+
+	if stats.Steps != 4 || stats.UniqueCells != 5 || stats.MSD != 16 {
+		t.Errorf("Expected 4 steps, 5 unique cells and MSD 16, got %d, %d and %f", stats.Steps, stats.UniqueCells, stats.MSD)
+	}
+	if len(stats.Walkers) != 1 {
+		t.Fatalf("Expected 1 walker, got %d", len(stats.Walkers))
+	}
+	w := stats.Walkers[0]
+	if w.PathLength != 4 || w.X != walker.Start.X+4 || w.Y != walker.Start.Y {
+		t.Errorf("Expected path length 4 ending at (%d,%d), got %+v", walker.Start.X+4, walker.Start.Y, w)
+	}
+
+	// Wrapping around the row revisits cells, so the count stops at its width
+	for range 12 {
+		rw.Step()
+	}
+	if got := rw.GetUniqueCells(); got != 10 {
+		t.Errorf("Expected 10 unique cells after wrapping, got %d", got)
+	}
+	if walker.PathLength != 16 {
+		t.Errorf("Expected path length 16, got %d", walker.PathLength)
+	}
+}
+
+func TestUniqueCellsAcrossWalkers(t *testing.T) {
+	rw := NewRandomWalk(10, 10, ModeMultiWalker, 2, 50)
+	walkers := rw.GetWalkers()
+	walkers[0].Visited = map[Position]bool{{X: 1, Y: 1}: true, {X: 2, Y: 1}: true}
+	walkers[1].Visited = map[Position]bool{{X: 2, Y: 1}: true, {X: 3, Y: 1}: true}
+
+	if got := rw.GetUniqueCells(); got != 3 {
+		t.Errorf("Expected 3 unique cells, got %d", got)
+	}
+}
+
+func TestExportStatsError(t *testing.T) {
+	rw := NewRandomWalk(10, 10, ModeSingleWalker, 1, 50)
+	if err := rw.ExportStats(filepath.Join(t.TempDir(), "missing", "stats.json")); err == nil {
+		t.Error("Expected error when exporting to a missing directory")
+	}
+}
+
+func TestStatsFileName(t *testing.T) {
+	rw := NewRandomWalk(10, 10, ModeSingleWalker, 1, 50)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if name := rw.StatsFileName(now); name != "random-walk-20250102-030405.json" {
+		t.Errorf("Unexpected file name %s", name)
+	}
+}
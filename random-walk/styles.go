@@ -2,9 +2,12 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
 )
 
 // Enhanced UI styles for better visual appearance
@@ -43,15 +46,38 @@ const (
 	SizeLabelCN = "📐 尺寸: %d×%d"
 	SizeLabelEN = "📐 Size: %d×%d"
 
+	MSDLabelCN = "📏 均方位移: %.1f"
+	MSDLabelEN = "📏 MSD: %.1f"
+
 	ModeLabelCN = "🎨 模式: %s"
 	ModeLabelEN = "🎨 Mode: %s"
 
+	BoundaryLabelCN = "🧱 边界: %s"
+	BoundaryLabelEN = "🧱 Boundary: %s"
+
+	ConnectivityLabelCN = "🧭 步向: %s"
+	ConnectivityLabelEN = "🧭 Steps: %s"
+
 	WalkersLabelCN = "👥 粒子数: %d"
 	WalkersLabelEN = "👥 Walkers: %d"
 
 	TrailLabelCN = "🌟 轨迹长度: %d"
 	TrailLabelEN = "🌟 Trail: %d"
 
+	ClusterLabelCN = "🌳 团簇: %d/%d"
+	ClusterLabelEN = "🌳 Cluster: %d/%d"
+
+	PersistenceLabelCN = "🧭 持续性: %.2f"
+	PersistenceLabelEN = "🧭 Persistence: %.2f"
+
+	BoundsLabelCN = "📦 范围: %d×%d×%d"
+	BoundsLabelEN = "📦 Bounds: %d×%d×%d"
+
+	ExportedLabelCN     = "💾 已导出: %s"
+	ExportedLabelEN     = "💾 Exported: %s"
+	ExportFailedLabelCN = "⚠️ 导出失败: %v"
+	ExportFailedLabelEN = "⚠️ Export failed: %v"
+
 	StatusLabelPlayingCN = "▶️ 运行中"
 	StatusLabelPlayingEN = "▶️ Running"
 	StatusLabelPausedCN  = "⏸️ 已暂停"
@@ -61,18 +87,30 @@ const (
 	SelectModeLabelCN = "M 切换模式"
 	SelectModeLabelEN = "M Change Mode"
 
+	BoundaryControlLabelCN = "B 切换边界"
+	BoundaryControlLabelEN = "B Boundary"
+
+	ConnectivityControlLabelCN = "C 4/8 方向"
+	ConnectivityControlLabelEN = "C 4/8 Directions"
+
 	WalkerControlLabelCN = "W/w 粒子数 +/-"
 	WalkerControlLabelEN = "W/w Walkers +/-"
 
 	TrailControlLabelCN = "T/t 轨迹长度 +/-"
 	TrailControlLabelEN = "T/t Trail +/-"
 
+	PersistenceControlLabelCN = "p/P 持续性 +/-"
+	PersistenceControlLabelEN = "p/P Persistence +/-"
+
 	LanguageLabelCN = "L 切换语言"
 	LanguageLabelEN = "L Switch Language"
 
 	SpeedControlLabelCN = "+/- 加速/减速"
 	SpeedControlLabelEN = "+/- Speed Up/Down"
 
+	ExportControlLabelCN = "E 导出统计"
+	ExportControlLabelEN = "E Export Stats"
+
 	SpaceControlLabelCN = "Space 暂停"
 	SpaceControlLabelEN = "Space Pause"
 
@@ -85,29 +123,85 @@ const (
 
 // RenderOptions contains rendering configuration with cached styles
 type RenderOptions struct {
-	walkerStyled string // Cached styled walker
-	trailStyled  string // Cached styled trail
-	emptyStyled  string // Cached styled empty cell
-	walkerChar   string
-	trailChar    string
-	emptyChar    string
+	walkerStyled   string // Cached styled walker
+	clusterStyled  string // Cached styled DLA cluster cell
+	obstacleStyled string // Cached styled wall
+	emptyStyled    string // Cached styled empty cell
+	walkerChar     string
+	trailChar      string
+	emptyChar      string
+
+	trailColor  string         // Color of the most recent trail
+	fadeColor   string         // Color the trail fades toward
+	trailColors map[int]string // Cached trail colors by intensity
+	trailStyles map[int]string // Cached styled trails by intensity
 }
 
 // NewRenderOptions creates optimized render options with pre-computed styles
 func NewRenderOptions(walkerColor, trailColor, emptyColor, walkerChar, trailChar, emptyChar string) RenderOptions {
 	return RenderOptions{
-		walkerStyled: lipgloss.NewStyle().Foreground(lipgloss.Color(walkerColor)).Render(walkerChar),
-		trailStyled:  lipgloss.NewStyle().Foreground(lipgloss.Color(trailColor)).Render(trailChar),
-		emptyStyled:  lipgloss.NewStyle().Foreground(lipgloss.Color(emptyColor)).Render(emptyChar),
-		walkerChar:   walkerChar,
-		trailChar:    trailChar,
-		emptyChar:    emptyChar,
+		walkerStyled:   lipgloss.NewStyle().Foreground(pkg.AdaptColor(walkerColor)).Render(walkerChar),
+		clusterStyled:  lipgloss.NewStyle().Foreground(pkg.AdaptColor(DefaultClusterColor)).Render(DefaultClusterChar),
+		obstacleStyled: lipgloss.NewStyle().Foreground(pkg.AdaptColor(DefaultObstacleColor)).Render(DefaultObstacleChar),
+		emptyStyled:    lipgloss.NewStyle().Foreground(pkg.AdaptColor(emptyColor)).Render(emptyChar),
+		walkerChar:     walkerChar,
+		trailChar:      trailChar,
+		emptyChar:      emptyChar,
+		trailColor:     trailColor,
+		fadeColor:      emptyColor,
+		trailColors:    make(map[int]string),
+		trailStyles:    make(map[int]string),
+	}
+}
+
+// trailColorFor returns the trail color for an intensity in [0, 255]:
+// the trail color at 255, fading toward the empty cell color as it decays
+func (ro RenderOptions) trailColorFor(intensity int) string {
+	intensity = min(max(intensity, 0), MaxTrailIntensity)
+	if color, ok := ro.trailColors[intensity]; ok {
+		return color
 	}
+	t := 1 - float64(intensity)/MaxTrailIntensity
+	color := blendColor(ro.trailColor, ro.fadeColor, t)
+	ro.trailColors[intensity] = color
+	return color
+}
+
+// trailStyledFor returns the styled trail character for an intensity
+func (ro RenderOptions) trailStyledFor(intensity int) string {
+	if styled, ok := ro.trailStyles[intensity]; ok {
+		return styled
+	}
+	styled := lipgloss.NewStyle().Foreground(pkg.AdaptColor(ro.trailColorFor(intensity))).Render(ro.trailChar)
+	ro.trailStyles[intensity] = styled
+	return styled
+}
+
+// blendColor linearly interpolates between two hex colors, t in [0, 1]
+func blendColor(from, to string, t float64) string {
+	fr, fg, fb := hexToRGB(from)
+	tr, tg, tb := hexToRGB(to)
+	r := float64(fr) + (float64(tr)-float64(fr))*t
+	g := float64(fg) + (float64(tg)-float64(fg))*t
+	b := float64(fb) + (float64(tb)-float64(fb))*t
+	return fmt.Sprintf("#%02X%02X%02X", int(math.Round(r)), int(math.Round(g)), int(math.Round(b)))
+}
+
+// hexToRGB converts a #RRGGBB hex color to its components, black if invalid
+func hexToRGB(color string) (int, int, int) {
+	if !pkg.IsValidHexColor(color) {
+		return 0, 0, 0
+	}
+	v, err := strconv.ParseUint(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return int(v >> 16 & 0xFF), int(v >> 8 & 0xFF), int(v & 0xFF)
 }
 
 // getWalkerStyled returns a styled walker with custom color
 func (ro RenderOptions) getWalkerStyled(color, char string) string {
-	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(char)
+	return lipgloss.NewStyle().Foreground(pkg.AdaptColor(color)).Render(char)
 }
 
 // HeaderLineView returns the header display string
@@ -121,7 +215,7 @@ func (m Model) HeaderLineView() string {
 
 // StatusLineView returns the status display string for the first row
 func (m Model) StatusLineView() string {
-	var status, stepsLabel, speedLabel, sizeLabel, modeLabel, walkersLabel, trailLabel string
+	var status, stepsLabel, msdLabel, speedLabel, sizeLabel, modeLabel, boundaryLabel, connectivityLabel, walkersLabel, trailLabel, persistenceLabel, clusterLabel, boundsLabel string
 
 	if m.language == Chinese {
 		status = StatusLabelPlayingCN
@@ -129,69 +223,120 @@ func (m Model) StatusLineView() string {
 			status = StatusLabelPausedCN
 		}
 		stepsLabel = StepsLabelCN
+		msdLabel = MSDLabelCN
 		speedLabel = SpeedLabelCN
 		sizeLabel = SizeLabelCN
 		modeLabel = ModeLabelCN
+		boundaryLabel = BoundaryLabelCN
+		connectivityLabel = ConnectivityLabelCN
 		walkersLabel = WalkersLabelCN
 		trailLabel = TrailLabelCN
+		persistenceLabel = PersistenceLabelCN
+		clusterLabel = ClusterLabelCN
+		boundsLabel = BoundsLabelCN
 	} else {
 		status = StatusLabelPlayingEN
 		if m.paused {
 			status = StatusLabelPausedEN
 		}
 		stepsLabel = StepsLabelEN
+		msdLabel = MSDLabelEN
 		speedLabel = SpeedLabelEN
 		sizeLabel = SizeLabelEN
 		modeLabel = ModeLabelEN
+		boundaryLabel = BoundaryLabelEN
+		connectivityLabel = ConnectivityLabelEN
 		walkersLabel = WalkersLabelEN
 		trailLabel = TrailLabelEN
+		persistenceLabel = PersistenceLabelEN
+		clusterLabel = ClusterLabelEN
+		boundsLabel = BoundsLabelEN
 	}
 
 	tableBuilder.Reset()
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(stepsLabel, m.currentStep)))
 	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(msdLabel, m.walk.GetMSD())))
+	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(speedLabel, m.refreshRate.String())))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sizeLabel, m.gridHeight, m.gridWidth)))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(modeLabel, m.mode.ToString(m.language))))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(boundaryLabel, m.walk.GetBoundary().ToString(m.language))))
+	if m.mode != ModeWalk3D {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(connectivityLabel, m.walk.GetConnectivity().ToString(m.language))))
+	}
 
 	// Show walker count for multi-walker modes
-	if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion {
+	if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion || m.mode == ModeDLA {
 		tableBuilder.WriteString(" | ")
 		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(walkersLabel, m.walkerCount)))
 	}
 
 	// Show trail length for trail modes
-	if m.mode == ModeTrailMode || m.mode == ModeBrownianMotion {
+	if m.mode == ModeTrailMode || m.mode == ModeBrownianMotion || m.mode == ModeCorrelated || m.mode == ModeWalk3D {
 		tableBuilder.WriteString(" | ")
 		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(trailLabel, m.trailLength)))
 	}
 
+	// Show persistence for correlated mode
+	if m.mode == ModeCorrelated {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(persistenceLabel, m.walk.GetPersistence())))
+	}
+
+	// Show cluster size for DLA mode
+	if m.mode == ModeDLA {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(clusterLabel, m.walk.GetClusterSize(), m.walk.GetMaxCluster())))
+	}
+
+	// Show the extent of the walk for 3D mode
+	if m.mode == ModeWalk3D {
+		bounds := m.walk.GetBounds3D()
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(boundsLabel, bounds.X, bounds.Y, bounds.Z)))
+	}
+
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(status))
+	if m.notice != "" {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(m.notice))
+	}
 
 	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
 }
 
 // ControlLineView returns the control display string
 func (m Model) ControlLineView() string {
-	var selectMode, walkerControl, trailControl, speedControl, language, space, reset, quit string
+	var selectMode, boundaryControl, connectivityControl, walkerControl, trailControl, persistenceControl, speedControl, language, exportControl, space, reset, quit string
 	if m.language == Chinese {
 		selectMode = SelectModeLabelCN
+		boundaryControl = BoundaryControlLabelCN
+		connectivityControl = ConnectivityControlLabelCN
 		walkerControl = WalkerControlLabelCN
 		trailControl = TrailControlLabelCN
+		persistenceControl = PersistenceControlLabelCN
 		language = LanguageLabelCN
 		speedControl = SpeedControlLabelCN
+		exportControl = ExportControlLabelCN
 		space = SpaceControlLabelCN
 		reset = ResetLabelCN
 		quit = QuitLabelCN
 	} else {
 		selectMode = SelectModeLabelEN
+		boundaryControl = BoundaryControlLabelEN
+		connectivityControl = ConnectivityControlLabelEN
 		walkerControl = WalkerControlLabelEN
 		trailControl = TrailControlLabelEN
+		persistenceControl = PersistenceControlLabelEN
 		language = LanguageLabelEN
 		speedControl = SpeedControlLabelEN
+		exportControl = ExportControlLabelEN
 		space = SpaceControlLabelEN
 		reset = ResetLabelEN
 		quit = QuitLabelEN
@@ -199,24 +344,39 @@ func (m Model) ControlLineView() string {
 
 	tableBuilder.Reset()
 	tableBuilder.WriteString(labelStyle.Render(selectMode))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(boundaryControl))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(connectivityControl))
 
 	// Show walker control for multi-walker modes
-	if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion {
+	if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion || m.mode == ModeDLA {
 		tableBuilder.WriteString(" | ")
 		tableBuilder.WriteString(labelStyle.Render(walkerControl))
 	}
 
 	// Show trail control for trail modes
-	if m.mode == ModeTrailMode || m.mode == ModeBrownianMotion {
+	if m.mode == ModeTrailMode || m.mode == ModeBrownianMotion || m.mode == ModeCorrelated || m.mode == ModeWalk3D {
 		tableBuilder.WriteString(" | ")
 		tableBuilder.WriteString(labelStyle.Render(trailControl))
 	}
 
+	// Show persistence control for correlated mode
+	if m.mode == ModeCorrelated {
+		tableBuilder.WriteString(" | ")
+		tableBuilder.WriteString(labelStyle.Render(persistenceControl))
+	}
+
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(speedControl))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(language))
 	tableBuilder.WriteString(" | ")
+	// Exporting is only available while paused
+	if m.paused {
+		tableBuilder.WriteString(labelStyle.Render(exportControl))
+		tableBuilder.WriteString(" | ")
+	}
 	tableBuilder.WriteString(labelStyle.Render(space))
 	tableBuilder.WriteString(" | ")
 	tableBuilder.WriteString(labelStyle.Render(reset))
@@ -225,3 +385,19 @@ func (m Model) ControlLineView() string {
 
 	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
 }
+
+// exportedLabel returns the stats export success message format
+func (m Model) exportedLabel() string {
+	if m.language == Chinese {
+		return ExportedLabelCN
+	}
+	return ExportedLabelEN
+}
+
+// exportFailedLabel returns the stats export failure message format
+func (m Model) exportFailedLabel() string {
+	if m.language == Chinese {
+		return ExportFailedLabelCN
+	}
+	return ExportFailedLabelEN
+}
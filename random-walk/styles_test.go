@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestBlendColor(t *testing.T) {
+	tests := []struct {
+		from, to string
+		t        float64
+		expected string
+	}{
+		{"#FF0000", "#0000FF", 0, "#FF0000"},
+		{"#FF0000", "#0000FF", 1, "#0000FF"},
+		{"#000000", "#FFFFFF", 0.5, "#808080"},
+		{"invalid", "#FFFFFF", 1, "#FFFFFF"},
+	}
+
+	for _, tt := range tests {
+		if got := blendColor(tt.from, tt.to, tt.t); got != tt.expected {
+			t.Errorf("blendColor(%s, %s, %v): expected %s, got %s", tt.from, tt.to, tt.t, tt.expected, got)
+		}
+	}
+}
+
+func TestTrailColorFor(t *testing.T) {
+	ro := NewRenderOptions(DefaultWalkerColor, "#0088FF", "#000000", DefaultWalkerChar, DefaultTrailChar, DefaultEmptyChar)
+
+	tests := []struct {
+		intensity int
+		expected  string
+	}{
+		{MaxTrailIntensity, "#0088FF"},
+		{0, "#000000"},
+		{-10, "#000000"},
+		{MaxTrailIntensity + 10, "#0088FF"},
+	}
+
+	for _, tt := range tests {
+		if got := ro.trailColorFor(tt.intensity); got != tt.expected {
+			t.Errorf("trailColorFor(%d): expected %s, got %s", tt.intensity, tt.expected, got)
+		}
+	}
+
+	// Brighter intensities give brighter colors
+	_, _, dim := hexToRGB(ro.trailColorFor(64))
+	_, _, bright := hexToRGB(ro.trailColorFor(192))
+	if dim >= bright {
+		t.Errorf("Expected intensity 192 (blue %d) brighter than 64 (blue %d)", bright, dim)
+	}
+
+	// Results are cached
+	if len(ro.trailColors) == 0 || len(ro.trailColors) > MaxTrailIntensity+1 {
+		t.Errorf("Expected cached trail colors, got %d entries", len(ro.trailColors))
+	}
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
+	"math"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/telepair/go-playground/pkg"
 )
 
 var (
@@ -32,6 +35,8 @@ type Model struct {
 	gridBuffer    strings.Builder
 	renderOptions RenderOptions
 	logger        *slog.Logger
+
+	notice string // Result of the last stats export, shown in the status line until the next key
 }
 
 // NewModel creates a new model with the given configuration
@@ -41,8 +46,17 @@ func NewModel(cfg Config) Model {
 	gridHeight := DefaultRows - keepHeight
 	gridWidth := DefaultCols - keepWidth
 
+	walk := NewRandomWalk(gridHeight, gridWidth, DefaultWalkMode, DefaultWalkerCount, DefaultTrailLength)
+	walk.SetSeed(cfg.Seed)
+	walk.Reset(gridHeight, gridWidth, DefaultWalkMode, DefaultWalkerCount, DefaultTrailLength)
+	walk.SetPersistence(cfg.Persistence)
+	walk.SetMaxCluster(cfg.MaxCluster)
+	walk.SetBoundary(cfg.Boundary)
+	walk.SetConnectivity(cfg.Connectivity)
+	walk.SetObstacles(cfg.Obstacles)
+
 	model := Model{
-		walk:          NewRandomWalk(gridHeight, gridWidth, DefaultWalkMode, DefaultWalkerCount, DefaultTrailLength),
+		walk:          walk,
 		language:      cfg.Language,
 		mode:          DefaultWalkMode,
 		walkerCount:   DefaultWalkerCount,
@@ -82,7 +96,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyPress(msg)
 	case tickMsg:
 		m.logger.Debug("Tick", "time", msg)
-		return m.handleTick()
+		return m.handleTick(time.Time(msg))
 	}
 	return m, nil
 }
@@ -113,6 +127,8 @@ func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 
 // handleKeyPress processes keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
+
 	switch msg.String() {
 	case "ctrl+c", "q", "esc":
 		return m, tea.Quit
@@ -134,14 +150,14 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.refreshRate = m.refreshRate * 2
 
 	case "m": // Cycle through walk modes
-		m.mode = WalkMode((int(m.mode) + 1) % 6) // We have 6 modes
+		m.mode = WalkMode((int(m.mode) + 1) % 9) // We have 9 modes
 		m.walk.Reset(m.gridHeight, m.gridWidth, m.mode, m.walkerCount, m.trailLength)
 		m.currentStep = 0
 
 	case "w": // Increase walker count (for multi-walker modes)
 		if m.walkerCount < MaxWalkerCount {
 			m.walkerCount++
-			if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion {
+			if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion || m.mode == ModeDLA {
 				m.walk.Reset(m.gridHeight, m.gridWidth, m.mode, m.walkerCount, m.trailLength)
 				m.currentStep = 0
 			}
@@ -150,12 +166,22 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "W": // Decrease walker count (for multi-walker modes)
 		if m.walkerCount > 1 {
 			m.walkerCount--
-			if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion {
+			if m.mode == ModeMultiWalker || m.mode == ModeBrownianMotion || m.mode == ModeDLA {
 				m.walk.Reset(m.gridHeight, m.gridWidth, m.mode, m.walkerCount, m.trailLength)
 				m.currentStep = 0
 			}
 		}
 
+	case "p": // Increase persistence (correlated mode)
+		if m.mode == ModeCorrelated {
+			m.walk.SetPersistence(math.Round((m.walk.GetPersistence()+PersistenceStep)*100) / 100)
+		}
+
+	case "P": // Decrease persistence (correlated mode)
+		if m.mode == ModeCorrelated {
+			m.walk.SetPersistence(math.Round((m.walk.GetPersistence()-PersistenceStep)*100) / 100)
+		}
+
 	case "t": // Increase trail length
 		if m.trailLength < MaxTrailLength {
 			m.trailLength += 10
@@ -170,6 +196,17 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.currentStep = 0
 		}
 
+	case "b": // Cycle through boundary types
+		m.walk.SetBoundary(m.walk.GetBoundary().Next())
+
+	case "c": // Toggle between 4 and 8 directions
+		m.walk.SetConnectivity(m.walk.GetConnectivity().Next())
+
+	case "e": // Export statistics while paused
+		if m.paused {
+			m.exportStats()
+		}
+
 	case "r": // Reset simulation
 		m.currentStep = 0
 		m.walk.Reset(m.gridHeight, m.gridWidth, m.mode, m.walkerCount, m.trailLength)
@@ -178,15 +215,29 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// exportStats writes the walk statistics to a timestamped JSON file and
+// reports the result in the status line
+func (m *Model) exportStats() {
+	path := m.walk.StatsFileName(time.Now())
+	if err := m.walk.ExportStats(path); err != nil {
+		m.logger.Error("Failed to export stats", "path", path, "error", err)
+		m.notice = fmt.Sprintf(m.exportFailedLabel(), err)
+		return
+	}
+	m.notice = fmt.Sprintf(m.exportedLabel(), path)
+}
+
 // handleTick processes timer ticks
-func (m Model) handleTick() (tea.Model, tea.Cmd) {
+func (m Model) handleTick(tick time.Time) (tea.Model, tea.Cmd) {
 	// Check if we should continue running (only update when not paused)
 	if !m.paused && m.walk.Step() {
 		m.currentStep = m.walk.GetSteps()
 	}
 
-	// Continue ticking only if not quitting
-	return m, tea.Tick(m.refreshRate, func(t time.Time) tea.Msg {
+	// Continue ticking, scheduled from when this tick fired so time spent
+	// stepping does not stretch the interval
+	delay := pkg.NextTickDelay(m.refreshRate, MinRefreshRate, time.Since(tick))
+	return m, tea.Tick(delay, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -212,6 +263,8 @@ func (m *Model) RenderGrid() string {
 	m.gridBuffer.Reset()
 	grid := m.walk.GetGrid()
 	trails := m.walk.GetTrails()
+	aggregate := m.walk.GetAggregate()
+	obstacles := m.walk.GetObstacles()
 	walkers := m.walk.GetWalkers()
 
 	if len(grid) == 0 {
@@ -220,7 +273,8 @@ func (m *Model) RenderGrid() string {
 
 	// Pre-calculate styled strings to avoid repeated lookups
 	emptyStr := m.renderOptions.emptyStyled
-	trailStr := m.renderOptions.trailStyled
+	clusterStr := m.renderOptions.clusterStyled
+	obstacleStr := m.renderOptions.obstacleStyled
 
 	// Create walker styled strings
 	walkerStyles := make(map[int]string)
@@ -239,12 +293,18 @@ func (m *Model) RenderGrid() string {
 
 		// Render cells in the row with optimized string operations
 		for j, cell := range row {
-			if cell > 0 {
+			if obstacles[i][j] {
+				// Wall at this position
+				m.gridBuffer.WriteString(obstacleStr)
+			} else if cell > 0 {
 				// Walker at this position
 				m.gridBuffer.WriteString(walkerStyles[cell])
+			} else if aggregate[i][j] {
+				// DLA cluster cell
+				m.gridBuffer.WriteString(clusterStr)
 			} else if trails[i][j] > 0 {
-				// Trail at this position
-				m.gridBuffer.WriteString(trailStr)
+				// Trail at this position, faded by age
+				m.gridBuffer.WriteString(m.renderOptions.trailStyledFor(trails[i][j]))
 			} else {
 				// Empty cell
 				m.gridBuffer.WriteString(emptyStr)
@@ -4,12 +4,13 @@ import (
 	"log/slog"
 	"math"
 	"math/rand/v2"
+	"slices"
 	"time"
 )
 
-// Position represents a 2D position
+// Position represents a grid position; Z is only used by the 3D walk
 type Position struct {
-	X, Y int
+	X, Y, Z int
 }
 
 // Walker represents a single walker
@@ -19,60 +20,101 @@ type Walker struct {
 	Trail    []Position
 	Color    string
 	Visited  map[Position]bool // For self-avoiding walk
+	Heading  Direction         // Direction of the last step, for correlated walk
+
+	Start        Position // Position at the start of the walk
+	Displacement Position // Unwrapped displacement from Start
+	PathLength   int      // Moves made since Start; blocked moves do not count
+
+	Stuck bool // Joined the DLA cluster with no replacement released
 }
 
 // RandomWalk represents the random walk simulation
 type RandomWalk struct {
-	grid        [][]int // Grid to store walker IDs (0 = empty, >0 = walker ID)
-	trails      [][]int // Grid to store trail intensities
+	grid        [][]int  // Grid to store walker IDs (0 = empty, >0 = walker ID)
+	trails      [][]int  // Grid to store trail intensities
+	aggregate   [][]bool // DLA cluster cells
+	obstacles   [][]bool // Wall cells, built from obstacleMap for the current size
+	obstacleMap [][]bool // Wall layout as loaded, anchored at the top-left corner
+	depth       [][]int  // Depth of the point drawn in each cell in 3D mode, -1 for none
 	walkers     []*Walker
 	rows        int
 	cols        int
 	steps       int
 	mode        WalkMode
 	trailLength int
+	persistence float64 // Probability of keeping the previous direction in correlated mode
+	clusterSize int     // Number of DLA cluster cells
+	maxCluster  int     // DLA cluster size at which no new walkers are released
 	rng         *rand.Rand
+	seed        int64 // Seed restored by every Init, 0 for time-based
+
+	boundary     BoundaryType // How walkers behave at the grid edges
+	connectivity Connectivity // Whether walkers also step diagonally
+
+	boundsMin Position // Smallest displacement reached in 3D mode
+	boundsMax Position // Largest displacement reached in 3D mode
 }
 
 // NewRandomWalk creates a new random walk instance
 func NewRandomWalk(rows, cols int, mode WalkMode, walkerCount int, trailLength int) *RandomWalk {
 	slog.Debug("NewRandomWalk", "rows", rows, "cols", cols, "mode", mode, "walkerCount", walkerCount, "trailLength", trailLength)
 
-	// Use time-based seeding for randomization
-	// #nosec G115 - Conversion is safe for our use case
-	seed := uint64(time.Now().UnixNano())
-	// #nosec G404 - Using math/rand for simulation, not cryptography
-	rng := rand.New(rand.NewPCG(seed, seed))
-
 	rw := &RandomWalk{
 		rows:        rows,
 		cols:        cols,
 		mode:        mode,
 		trailLength: trailLength,
+		persistence: DefaultPersistence,
+		maxCluster:  DefaultMaxCluster,
 		steps:       0,
-		rng:         rng,
+		rng:         newRand(0),
 	}
 	rw.Init(walkerCount)
 	return rw
 }
 
+// newRand returns a random source for seed, seeded from the clock when seed is 0
+func newRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	// #nosec G115 - Conversion is safe for our use case
+	s := uint64(seed)
+	// #nosec G404 - Using math/rand for simulation, not cryptography
+	return rand.New(rand.NewPCG(s, s))
+}
+
 // Init initializes the random walk
 func (rw *RandomWalk) Init(walkerCount int) {
 	slog.Debug("RandomWalk Init", "rows", rw.rows, "cols", rw.cols, "mode", rw.mode, "walkerCount", walkerCount)
 
+	// A fixed seed makes every reset replay the same walk
+	if rw.seed != 0 {
+		rw.rng = newRand(rw.seed)
+	}
+
 	// Initialize grids
 	rw.grid = make([][]int, rw.rows)
 	rw.trails = make([][]int, rw.rows)
+	rw.aggregate = make([][]bool, rw.rows)
+	rw.depth = make([][]int, rw.rows)
 	for i := range rw.rows {
 		rw.grid[i] = make([]int, rw.cols)
 		rw.trails[i] = make([]int, rw.cols)
+		rw.aggregate[i] = make([]bool, rw.cols)
+		rw.depth[i] = make([]int, rw.cols)
 	}
+	rw.clusterSize = 0
+	rw.boundsMin = Position{}
+	rw.boundsMax = Position{}
+	rw.buildObstacles()
 
 	// Initialize walkers based on mode
 	rw.walkers = make([]*Walker, 0)
 
 	switch rw.mode {
-	case ModeSingleWalker, ModeTrailMode, ModeSelfAvoidingWalk, ModeLevyFlight:
+	case ModeSingleWalker, ModeTrailMode, ModeSelfAvoidingWalk, ModeLevyFlight, ModeCorrelated:
 		// Single walker starting at center
 		walker := &Walker{
 			ID:       1,
@@ -80,7 +122,9 @@ func (rw *RandomWalk) Init(walkerCount int) {
 			Trail:    make([]Position, 0, rw.trailLength),
 			Color:    DefaultWalkerColor,
 			Visited:  make(map[Position]bool),
+			Heading:  rw.randomDirection(),
 		}
+		walker.Start = walker.Position
 		walker.Visited[walker.Position] = true
 		rw.walkers = append(rw.walkers, walker)
 		rw.grid[walker.Position.Y][walker.Position.X] = walker.ID
@@ -105,12 +149,57 @@ func (rw *RandomWalk) Init(walkerCount int) {
 				Trail:    make([]Position, 0, rw.trailLength),
 				Color:    GetWalkerColor(i),
 				Visited:  make(map[Position]bool),
+				Heading:  rw.randomDirection(),
 			}
+			walker.Start = walker.Position
 			walker.Visited[walker.Position] = true
 			rw.walkers = append(rw.walkers, walker)
 			rw.grid[walker.Position.Y][walker.Position.X] = walker.ID
 		}
+
+	case ModeDLA:
+		// Sticky seed in the center, walkers released from the edges
+		seed := rw.freePosition(Position{X: rw.cols / 2, Y: rw.rows / 2})
+		rw.aggregate[seed.Y][seed.X] = true
+		rw.clusterSize = 1
+
+		if walkerCount > MaxWalkerCount {
+			walkerCount = MaxWalkerCount
+		}
+		if walkerCount < 1 {
+			walkerCount = DefaultWalkerCount
+		}
+
+		for i := 0; i < walkerCount; i++ {
+			walker := &Walker{
+				ID:      i + 1,
+				Trail:   make([]Position, 0, rw.trailLength),
+				Color:   GetWalkerColor(i),
+				Visited: make(map[Position]bool),
+			}
+			if !rw.releaseWalker(walker) {
+				break
+			}
+			rw.walkers = append(rw.walkers, walker)
+		}
+
+	case ModeWalk3D:
+		// Single walker starting at the center of the cube
+		center := rw.cubeSide() / 2
+		walker := &Walker{
+			ID:       1,
+			Position: Position{X: center, Y: center, Z: center},
+			Trail:    make([]Position, 0, rw.trailLength),
+			Color:    DefaultWalkerColor,
+			Visited:  make(map[Position]bool),
+		}
+		walker.Start = walker.Position
+		walker.Visited[walker.Position] = true
+		rw.walkers = append(rw.walkers, walker)
+		rw.project3D()
 	}
+
+	rw.relocateWalkers()
 }
 
 // Step advances the random walk by one step
@@ -118,6 +207,9 @@ func (rw *RandomWalk) Step() bool {
 	for _, walker := range rw.walkers {
 		rw.moveWalker(walker)
 	}
+	if rw.mode == ModeDLA {
+		rw.walkers = slices.DeleteFunc(rw.walkers, func(w *Walker) bool { return w.Stuck })
+	}
 
 	rw.steps++
 	rw.updateTrails()
@@ -127,19 +219,31 @@ func (rw *RandomWalk) Step() bool {
 
 // moveWalker moves a single walker according to the walk mode
 func (rw *RandomWalk) moveWalker(walker *Walker) {
+	if rw.mode == ModeWalk3D {
+		rw.moveWalker3D(walker)
+		return
+	}
+
 	// Clear current position
 	if rw.grid[walker.Position.Y][walker.Position.X] == walker.ID {
 		rw.grid[walker.Position.Y][walker.Position.X] = 0
 	}
 
 	// Add current position to trail
-	if rw.mode == ModeTrailMode || rw.mode == ModeBrownianMotion {
+	if rw.mode == ModeTrailMode || rw.mode == ModeBrownianMotion || rw.mode == ModeCorrelated {
 		walker.Trail = append(walker.Trail, walker.Position)
 		if len(walker.Trail) > rw.trailLength {
 			walker.Trail = walker.Trail[1:]
 		}
 	}
 
+	// The cluster may have grown next to the walker since its last move;
+	// stick in place rather than stepping onto a cluster cell
+	if rw.mode == ModeDLA && rw.touchesAggregate(walker.Position) {
+		rw.stick(walker)
+		return
+	}
+
 	// Calculate next position based on mode
 	var newPos Position
 
@@ -154,10 +258,18 @@ func (rw *RandomWalk) moveWalker(walker *Walker) {
 	case ModeLevyFlight:
 		newPos = rw.getLevyFlightNextPosition(walker)
 
+	case ModeCorrelated:
+		walker.Heading = rw.getCorrelatedDirection(walker)
+		newPos = rw.applyDirection(walker.Position, walker.Heading)
+
 	case ModeBrownianMotion:
 		// Brownian motion with smaller steps
 		angle := rw.rng.Float64() * 2 * math.Pi
 		distance := rw.rng.Float64() * 2
+		if rw.connectivity == ConnectivityFour {
+			// Step along one axis only
+			angle = math.Round(angle/(math.Pi/2)) * math.Pi / 2
+		}
 		dx := int(math.Round(distance * math.Cos(angle)))
 		dy := int(math.Round(distance * math.Sin(angle)))
 		newPos = Position{
@@ -172,18 +284,155 @@ func (rw *RandomWalk) moveWalker(walker *Walker) {
 		newPos = rw.applyDirection(walker.Position, dir)
 	}
 
-	// Wrap around boundaries
-	newPos.X = (newPos.X + rw.cols) % rw.cols
-	newPos.Y = (newPos.Y + rw.rows) % rw.rows
+	// Keep the move on the grid
+	pos, inside := rw.applyBoundary(newPos)
+	if !inside {
+		rw.respawn(walker)
+		return
+	}
+
+	// Walls block the move; the walker stays where it is
+	if rw.isObstacle(pos) {
+		rw.grid[walker.Position.Y][walker.Position.X] = walker.ID
+		return
+	}
+
+	step := rw.displacementStep(walker.Position, newPos, pos)
+	walker.Displacement.X += step.X
+	walker.Displacement.Y += step.Y
+	walker.PathLength++
 
 	// Update walker position
-	walker.Position = newPos
-	walker.Visited[newPos] = true
+	walker.Position = pos
+	walker.Visited[pos] = true
+
+	// Stick to the cluster when touching it
+	if rw.mode == ModeDLA && rw.touchesAggregate(pos) {
+		rw.stick(walker)
+		return
+	}
 
 	// Update grid
 	rw.grid[walker.Position.Y][walker.Position.X] = walker.ID
 }
 
+// boundCoord applies the boundary to coordinate v on an axis of n cells. It
+// returns the coordinate on the grid, or false if the walker left the grid
+// and is absorbed.
+func (rw *RandomWalk) boundCoord(v, n int) (int, bool) {
+	if v >= 0 && v < n {
+		return v, true
+	}
+	switch rw.boundary {
+	case BoundaryReflect:
+		// Mirror about the edge cell, clamping jumps longer than the grid
+		if v < 0 {
+			v = -v
+		} else {
+			v = 2*(n-1) - v
+		}
+		return max(min(v, n-1), 0), true
+	case BoundaryAbsorb:
+		return v, false
+	default:
+		return (v%n + n) % n, true
+	}
+}
+
+// applyBoundary moves pos onto the grid, reporting false if the walker is
+// absorbed
+func (rw *RandomWalk) applyBoundary(pos Position) (Position, bool) {
+	x, insideX := rw.boundCoord(pos.X, rw.cols)
+	y, insideY := rw.boundCoord(pos.Y, rw.rows)
+	return Position{X: x, Y: y}, insideX && insideY
+}
+
+// displacementStep returns the step a walker made from from, given the
+// target raw before applying the boundary and the cell to it ended up in.
+// Wrapping uses the raw step, so displacement is not distorted by crossing
+// an edge; a reflected walker really turned around.
+func (rw *RandomWalk) displacementStep(from, raw, to Position) Position {
+	if rw.boundary == BoundaryWrap {
+		to = raw
+	}
+	return Position{X: to.X - from.X, Y: to.Y - from.Y, Z: to.Z - from.Z}
+}
+
+// respawn returns a walker absorbed by the boundary to where it started. DLA
+// walkers are released from the edge again instead, or retired if there is
+// no room.
+func (rw *RandomWalk) respawn(walker *Walker) {
+	if rw.mode == ModeDLA {
+		if !rw.releaseWalker(walker) {
+			walker.Stuck = true
+		}
+		return
+	}
+
+	walker.Position = walker.Start
+	walker.Displacement = Position{}
+	walker.PathLength = 0
+	walker.Visited[walker.Position] = true
+	if rw.mode != ModeWalk3D {
+		rw.grid[walker.Position.Y][walker.Position.X] = walker.ID
+	}
+}
+
+// touchesAggregate reports whether pos is next to (or on) a DLA cluster cell
+func (rw *RandomWalk) touchesAggregate(pos Position) bool {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			y := (pos.Y + dy + rw.rows) % rw.rows
+			x := (pos.X + dx + rw.cols) % rw.cols
+			if rw.aggregate[y][x] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stick adds the walker's cell to the DLA cluster and releases it again from
+// the edge, or retires it once the cluster is full
+func (rw *RandomWalk) stick(walker *Walker) {
+	rw.aggregate[walker.Position.Y][walker.Position.X] = true
+	rw.clusterSize++
+
+	if rw.clusterSize >= rw.maxCluster || !rw.releaseWalker(walker) {
+		walker.Stuck = true
+	}
+}
+
+// releaseWalker places the walker on a random edge cell away from the DLA
+// cluster, reporting false if no such cell was found
+func (rw *RandomWalk) releaseWalker(walker *Walker) bool {
+	const attempts = 100
+	for range attempts {
+		var pos Position
+		switch rw.rng.IntN(4) {
+		case 0:
+			pos = Position{X: rw.rng.IntN(rw.cols), Y: 0}
+		case 1:
+			pos = Position{X: rw.rng.IntN(rw.cols), Y: rw.rows - 1}
+		case 2:
+			pos = Position{X: 0, Y: rw.rng.IntN(rw.rows)}
+		default:
+			pos = Position{X: rw.cols - 1, Y: rw.rng.IntN(rw.rows)}
+		}
+		if rw.touchesAggregate(pos) || rw.isObstacle(pos) {
+			continue
+		}
+
+		walker.Position = pos
+		walker.Start = pos
+		walker.Displacement = Position{}
+		walker.PathLength = 0
+		rw.grid[pos.Y][pos.X] = walker.ID
+		return true
+	}
+	return false
+}
+
 // getSelfAvoidingNextPosition returns the next position for self-avoiding walk
 func (rw *RandomWalk) getSelfAvoidingNextPosition(walker *Walker) Position {
 	directions := rw.getDirections()
@@ -191,12 +440,10 @@ func (rw *RandomWalk) getSelfAvoidingNextPosition(walker *Walker) Position {
 
 	// Find all valid moves (not visited positions)
 	for _, dir := range directions {
-		newPos := rw.applyDirection(walker.Position, dir)
-		// Wrap around
-		newPos.X = (newPos.X + rw.cols) % rw.cols
-		newPos.Y = (newPos.Y + rw.rows) % rw.rows
+		newPos, inside := rw.applyBoundary(rw.applyDirection(walker.Position, dir))
 
-		if !walker.Visited[newPos] {
+		// Leaving the grid is allowed; the walker is absorbed
+		if !inside || (!walker.Visited[newPos] && !rw.isObstacle(newPos)) {
 			validMoves = append(validMoves, dir)
 		}
 	}
@@ -206,13 +453,9 @@ func (rw *RandomWalk) getSelfAvoidingNextPosition(walker *Walker) Position {
 		return walker.Position
 	}
 
-	// Choose random valid move
+	// Choose random valid move; the caller applies the boundary
 	dir := validMoves[rw.rng.IntN(len(validMoves))]
-	newPos := rw.applyDirection(walker.Position, dir)
-	newPos.X = (newPos.X + rw.cols) % rw.cols
-	newPos.Y = (newPos.Y + rw.rows) % rw.rows
-
-	return newPos
+	return rw.applyDirection(walker.Position, dir)
 }
 
 // getLevyFlightNextPosition returns the next position for Lévy flight
@@ -224,6 +467,10 @@ func (rw *RandomWalk) getLevyFlightNextPosition(walker *Walker) Position {
 	u := rw.rng.Float64()
 	if u > 0.9 { // 10% chance of long jump
 		distance := rw.rng.Float64() * float64(min(rw.rows, rw.cols)) / 4
+		if rw.connectivity == ConnectivityFour {
+			// Jump along one axis only
+			angle = math.Round(angle/(math.Pi/2)) * math.Pi / 2
+		}
 		dx := int(math.Round(distance * math.Cos(angle)))
 		dy := int(math.Round(distance * math.Sin(angle)))
 		return Position{
@@ -238,9 +485,36 @@ func (rw *RandomWalk) getLevyFlightNextPosition(walker *Walker) Position {
 	return rw.applyDirection(walker.Position, dir)
 }
 
-// getDirections returns available directions based on walk mode
+// getCorrelatedDirection returns the next direction for a correlated walk:
+// the previous direction with the persistence probability, otherwise one of
+// the other directions chosen uniformly
+func (rw *RandomWalk) getCorrelatedDirection(walker *Walker) Direction {
+	if rw.rng.Float64() < rw.persistence {
+		return walker.Heading
+	}
+
+	directions := rw.getDirections()
+	others := make([]Direction, 0, len(directions)-1)
+	for _, dir := range directions {
+		if dir != walker.Heading {
+			others = append(others, dir)
+		}
+	}
+	return others[rw.rng.IntN(len(others))]
+}
+
+// randomDirection returns a uniformly chosen direction
+func (rw *RandomWalk) randomDirection() Direction {
+	directions := rw.getDirections()
+	return directions[rw.rng.IntN(len(directions))]
+}
+
+// getDirections returns the directions a walker can step in: the four
+// orthogonal ones, plus the four diagonal ones unless connectivity is four
 func (rw *RandomWalk) getDirections() []Direction {
-	// For most modes, use 8 directions
+	if rw.connectivity == ConnectivityFour {
+		return []Direction{DirectionUp, DirectionDown, DirectionLeft, DirectionRight}
+	}
 	return []Direction{
 		DirectionUp, DirectionDown, DirectionLeft, DirectionRight,
 		DirectionUpLeft, DirectionUpRight, DirectionDownLeft, DirectionDownRight,
@@ -273,6 +547,11 @@ func (rw *RandomWalk) applyDirection(pos Position, dir Direction) Position {
 
 // updateTrails updates the trail intensity grid
 func (rw *RandomWalk) updateTrails() {
+	if rw.mode == ModeWalk3D {
+		rw.project3D()
+		return
+	}
+
 	// Decay existing trails
 	for i := range rw.rows {
 		for j := range rw.cols {
@@ -285,7 +564,7 @@ func (rw *RandomWalk) updateTrails() {
 	// Add current walker trails
 	for _, walker := range rw.walkers {
 		for i, pos := range walker.Trail {
-			intensity := (i + 1) * 255 / len(walker.Trail) // Gradient intensity
+			intensity := (i + 1) * MaxTrailIntensity / len(walker.Trail) // Gradient intensity
 			if intensity > rw.trails[pos.Y][pos.X] {
 				rw.trails[pos.Y][pos.X] = intensity
 			}
@@ -303,16 +582,184 @@ func (rw *RandomWalk) GetTrails() [][]int {
 	return rw.trails
 }
 
+// SetObstacles sets the wall layout, where true marks a wall. The layout is
+// anchored at the top-left corner; cells outside it are free. Walkers on a
+// wall are moved to the nearest free cell.
+func (rw *RandomWalk) SetObstacles(obstacles [][]bool) {
+	rw.obstacleMap = obstacles
+	rw.buildObstacles()
+	rw.relocateWalkers()
+}
+
+// GetObstacles returns the wall grid
+func (rw *RandomWalk) GetObstacles() [][]bool {
+	return rw.obstacles
+}
+
+// buildObstacles crops or pads the wall layout to the grid size. Walls are
+// 2D, so the 3D walk has none.
+func (rw *RandomWalk) buildObstacles() {
+	rw.obstacles = make([][]bool, rw.rows)
+	for i := range rw.rows {
+		rw.obstacles[i] = make([]bool, rw.cols)
+		if i < len(rw.obstacleMap) && rw.mode != ModeWalk3D {
+			copy(rw.obstacles[i], rw.obstacleMap[i])
+		}
+	}
+}
+
+// isObstacle reports whether pos, wrapped onto the grid, is a wall
+func (rw *RandomWalk) isObstacle(pos Position) bool {
+	x := (pos.X%rw.cols + rw.cols) % rw.cols
+	y := (pos.Y%rw.rows + rw.rows) % rw.rows
+	return rw.obstacles[y][x]
+}
+
+// freePosition returns pos if it is not a wall, otherwise the nearest free
+// cell; pos itself if the whole grid is walled
+func (rw *RandomWalk) freePosition(pos Position) Position {
+	for r := range max(rw.rows, rw.cols) {
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if max(abs(dx), abs(dy)) != r {
+					continue // Only the ring at distance r
+				}
+				candidate := Position{
+					X: ((pos.X+dx)%rw.cols + rw.cols) % rw.cols,
+					Y: ((pos.Y+dy)%rw.rows + rw.rows) % rw.rows,
+				}
+				if !rw.obstacles[candidate.Y][candidate.X] {
+					return candidate
+				}
+			}
+		}
+	}
+	return pos
+}
+
+// relocateWalkers moves walkers standing on a wall to the nearest free cell.
+// Walls are 2D, so the 3D walk ignores them.
+func (rw *RandomWalk) relocateWalkers() {
+	if rw.mode == ModeWalk3D {
+		return
+	}
+	for _, walker := range rw.walkers {
+		if !rw.isObstacle(walker.Position) {
+			continue
+		}
+		if rw.grid[walker.Position.Y][walker.Position.X] == walker.ID {
+			rw.grid[walker.Position.Y][walker.Position.X] = 0
+		}
+		walker.Position = rw.freePosition(walker.Position)
+		walker.Start = walker.Position
+		walker.Displacement = Position{}
+		walker.PathLength = 0
+		walker.Visited = map[Position]bool{walker.Position: true}
+		rw.grid[walker.Position.Y][walker.Position.X] = walker.ID
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// GetAggregate returns the DLA cluster grid
+func (rw *RandomWalk) GetAggregate() [][]bool {
+	return rw.aggregate
+}
+
+// GetClusterSize returns the number of DLA cluster cells
+func (rw *RandomWalk) GetClusterSize() int {
+	return rw.clusterSize
+}
+
+// SetMaxCluster sets the DLA cluster size at which no new walkers are released
+func (rw *RandomWalk) SetMaxCluster(size int) {
+	rw.maxCluster = max(size, 1)
+}
+
+// GetMaxCluster returns the DLA cluster size limit
+func (rw *RandomWalk) GetMaxCluster() int {
+	return rw.maxCluster
+}
+
 // GetWalkers returns all walkers
 func (rw *RandomWalk) GetWalkers() []*Walker {
 	return rw.walkers
 }
 
+// GetMSD returns the mean squared displacement of the walkers from their
+// start positions, measured without boundary wrapping
+func (rw *RandomWalk) GetMSD() float64 {
+	if len(rw.walkers) == 0 {
+		return 0
+	}
+	total := 0
+	for _, walker := range rw.walkers {
+		d := walker.Displacement
+		total += d.X*d.X + d.Y*d.Y + d.Z*d.Z
+	}
+	return float64(total) / float64(len(rw.walkers))
+}
+
+// SetBoundary sets how walkers behave at the grid edges
+func (rw *RandomWalk) SetBoundary(boundary BoundaryType) {
+	rw.boundary = boundary
+}
+
+// SetConnectivity sets whether walkers also step diagonally. Walkers heading
+// diagonally turn to a new direction when diagonal steps are disabled.
+func (rw *RandomWalk) SetConnectivity(connectivity Connectivity) {
+	rw.connectivity = connectivity
+	directions := rw.getDirections()
+	for _, walker := range rw.walkers {
+		if !slices.Contains(directions, walker.Heading) {
+			walker.Heading = rw.randomDirection()
+		}
+	}
+}
+
+// GetConnectivity returns whether walkers also step diagonally
+func (rw *RandomWalk) GetConnectivity() Connectivity {
+	return rw.connectivity
+}
+
+// GetBoundary returns how walkers behave at the grid edges
+func (rw *RandomWalk) GetBoundary() BoundaryType {
+	return rw.boundary
+}
+
 // GetSteps returns the number of steps taken
 func (rw *RandomWalk) GetSteps() int {
 	return rw.steps
 }
 
+// SetSeed sets the random seed used from the next Init or Reset on; 0 seeds
+// from the clock
+func (rw *RandomWalk) SetSeed(seed int64) {
+	rw.seed = seed
+}
+
+// GetSeed returns the random seed, 0 for time-based
+func (rw *RandomWalk) GetSeed() int64 {
+	return rw.seed
+}
+
+// SetPersistence sets the probability of keeping the previous direction in
+// correlated mode, clamped to [0, 1]
+func (rw *RandomWalk) SetPersistence(persistence float64) {
+	rw.persistence = min(max(persistence, 0), 1)
+}
+
+// GetPersistence returns the correlated walk persistence
+func (rw *RandomWalk) GetPersistence() float64 {
+	return rw.persistence
+}
+
 // Reset resets the random walk
 func (rw *RandomWalk) Reset(rows, cols int, mode WalkMode, walkerCount int, trailLength int) {
 	slog.Debug("RandomWalk Reset", "rows", rows, "cols", cols, "mode", mode, "walkerCount", walkerCount, "trailLength", trailLength)
@@ -0,0 +1,125 @@
+package main
+
+// The 3D walk moves in a cube of cubeSide cells per axis, with y
+// pointing up. Cells are projected isometrically, viewed from the (+x, +y, +z)
+// corner: a unit along x moves two columns right and half a row down, a unit
+// along z two columns left and half a row down, and a unit along y one row up.
+// Terminal cells are about twice as tall as wide, so this is the classic 2:1
+// isometric view.
+// A depth buffer keeps the nearest point of each screen cell, and the trail
+// intensity of that point encodes its depth.
+
+// directions3D are the six unit steps of the 3D walk
+var directions3D = []Position{
+	{X: 1}, {X: -1},
+	{Y: 1}, {Y: -1},
+	{Z: 1}, {Z: -1},
+}
+
+// cubeSide returns the side of the cube the 3D walk moves in: the largest
+// odd size whose projection fits the grid
+func (rw *RandomWalk) cubeSide() int {
+	// A cube of side 2h+1 projects onto 4h+1 rows and 8h+1 columns
+	h := max(min((rw.rows-1)/4, (rw.cols-1)/8), 1)
+	return 2*h + 1
+}
+
+// moveWalker3D moves the walker one step along a random axis
+func (rw *RandomWalk) moveWalker3D(walker *Walker) {
+	walker.Trail = append(walker.Trail, walker.Position)
+	if len(walker.Trail) > rw.trailLength {
+		walker.Trail = walker.Trail[1:]
+	}
+
+	dir := directions3D[rw.rng.IntN(len(directions3D))]
+	raw := Position{
+		X: walker.Position.X + dir.X,
+		Y: walker.Position.Y + dir.Y,
+		Z: walker.Position.Z + dir.Z,
+	}
+
+	side := rw.cubeSide()
+	x, insideX := rw.boundCoord(raw.X, side)
+	y, insideY := rw.boundCoord(raw.Y, side)
+	z, insideZ := rw.boundCoord(raw.Z, side)
+	if !insideX || !insideY || !insideZ {
+		rw.respawn(walker)
+		return
+	}
+	pos := Position{X: x, Y: y, Z: z}
+
+	step := rw.displacementStep(walker.Position, raw, pos)
+	walker.Displacement.X += step.X
+	walker.Displacement.Y += step.Y
+	walker.Displacement.Z += step.Z
+	walker.PathLength++
+	rw.updateBounds(walker.Displacement)
+
+	walker.Position = pos
+	walker.Visited[walker.Position] = true
+}
+
+// updateBounds grows the 3D bounding box of the walk to include d
+func (rw *RandomWalk) updateBounds(d Position) {
+	rw.boundsMin = Position{X: min(rw.boundsMin.X, d.X), Y: min(rw.boundsMin.Y, d.Y), Z: min(rw.boundsMin.Z, d.Z)}
+	rw.boundsMax = Position{X: max(rw.boundsMax.X, d.X), Y: max(rw.boundsMax.Y, d.Y), Z: max(rw.boundsMax.Z, d.Z)}
+}
+
+// GetBounds3D returns the size of the box enclosing the unwrapped 3D walk
+func (rw *RandomWalk) GetBounds3D() Position {
+	return Position{
+		X: rw.boundsMax.X - rw.boundsMin.X + 1,
+		Y: rw.boundsMax.Y - rw.boundsMin.Y + 1,
+		Z: rw.boundsMax.Z - rw.boundsMin.Z + 1,
+	}
+}
+
+// project maps a cube cell to its screen row and column and its depth,
+// larger depths being nearer to the viewer
+func (rw *RandomWalk) project(p Position) (int, int, int) {
+	row := rw.rows/2 + (p.X+p.Z)/2 - p.Y
+	col := rw.cols/2 + 2*(p.X-p.Z)
+	return row, col, p.X + p.Y + p.Z
+}
+
+// project3D redraws the grid and trails from the 3D walkers, drawing each
+// trail point and walker only where it is nearer than what is already there
+func (rw *RandomWalk) project3D() {
+	for i := range rw.rows {
+		for j := range rw.cols {
+			rw.grid[i][j] = 0
+			rw.trails[i][j] = 0
+			rw.depth[i][j] = -1
+		}
+	}
+
+	maxDepth := 3 * (rw.cubeSide() - 1)
+	for _, walker := range rw.walkers {
+		for _, pos := range walker.Trail {
+			row, col, depth := rw.project(pos)
+			if rw.nearer(row, col, depth) {
+				rw.depth[row][col] = depth
+				rw.grid[row][col] = 0
+				rw.trails[row][col] = MinDepthIntensity + (MaxTrailIntensity-MinDepthIntensity)*depth/max(maxDepth, 1)
+			}
+		}
+
+		// The walker wins ties with its own trail
+		row, col, depth := rw.project(walker.Position)
+		if rw.nearer(row, col, depth) || (rw.onGrid(row, col) && rw.depth[row][col] == depth) {
+			rw.depth[row][col] = depth
+			rw.grid[row][col] = walker.ID
+		}
+	}
+}
+
+// nearer reports whether depth is on the grid and nearer than the point
+// already drawn at (row, col)
+func (rw *RandomWalk) nearer(row, col, depth int) bool {
+	return rw.onGrid(row, col) && depth > rw.depth[row][col]
+}
+
+// onGrid reports whether (row, col) is inside the grid
+func (rw *RandomWalk) onGrid(row, col int) bool {
+	return row >= 0 && row < rw.rows && col >= 0 && col < rw.cols
+}
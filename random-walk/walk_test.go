@@ -1,6 +1,8 @@
 package main
 
 import (
+	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -19,6 +21,9 @@ func TestNewRandomWalk(t *testing.T) {
 		{"Brownian Motion", ModeBrownianMotion},
 		{"Self-Avoiding Walk", ModeSelfAvoidingWalk},
 		{"Lévy Flight", ModeLevyFlight},
+		{"Correlated", ModeCorrelated},
+		{"DLA", ModeDLA},
+		{"3D Walk", ModeWalk3D},
 	}
 
 	for _, tt := range tests {
@@ -43,7 +48,7 @@ func TestNewRandomWalk(t *testing.T) {
 
 			// Check walker count based on mode
 			expectedWalkers := 1
-			if tt.mode == ModeMultiWalker || tt.mode == ModeBrownianMotion {
+			if tt.mode == ModeMultiWalker || tt.mode == ModeBrownianMotion || tt.mode == ModeDLA {
 				expectedWalkers = walkerCount
 			}
 
@@ -181,6 +186,357 @@ func TestReset(t *testing.T) {
 	}
 }
 
+// walkPositions returns the positions of every walker after each of n steps
+func walkPositions(rw *RandomWalk, n int) [][]Position {
+	steps := make([][]Position, n)
+	for i := range n {
+		rw.Step()
+		for _, walker := range rw.GetWalkers() {
+			steps[i] = append(steps[i], walker.Position)
+		}
+	}
+	return steps
+}
+
+func TestSeedReproducible(t *testing.T) {
+	for _, mode := range []WalkMode{ModeMultiWalker, ModeLevyFlight, ModeWalk3D} {
+		a := NewRandomWalk(20, 30, mode, 5, 50)
+		a.SetSeed(42)
+		a.Reset(20, 30, mode, 5, 50)
+		b := NewRandomWalk(20, 30, mode, 5, 50)
+		b.SetSeed(42)
+		b.Reset(20, 30, mode, 5, 50)
+
+		first := walkPositions(a, 100)
+		if !reflect.DeepEqual(first, walkPositions(b, 100)) {
+			t.Errorf("%v: expected equal seeds to produce identical walks", mode)
+		}
+
+		// Reset replays the same walk
+		a.Reset(20, 30, mode, 5, 50)
+		if !reflect.DeepEqual(first, walkPositions(a, 100)) {
+			t.Errorf("%v: expected reset to replay the seeded walk", mode)
+		}
+
+		c := NewRandomWalk(20, 30, mode, 5, 50)
+		c.SetSeed(43)
+		c.Reset(20, 30, mode, 5, 50)
+		if reflect.DeepEqual(first, walkPositions(c, 100)) {
+			t.Errorf("%v: expected different seeds to produce different walks", mode)
+		}
+	}
+}
+
+func TestCorrelatedWalk(t *testing.T) {
+	rows, cols := 20, 20
+	rw := NewRandomWalk(rows, cols, ModeCorrelated, 1, 50)
+
+	// Full persistence keeps the initial heading forever
+	rw.SetPersistence(1)
+	walker := rw.GetWalkers()[0]
+	heading := walker.Heading
+	for i := 0; i < 50; i++ {
+		before := walker.Position
+		rw.Step()
+		expected := rw.applyDirection(before, heading)
+		expected.X = (expected.X + cols) % cols
+		expected.Y = (expected.Y + rows) % rows
+		if walker.Position != expected {
+			t.Fatalf("Step %d: expected position %v, got %v", i, expected, walker.Position)
+		}
+	}
+
+	// Zero persistence never repeats the previous direction
+	rw.SetPersistence(0)
+	for i := 0; i < 200; i++ {
+		previous := walker.Heading
+		rw.Step()
+		if walker.Heading == previous {
+			t.Fatalf("Step %d: direction %d repeated with zero persistence", i, previous)
+		}
+	}
+}
+
+func TestSetPersistenceClamps(t *testing.T) {
+	rw := NewRandomWalk(10, 10, ModeCorrelated, 1, 50)
+
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{0.5, 0.5},
+		{-0.3, 0},
+		{1.7, 1},
+	}
+
+	for _, tt := range tests {
+		rw.SetPersistence(tt.input)
+		if rw.GetPersistence() != tt.expected {
+			t.Errorf("SetPersistence(%v): expected %v, got %v", tt.input, tt.expected, rw.GetPersistence())
+		}
+	}
+}
+
+func TestMSD(t *testing.T) {
+	rows, cols := 10, 10
+	rw := NewRandomWalk(rows, cols, ModeCorrelated, 1, 50)
+	if rw.GetMSD() != 0 {
+		t.Errorf("Expected MSD 0 before any step, got %f", rw.GetMSD())
+	}
+
+	// Walk straight right across the boundary several times
+	rw.SetPersistence(1)
+	walker := rw.GetWalkers()[0]
+	walker.Heading = DirectionRight
+	steps := 3 * cols
+	for i := 0; i < steps; i++ {
+		rw.Step()
+	}
+
+	if walker.Position != walker.Start {
+		t.Errorf("Expected walker back at %v after wrapping, got %v", walker.Start, walker.Position)
+	}
+	if walker.Displacement != (Position{X: steps, Y: 0}) {
+		t.Errorf("Expected displacement (%d, 0), got %v", steps, walker.Displacement)
+	}
+	if expected := float64(steps * steps); rw.GetMSD() != expected {
+		t.Errorf("Expected MSD %f, got %f", expected, rw.GetMSD())
+	}
+}
+
+func TestBoundaryPushedPastEdge(t *testing.T) {
+	tests := []struct {
+		boundary     BoundaryType
+		position     Position
+		displacement int
+		pathLength   int
+	}{
+		{BoundaryWrap, Position{X: 0, Y: 5}, 5, 5},
+		{BoundaryReflect, Position{X: 8, Y: 5}, 3, 5},
+		{BoundaryAbsorb, Position{X: 5, Y: 5}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		rw := NewRandomWalk(10, 10, ModeCorrelated, 1, 50)
+		rw.SetBoundary(tt.boundary)
+
+		// Walk straight right from the center to the edge, then one step past it
+		rw.SetPersistence(1)
+		walker := rw.GetWalkers()[0]
+		walker.Heading = DirectionRight
+		for range 5 {
+			rw.Step()
+		}
+
+		name := tt.boundary.ToString(English)
+		if walker.Position != tt.position {
+			t.Errorf("%s: expected position %v, got %v", name, tt.position, walker.Position)
+		}
+		if walker.Displacement.X != tt.displacement || walker.PathLength != tt.pathLength {
+			t.Errorf("%s: expected displacement %d and path length %d, got %d and %d",
+				name, tt.displacement, tt.pathLength, walker.Displacement.X, walker.PathLength)
+		}
+		if rw.GetGrid()[tt.position.Y][tt.position.X] != walker.ID {
+			t.Errorf("%s: expected the walker on the grid at %v", name, tt.position)
+		}
+	}
+}
+
+func TestBoundCoord(t *testing.T) {
+	tests := []struct {
+		boundary BoundaryType
+		v        int
+		expected int
+		inside   bool
+	}{
+		{BoundaryWrap, -1, 9, true},
+		{BoundaryWrap, 12, 2, true},
+		{BoundaryReflect, -1, 1, true},
+		{BoundaryReflect, 10, 8, true},
+		{BoundaryReflect, 25, 0, true}, // A jump longer than the grid is clamped
+		{BoundaryAbsorb, -1, -1, false},
+		{BoundaryAbsorb, 9, 9, true},
+	}
+
+	rw := NewRandomWalk(10, 10, ModeSingleWalker, 1, 50)
+	for _, tt := range tests {
+		rw.SetBoundary(tt.boundary)
+		got, inside := rw.boundCoord(tt.v, 10)
+		if got != tt.expected || inside != tt.inside {
+			t.Errorf("%s: boundCoord(%d) = %d, %v; expected %d, %v",
+				tt.boundary.ToString(English), tt.v, got, inside, tt.expected, tt.inside)
+		}
+	}
+
+	if BoundaryAbsorb.Next() != BoundaryWrap {
+		t.Error("Expected the boundary cycle to wrap around to Wrap")
+	}
+}
+
+func TestGetDirectionsConnectivity(t *testing.T) {
+	rw := NewRandomWalk(10, 10, ModeCorrelated, 1, 50)
+	if got := len(rw.getDirections()); got != 8 {
+		t.Errorf("Expected 8 directions by default, got %d", got)
+	}
+
+	walker := rw.GetWalkers()[0]
+	walker.Heading = DirectionUpLeft
+	rw.SetConnectivity(ConnectivityFour)
+	directions := rw.getDirections()
+	if len(directions) != 4 {
+		t.Fatalf("Expected 4 directions, got %d", len(directions))
+	}
+	if !slices.Contains(directions, walker.Heading) {
+		t.Errorf("Expected the diagonal heading to be replaced, got %d", walker.Heading)
+	}
+
+	// Every step is orthogonal, so exactly one coordinate changes
+	rw.SetPersistence(0)
+	for i := range 100 {
+		before := walker.Position
+		rw.Step()
+		dx := (walker.Position.X - before.X + 10) % 10
+		dy := (walker.Position.Y - before.Y + 10) % 10
+		if (dx == 0) == (dy == 0) {
+			t.Fatalf("Step %d: expected an orthogonal step, moved from %v to %v", i, before, walker.Position)
+		}
+	}
+
+	if ConnectivityFour.Next() != ConnectivityEight {
+		t.Error("Expected the connectivity cycle to wrap around to 8 directions")
+	}
+}
+
+// Test that Brownian steps stay on the axes with 4 directions
+func TestBrownianConnectivityFour(t *testing.T) {
+	rw := NewRandomWalk(50, 50, ModeBrownianMotion, 1, 50)
+	rw.SetConnectivity(ConnectivityFour)
+	for i := range 200 {
+		before := make([]Position, len(rw.GetWalkers()))
+		for j, walker := range rw.GetWalkers() {
+			before[j] = walker.Position
+		}
+		rw.Step()
+		for j, walker := range rw.GetWalkers() {
+			if walker.Position.X != before[j].X && walker.Position.Y != before[j].Y {
+				t.Fatalf("Step %d: expected an axis step, moved from %v to %v", i, before[j], walker.Position)
+			}
+		}
+	}
+}
+
+func TestMSDAveragesWalkers(t *testing.T) {
+	rw := NewRandomWalk(10, 10, ModeMultiWalker, 2, 50)
+	walkers := rw.GetWalkers()
+	walkers[0].Displacement = Position{X: 3, Y: 4}
+	walkers[1].Displacement = Position{X: -1, Y: 0}
+
+	if rw.GetMSD() != 13 {
+		t.Errorf("Expected MSD 13, got %f", rw.GetMSD())
+	}
+}
+
+func TestDLA(t *testing.T) {
+	rows, cols := 15, 15
+	maxCluster := 20
+	rw := NewRandomWalk(rows, cols, ModeDLA, 3, 50)
+	rw.SetMaxCluster(maxCluster)
+
+	if rw.GetClusterSize() != 1 || !rw.GetAggregate()[rows/2][cols/2] {
+		t.Fatal("Expected a single seed cell in the center")
+	}
+	for _, walker := range rw.GetWalkers() {
+		p := walker.Position
+		if p.X != 0 && p.X != cols-1 && p.Y != 0 && p.Y != rows-1 {
+			t.Errorf("Expected walker %d released on an edge, got %v", walker.ID, p)
+		}
+	}
+
+	for i := 0; i < 100000 && len(rw.GetWalkers()) > 0; i++ {
+		rw.Step()
+	}
+
+	if rw.GetClusterSize() < maxCluster {
+		t.Errorf("Expected the cluster to reach %d cells, got %d", maxCluster, rw.GetClusterSize())
+	}
+	if len(rw.GetWalkers()) != 0 {
+		t.Errorf("Expected no walkers after the cluster is full, got %d", len(rw.GetWalkers()))
+	}
+
+	// Every cell of the cluster touches another one
+	aggregate := rw.GetAggregate()
+	count := 0
+	for y := range aggregate {
+		for x := range aggregate[y] {
+			if !aggregate[y][x] {
+				continue
+			}
+			count++
+			aggregate[y][x] = false
+			if !rw.touchesAggregate(Position{X: x, Y: y}) {
+				t.Errorf("Cluster cell (%d,%d) is disconnected", x, y)
+			}
+			aggregate[y][x] = true
+		}
+	}
+	if count != rw.GetClusterSize() {
+		t.Errorf("Expected %d cluster cells, counted %d", rw.GetClusterSize(), count)
+	}
+}
+
+// wallColumn returns an obstacle map with a wall along column x, open at row gap
+func wallColumn(rows, x, gap int) [][]bool {
+	obstacles := make([][]bool, rows)
+	for i := range obstacles {
+		obstacles[i] = make([]bool, x+1)
+		obstacles[i][x] = i != gap
+	}
+	return obstacles
+}
+
+func TestObstaclesBlockWalkers(t *testing.T) {
+	rows, cols := 12, 12
+	modes := []WalkMode{ModeSingleWalker, ModeMultiWalker, ModeBrownianMotion, ModeSelfAvoidingWalk, ModeLevyFlight, ModeCorrelated, ModeDLA}
+
+	for _, mode := range modes {
+		rw := NewRandomWalk(rows, cols, mode, 5, 50)
+		rw.SetObstacles(wallColumn(rows, cols/2, -1))
+
+		for i := 0; i < 500; i++ {
+			rw.Step()
+			for _, walker := range rw.GetWalkers() {
+				if rw.isObstacle(walker.Position) {
+					t.Fatalf("%s: walker %d on a wall at %v", mode.ToString(English), walker.ID, walker.Position)
+				}
+			}
+		}
+	}
+}
+
+func TestObstaclesRelocateWalkers(t *testing.T) {
+	rows, cols := 10, 10
+	rw := NewRandomWalk(rows, cols, ModeSingleWalker, 1, 50)
+
+	// The single walker starts in the center, which is now a wall
+	rw.SetObstacles(wallColumn(rows, cols/2, -1))
+	walker := rw.GetWalkers()[0]
+	if rw.isObstacle(walker.Position) {
+		t.Fatalf("Walker should have been relocated off the wall, got %v", walker.Position)
+	}
+	if rw.GetGrid()[walker.Position.Y][walker.Position.X] != walker.ID {
+		t.Error("Grid should show the walker at its new position")
+	}
+	if rw.GetGrid()[rows/2][cols/2] != 0 {
+		t.Error("Grid should not show the walker on the wall")
+	}
+
+	// The layout survives a reset
+	rw.Reset(rows, cols, ModeSingleWalker, 1, 50)
+	if !rw.GetObstacles()[0][cols/2] || rw.isObstacle(rw.GetWalkers()[0].Position) {
+		t.Error("Obstacles should be kept and walkers relocated after reset")
+	}
+}
+
 func BenchmarkRandomWalkStep(b *testing.B) {
 	rows, cols := 100, 100
 	rw := NewRandomWalk(rows, cols, ModeSingleWalker, 1, 50)
@@ -200,3 +556,71 @@ func BenchmarkMultiWalkerStep(b *testing.B) {
 		rw.Step()
 	}
 }
+
+func TestWalk3D(t *testing.T) {
+	rw := NewRandomWalk(24, 76, ModeWalk3D, 1, 50)
+	walker := rw.GetWalkers()[0]
+	side := rw.cubeSide()
+	if side != 11 {
+		t.Fatalf("Expected cube side 11 for a 24x76 grid, got %d", side)
+	}
+
+	for i := 0; i < 200; i++ {
+		prev := walker.Displacement
+		rw.Step()
+
+		// Every step moves one unit along exactly one axis
+		d := walker.Displacement
+		if moved := abs(d.X-prev.X) + abs(d.Y-prev.Y) + abs(d.Z-prev.Z); moved != 1 {
+			t.Fatalf("Step %d moved %d units", i, moved)
+		}
+		p := walker.Position
+		if p.X < 0 || p.X >= side || p.Y < 0 || p.Y >= side || p.Z < 0 || p.Z >= side {
+			t.Fatalf("Walker left the cube at %v", p)
+		}
+	}
+
+	// The walker is drawn where it projects
+	row, col, _ := rw.project(walker.Position)
+	if rw.GetGrid()[row][col] != walker.ID {
+		t.Errorf("Expected walker at (%d,%d)", row, col)
+	}
+
+	bounds := rw.GetBounds3D()
+	if bounds.X < 1 || bounds.Y < 1 || bounds.Z < 1 || bounds.X+bounds.Y+bounds.Z == 3 {
+		t.Errorf("Expected the bounds to grow, got %v", bounds)
+	}
+}
+
+func TestProject3DDepth(t *testing.T) {
+	rw := NewRandomWalk(24, 76, ModeWalk3D, 1, 50)
+	walker := rw.GetWalkers()[0]
+
+	// Moving one unit along every axis projects onto the same cell, nearer
+	far := Position{X: 2, Y: 2, Z: 2}
+	near := Position{X: 3, Y: 3, Z: 3}
+	farRow, farCol, farDepth := rw.project(far)
+	nearRow, nearCol, nearDepth := rw.project(near)
+	if farRow != nearRow || farCol != nearCol || nearDepth <= farDepth {
+		t.Fatalf("Expected %v to hide %v", near, far)
+	}
+
+	// The nearer point overdraws the farther one regardless of order
+	for _, trail := range [][]Position{{far, near}, {near, far}} {
+		walker.Trail = trail
+		walker.Position = Position{}
+		rw.project3D()
+		if got := rw.depth[farRow][farCol]; got != nearDepth {
+			t.Errorf("Trail %v: expected depth %d, got %d", trail, nearDepth, got)
+		}
+	}
+
+	// Nearer points are drawn brighter
+	walker.Trail = []Position{{X: 0, Y: 0, Z: 10}, {X: 10, Y: 10, Z: 0}}
+	rw.project3D()
+	r0, c0, _ := rw.project(walker.Trail[0])
+	r1, c1, _ := rw.project(walker.Trail[1])
+	if rw.GetTrails()[r0][c0] >= rw.GetTrails()[r1][c1] {
+		t.Errorf("Expected the nearer point to be brighter, got %d and %d", rw.GetTrails()[r0][c0], rw.GetTrails()[r1][c1])
+	}
+}
@@ -0,0 +1,147 @@
+// Package main implements a terminal-based Wa-Tor predator-prey simulation.
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/telepair/go-playground/pkg"
+)
+
+// Language represents the supported languages
+type Language int
+
+// Language constants
+const (
+	English Language = iota
+	Chinese
+)
+
+// ToString returns the string representation of language
+func (l Language) ToString(language Language) string {
+	switch l {
+	case English:
+		if language == Chinese {
+			return "英文"
+		}
+		return "en"
+	case Chinese:
+		if language == Chinese {
+			return "中文"
+		}
+		return "cn"
+	}
+	if language == Chinese {
+		return "英文"
+	}
+	return "en"
+}
+
+// Application constants
+const (
+	// Grid and display constants
+	DefaultRows = 30 // Default window rows
+	DefaultCols = 80 // Default window columns
+
+	DefaultLanguage    = English                // Default language
+	DefaultRefreshRate = 100 * time.Millisecond // Default refresh rate
+	MinRefreshRate     = 10 * time.Millisecond  // Minimum refresh rate
+
+	// Ecosystem
+	DefaultFishDensity  = 0.3 // Default fraction of cells initially holding a fish
+	DefaultSharkDensity = 0.1 // Default fraction of cells initially holding a shark
+	DefaultFishBreed    = 3   // Default chronons a fish lives before it breeds
+	DefaultSharkBreed   = 10  // Default chronons a shark lives before it breeds
+	DefaultSharkStarve  = 3   // Default chronons a shark survives without eating
+	MaxChronons         = 50  // Largest breed or starve time
+
+	// Colors
+	DefaultFishColor  = "#32CD32" // Fish color (green)
+	DefaultSharkColor = "#FF4500" // Shark color (orange red)
+	DefaultWaterColor = "#000080" // Water color (navy)
+
+	// Characters
+	DefaultFishChar  = "•" // Fish character
+	DefaultSharkChar = "▲" // Shark character
+	DefaultWaterChar = " " // Water character
+
+	// Default values
+	DefaultLogFile         = "debug.log"     // Default log file path
+	DefaultProfileInterval = 5 * time.Second // Default profile information output interval
+	DefaultProfilePort     = 6060            // Default profile server port
+)
+
+// DefaultConfig is the default configuration
+var DefaultConfig = Config{
+	FishColor:    DefaultFishColor,
+	SharkColor:   DefaultSharkColor,
+	WaterColor:   DefaultWaterColor,
+	FishChar:     DefaultFishChar,
+	SharkChar:    DefaultSharkChar,
+	WaterChar:    DefaultWaterChar,
+	FishDensity:  DefaultFishDensity,
+	SharkDensity: DefaultSharkDensity,
+	FishBreed:    DefaultFishBreed,
+	SharkBreed:   DefaultSharkBreed,
+	SharkStarve:  DefaultSharkStarve,
+	Language:     DefaultLanguage,
+}
+
+// Config holds all application configuration
+type Config struct {
+	FishColor    string
+	SharkColor   string
+	WaterColor   string
+	FishChar     string
+	SharkChar    string
+	WaterChar    string
+	FishDensity  float64 // Fraction of cells initially holding a fish
+	SharkDensity float64 // Fraction of cells initially holding a shark
+	FishBreed    int     // Chronons a fish lives before it breeds
+	SharkBreed   int     // Chronons a shark lives before it breeds
+	SharkStarve  int     // Chronons a shark survives without eating
+	Language     Language
+}
+
+// SetLanguage sets the language
+func (c *Config) SetLanguage(lang string) {
+	langLower := strings.ToLower(lang)
+	if langLower == "cn" || langLower == "zh" {
+		c.Language = Chinese
+	} else {
+		c.Language = English
+	}
+}
+
+// Check validates the configuration
+func (c *Config) Check() {
+	for _, msg := range pkg.Validate(
+		pkg.HexColor("fish", &c.FishColor, DefaultFishColor),
+		pkg.HexColor("shark", &c.SharkColor, DefaultSharkColor),
+		pkg.HexColor("water", &c.WaterColor, DefaultWaterColor),
+		pkg.Char("fish", &c.FishChar, DefaultFishChar),
+		pkg.Char("shark", &c.SharkChar, DefaultSharkChar),
+		pkg.Char("water", &c.WaterChar, DefaultWaterChar),
+		pkg.Language(&c.Language, DefaultLanguage),
+	) {
+		fmt.Println(msg)
+	}
+	if c.FishDensity <= 0 || c.SharkDensity <= 0 || c.FishDensity+c.SharkDensity > 1 {
+		fmt.Printf("invalid densities %v and %v, must be positive with a sum of at most 1, using defaults %v and %v\n",
+			c.FishDensity, c.SharkDensity, DefaultFishDensity, DefaultSharkDensity)
+		c.FishDensity, c.SharkDensity = DefaultFishDensity, DefaultSharkDensity
+	}
+	if c.FishBreed < 1 || c.FishBreed > MaxChronons {
+		fmt.Printf("invalid fish breed time %d, must be between 1 and %d, using default %d\n", c.FishBreed, MaxChronons, DefaultFishBreed)
+		c.FishBreed = DefaultFishBreed
+	}
+	if c.SharkBreed < 1 || c.SharkBreed > MaxChronons {
+		fmt.Printf("invalid shark breed time %d, must be between 1 and %d, using default %d\n", c.SharkBreed, MaxChronons, DefaultSharkBreed)
+		c.SharkBreed = DefaultSharkBreed
+	}
+	if c.SharkStarve < 1 || c.SharkStarve > MaxChronons {
+		fmt.Printf("invalid shark starve time %d, must be between 1 and %d, using default %d\n", c.SharkStarve, MaxChronons, DefaultSharkStarve)
+		c.SharkStarve = DefaultSharkStarve
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	_ "net/http/pprof" //nolint:gosec
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/telepair/go-playground/pkg"
+)
+
+func main() {
+	// Custom usage function
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Wa-Tor - A Terminal User Interface implementation of the Wa-Tor predator-prey simulation\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s                                      # Run with default settings\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -shark-density 0.02 -shark-starve 5 # Few sharks that last longer without food\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -fish-breed 2 -shark-breed 6          # Faster breeding on both sides\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -lang cn                             # Run in Chinese\n", os.Args[0])
+	}
+
+	// Parse command line flags
+	var fishColor = flag.String("fish-color", DefaultFishColor, "Fish color (hex)")
+	var sharkColor = flag.String("shark-color", DefaultSharkColor, "Shark color (hex)")
+	var waterColor = flag.String("water-color", DefaultWaterColor, "Water color (hex)")
+	var fishChar = flag.String("fish-char", DefaultFishChar, "Fish character")
+	var sharkChar = flag.String("shark-char", DefaultSharkChar, "Shark character")
+	var waterChar = flag.String("water-char", DefaultWaterChar, "Water character")
+	var fishDensity = flag.Float64("fish-density", DefaultFishDensity, "Fraction of cells initially holding a fish")
+	var sharkDensity = flag.Float64("shark-density", DefaultSharkDensity, "Fraction of cells initially holding a shark")
+	var fishBreed = flag.Int("fish-breed", DefaultFishBreed, "Chronons a fish lives before it breeds")
+	var sharkBreed = flag.Int("shark-breed", DefaultSharkBreed, "Chronons a shark lives before it breeds")
+	var sharkStarve = flag.Int("shark-starve", DefaultSharkStarve, "Chronons a shark survives without eating")
+	var lang = flag.String("lang", DefaultLanguage.ToString(DefaultLanguage), "Language (en/cn)")
+	var enableProfiling = flag.Bool("profile", false, "Enable profiling and monitoring")
+	var profilePort = flag.Int("profile-port", DefaultProfilePort, "Profiling server port")
+	var profileInterval = flag.Duration("profile-interval", DefaultProfileInterval, "Profile information output interval")
+	var logFile = flag.String("log-file", DefaultLogFile, "Log file path")
+
+	flag.Parse()
+
+	if *logFile != "" {
+		_, _ = pkg.InitLog("debug", "text", *logFile)
+	}
+	slog.Debug("Wa-Tor starting")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize monitoring if enabled
+	if *enableProfiling {
+		go pkg.StartProfile(ctx, *profilePort)
+		go pkg.StartWatchdog(ctx, *profileInterval)
+	}
+
+	// Create and configure application
+	config := Config{
+		FishColor:    *fishColor,
+		SharkColor:   *sharkColor,
+		WaterColor:   *waterColor,
+		FishChar:     *fishChar,
+		SharkChar:    *sharkChar,
+		WaterChar:    *waterChar,
+		FishDensity:  *fishDensity,
+		SharkDensity: *sharkDensity,
+		FishBreed:    *fishBreed,
+		SharkBreed:   *sharkBreed,
+		SharkStarve:  *sharkStarve,
+	}
+	config.SetLanguage(*lang)
+	config.Check()
+
+	// Map colors to what the terminal can show
+	pkg.SetColorProfile(pkg.DetectColorProfile(os.Getenv))
+
+	// Create initial model
+	initialModel := NewModel(config)
+
+	// Run the application
+	p := tea.NewProgram(initialModel, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		os.Exit(1)
+	}
+
+	slog.Debug("Wa-Tor finished")
+}
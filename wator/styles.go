@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/telepair/go-playground/pkg"
+)
+
+// UI styles
+var (
+	// Header styles
+	headerStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#1E3A8A")).
+			Padding(0, 2).
+			MarginBottom(1).
+			Align(lipgloss.Center)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#4A5568")).
+			Padding(0, 1).
+			Bold(true)
+
+	tableBuilder strings.Builder
+)
+
+// UI text constants
+const (
+	// Header Line
+	HeaderCN = "🦈 Wa-Tor 捕食者与猎物 🐟"
+	HeaderEN = "🦈 Wa-Tor Predator and Prey 🐟"
+
+	// Status Line
+	ChrononLabelCN = "🔢 时间: %d"
+	ChrononLabelEN = "🔢 Chronon: %d"
+
+	FishLabelCN = "🐟 鱼: %d"
+	FishLabelEN = "🐟 Fish: %d"
+
+	SharksLabelCN = "🦈 鲨鱼: %d"
+	SharksLabelEN = "🦈 Sharks: %d"
+
+	ParametersLabelCN = "⚙️ 鱼繁殖 %d / 鲨繁殖 %d / 饿死 %d"
+	ParametersLabelEN = "⚙️ Fish breed %d / Shark breed %d / Starve %d"
+
+	SpeedLabelCN = "🔄 刷新: %s"
+	SpeedLabelEN = "🔄 Speed: %s"
+
+	SizeLabelCN = "📐 尺寸: %d×%d"
+	SizeLabelEN = "📐 Size: %d×%d"
+
+	StatusLabelPlayingCN = "▶️ 运行中"
+	StatusLabelPlayingEN = "▶️ Running"
+	StatusLabelPausedCN  = "⏸️ 已暂停"
+	StatusLabelPausedEN  = "⏸️ Paused"
+
+	// Control Line
+	FishBreedControlLabelCN = "f/F 鱼繁殖 +/-"
+	FishBreedControlLabelEN = "f/F Fish Breed +/-"
+
+	SharkBreedControlLabelCN = "s/S 鲨繁殖 +/-"
+	SharkBreedControlLabelEN = "s/S Shark Breed +/-"
+
+	StarveControlLabelCN = "d/D 饿死 +/-"
+	StarveControlLabelEN = "d/D Starve +/-"
+
+	SpeedControlLabelCN = "+/- 加速/减速"
+	SpeedControlLabelEN = "+/- Speed Up/Down"
+
+	LanguageLabelCN = "L 切换语言"
+	LanguageLabelEN = "L Switch Language"
+
+	SpaceControlLabelCN = "Space 暂停"
+	SpaceControlLabelEN = "Space Pause"
+
+	ResetLabelCN = "R 重新播种"
+	ResetLabelEN = "R Reseed"
+
+	QuitLabelCN = "Q 退出"
+	QuitLabelEN = "Q Quit"
+)
+
+// RenderOptions contains rendering configuration with cached styles
+type RenderOptions struct {
+	creatureStyled [3]string // Cached styled cells, indexed by Creature
+}
+
+// NewRenderOptions pre-computes the styled string for each creature
+func NewRenderOptions(cfg Config) RenderOptions {
+	var ro RenderOptions
+	water := lipgloss.NewStyle().Background(pkg.AdaptColor(cfg.WaterColor))
+	ro.creatureStyled[CreatureNone] = water.Render(cfg.WaterChar)
+	ro.creatureStyled[CreatureFish] = water.Foreground(pkg.AdaptColor(cfg.FishColor)).Render(cfg.FishChar)
+	ro.creatureStyled[CreatureShark] = water.Foreground(pkg.AdaptColor(cfg.SharkColor)).Render(cfg.SharkChar)
+	return ro
+}
+
+// HeaderLineView returns the header display string
+func (m Model) HeaderLineView() string {
+	style := headerStyle.Width(m.width)
+	if m.language == Chinese {
+		return style.Render(HeaderCN)
+	}
+	return style.Render(HeaderEN)
+}
+
+// StatusLineView returns the status display string
+func (m Model) StatusLineView() string {
+	var status, chrononLabel, fishLabel, sharksLabel, parametersLabel, speedLabel, sizeLabel string
+
+	if m.language == Chinese {
+		status = StatusLabelPlayingCN
+		if m.paused {
+			status = StatusLabelPausedCN
+		}
+		chrononLabel = ChrononLabelCN
+		fishLabel = FishLabelCN
+		sharksLabel = SharksLabelCN
+		parametersLabel = ParametersLabelCN
+		speedLabel = SpeedLabelCN
+		sizeLabel = SizeLabelCN
+	} else {
+		status = StatusLabelPlayingEN
+		if m.paused {
+			status = StatusLabelPausedEN
+		}
+		chrononLabel = ChrononLabelEN
+		fishLabel = FishLabelEN
+		sharksLabel = SharksLabelEN
+		parametersLabel = ParametersLabelEN
+		speedLabel = SpeedLabelEN
+		sizeLabel = SizeLabelEN
+	}
+
+	tableBuilder.Reset()
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(chrononLabel, m.ocean.GetChronon())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(fishLabel, m.ocean.GetFish())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sharksLabel, m.ocean.GetSharks())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(parametersLabel,
+		m.ocean.GetFishBreed(), m.ocean.GetSharkBreed(), m.ocean.GetSharkStarve())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(speedLabel, m.refreshRate.String())))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(fmt.Sprintf(sizeLabel, m.gridHeight, m.gridWidth)))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(status))
+
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
+}
+
+// ControlLineView returns the control display string
+func (m Model) ControlLineView() string {
+	var fishBreed, sharkBreed, starve, speedControl, language, space, reset, quit string
+	if m.language == Chinese {
+		fishBreed = FishBreedControlLabelCN
+		sharkBreed = SharkBreedControlLabelCN
+		starve = StarveControlLabelCN
+		speedControl = SpeedControlLabelCN
+		language = LanguageLabelCN
+		space = SpaceControlLabelCN
+		reset = ResetLabelCN
+		quit = QuitLabelCN
+	} else {
+		fishBreed = FishBreedControlLabelEN
+		sharkBreed = SharkBreedControlLabelEN
+		starve = StarveControlLabelEN
+		speedControl = SpeedControlLabelEN
+		language = LanguageLabelEN
+		space = SpaceControlLabelEN
+		reset = ResetLabelEN
+		quit = QuitLabelEN
+	}
+
+	tableBuilder.Reset()
+	tableBuilder.WriteString(labelStyle.Render(fishBreed))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(sharkBreed))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(starve))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(speedControl))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(language))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(space))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(reset))
+	tableBuilder.WriteString(" | ")
+	tableBuilder.WriteString(labelStyle.Render(quit))
+
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(tableBuilder.String())
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/telepair/go-playground/pkg"
+)
+
+var (
+	keepWidth  = 4
+	keepHeight = 6
+)
+
+// Model represents the application state
+type Model struct {
+	ocean *WaTor
+
+	language Language
+
+	paused        bool
+	refreshRate   time.Duration
+	width         int
+	gridHeight    int
+	gridWidth     int
+	buffer        strings.Builder
+	gridBuffer    strings.Builder
+	renderOptions RenderOptions
+	logger        *slog.Logger
+}
+
+// NewModel creates a new model with the given configuration
+func NewModel(cfg Config) Model {
+	cfg.Check()
+
+	gridHeight := DefaultRows - keepHeight
+	gridWidth := DefaultCols - keepWidth
+
+	return Model{
+		ocean:         NewWaTor(gridHeight, gridWidth, cfg),
+		language:      cfg.Language,
+		width:         DefaultCols,
+		gridHeight:    gridHeight,
+		gridWidth:     gridWidth,
+		renderOptions: NewRenderOptions(cfg),
+		refreshRate:   DefaultRefreshRate,
+		logger:        slog.With("module", "ui"),
+	}
+}
+
+// tickMsg is sent every tick
+type tickMsg time.Time
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return tea.Tick(m.refreshRate, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.logger.Debug("Window size changed", "width", msg.Width, "height", msg.Height)
+		return m.handleWindowResize(msg)
+	case tea.KeyMsg:
+		m.logger.Debug("Key pressed", "key", msg.String())
+		return m.handleKeyPress(msg)
+	case tickMsg:
+		return m.handleTick(time.Time(msg))
+	}
+	return m, nil
+}
+
+// View renders the current state
+func (m Model) View() string {
+	m.logger.Debug("Model View",
+		"width", m.width,
+		"gridWidth", m.gridWidth,
+		"gridHeight", m.gridHeight,
+		"language", m.language,
+		"paused", m.paused,
+		"refreshRate", m.refreshRate)
+	return m.RenderMode()
+}
+
+// handleWindowResize processes terminal window size changes
+func (m Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
+	m.width = msg.Width
+	m.gridWidth = msg.Width - keepWidth
+	m.gridHeight = msg.Height - keepHeight
+	m.ocean.Reset(m.gridHeight, m.gridWidth)
+	return m, nil
+}
+
+// handleKeyPress processes keyboard input. Breed and starve times change the
+// running ecosystem without reseeding it.
+func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+
+	case " ", "enter": // Pause/resume
+		m.paused = !m.paused
+
+	case "l": // Language toggle
+		if m.language == English {
+			m.language = Chinese
+		} else {
+			m.language = English
+		}
+
+	case "+", "=", "up": // Increase refresh rate (make it faster)
+		m.refreshRate = max(m.refreshRate/2, MinRefreshRate)
+
+	case "-", "_", "down": // Decrease refresh rate (make it slower)
+		m.refreshRate = m.refreshRate * 2
+
+	case "f": // Fish breed more slowly
+		m.ocean.SetFishBreed(m.ocean.GetFishBreed() + 1)
+
+	case "F": // Fish breed faster
+		m.ocean.SetFishBreed(m.ocean.GetFishBreed() - 1)
+
+	case "s": // Sharks breed more slowly
+		m.ocean.SetSharkBreed(m.ocean.GetSharkBreed() + 1)
+
+	case "S": // Sharks breed faster
+		m.ocean.SetSharkBreed(m.ocean.GetSharkBreed() - 1)
+
+	case "d": // Sharks survive longer without food
+		m.ocean.SetSharkStarve(m.ocean.GetSharkStarve() + 1)
+
+	case "D": // Sharks starve sooner
+		m.ocean.SetSharkStarve(m.ocean.GetSharkStarve() - 1)
+
+	case "r": // Reseed the ocean
+		m.ocean.Reset(m.gridHeight, m.gridWidth)
+	}
+
+	return m, nil
+}
+
+// handleTick processes timer ticks
+func (m Model) handleTick(tick time.Time) (tea.Model, tea.Cmd) {
+	if !m.paused {
+		m.ocean.Step()
+	}
+
+	// Schedule from when this tick fired, so time spent stepping does not
+	// stretch the interval
+	delay := pkg.NextTickDelay(m.refreshRate, MinRefreshRate, time.Since(tick))
+	return m, tea.Tick(delay, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// RenderMode renders the complete UI
+func (m Model) RenderMode() string {
+	m.buffer.Reset()
+
+	m.buffer.WriteString(m.HeaderLineView())
+	m.buffer.WriteString("\n")
+	m.buffer.WriteString(m.StatusLineView())
+	m.buffer.WriteString("\n\n")
+	m.buffer.WriteString(m.RenderGrid())
+	m.buffer.WriteString("\n\n")
+	m.buffer.WriteString(m.ControlLineView())
+
+	return m.buffer.String()
+}
+
+// RenderGrid renders the ocean
+func (m *Model) RenderGrid() string {
+	m.gridBuffer.Reset()
+	grid := m.ocean.GetGrid()
+
+	lastRowIndex := len(grid) - 1
+	for i, row := range grid {
+		m.gridBuffer.WriteString(" ")
+		for _, cell := range row {
+			m.gridBuffer.WriteString(m.renderOptions.creatureStyled[cell.Creature])
+		}
+		if i < lastRowIndex {
+			m.gridBuffer.WriteByte('\n')
+		}
+	}
+
+	return m.gridBuffer.String()
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Creature is the occupant of a cell of the ocean
+type Creature uint8
+
+// Creature constants
+const (
+	CreatureNone  Creature = iota // Empty water
+	CreatureFish                  // Prey, breeds after FishBreed chronons
+	CreatureShark                 // Predator, breeds after SharkBreed chronons and starves after SharkStarve
+)
+
+// Cell is one cell of the ocean and the counters of its creature
+type Cell struct {
+	Creature Creature
+	breed    int  // Chronons since the creature was born or last bred
+	starve   int  // Chronons since the shark last ate
+	moved    bool // Whether the creature has already moved this chronon
+}
+
+// neighborOffsets lists the (row, col) offsets of the four neighbours of a cell
+var neighborOffsets = [4][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+
+// WaTor is A. K. Dewdney's predator-prey ecosystem on a toroidal ocean.
+// Each chronon every fish moves to a random empty neighbour, and every shark
+// to a random neighbouring fish, which it eats, or else to an empty
+// neighbour. A creature that has lived long enough since it last bred leaves
+// a newborn behind when it moves, and a shark that has not eaten for too long
+// starves.
+type WaTor struct {
+	mu           sync.RWMutex
+	rows         int
+	cols         int
+	fishDensity  float64
+	sharkDensity float64
+	fishBreed    int
+	sharkBreed   int
+	sharkStarve  int
+	grid         [][]Cell
+	order        []int // Cell indices in the order creatures move, reshuffled every chronon
+	chronon      int
+	fish         int // Number of fish
+	sharks       int // Number of sharks
+	rng          *rand.Rand
+}
+
+// NewWaTor creates an ocean seeded with fish and sharks at the densities and
+// the breed and starve times of the configuration
+func NewWaTor(rows, cols int, cfg Config) *WaTor {
+	// Use time-based seeding for randomization (not cryptographic)
+	// #nosec G115 - Conversion is safe for our use case
+	seed := uint64(time.Now().UnixNano())
+
+	w := &WaTor{
+		fishDensity:  cfg.FishDensity,
+		sharkDensity: cfg.SharkDensity,
+		fishBreed:    cfg.FishBreed,
+		sharkBreed:   cfg.SharkBreed,
+		sharkStarve:  cfg.SharkStarve,
+		// #nosec G404 - Using math/rand for simulation, not cryptography
+		rng: rand.New(rand.NewPCG(seed, seed)),
+	}
+	w.Reset(rows, cols)
+	return w
+}
+
+// Reset resizes the ocean and reseeds it randomly. Breed counters start at
+// random values, so the first generation does not breed all at once.
+func (w *WaTor) Reset(rows, cols int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.rows = max(rows, 1)
+	w.cols = max(cols, 1)
+	w.grid = make([][]Cell, w.rows)
+	for i := range w.grid {
+		w.grid[i] = make([]Cell, w.cols)
+	}
+	w.order = make([]int, w.rows*w.cols)
+	for i := range w.order {
+		w.order[i] = i
+	}
+	w.chronon = 0
+	w.fish, w.sharks = 0, 0
+
+	for i := range w.grid {
+		for j := range w.grid[i] {
+			switch u := w.rng.Float64(); {
+			case u < w.fishDensity:
+				w.grid[i][j] = Cell{Creature: CreatureFish, breed: w.rng.IntN(w.fishBreed)}
+				w.fish++
+			case u < w.fishDensity+w.sharkDensity:
+				w.grid[i][j] = Cell{Creature: CreatureShark, breed: w.rng.IntN(w.sharkBreed)}
+				w.sharks++
+			}
+		}
+	}
+}
+
+// SetCells replaces the ocean contents with the given creatures, all with
+// fresh counters, for tests and fixed patterns. The creatures must match the
+// grid size.
+func (w *WaTor) SetCells(creatures [][]Creature) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.fish, w.sharks = 0, 0
+	for i := range w.grid {
+		for j := range w.grid[i] {
+			w.grid[i][j] = Cell{Creature: creatures[i][j]}
+			switch creatures[i][j] {
+			case CreatureFish:
+				w.fish++
+			case CreatureShark:
+				w.sharks++
+			}
+		}
+	}
+}
+
+// Step advances the ocean by one chronon. Creatures move one at a time in a
+// random order, so no direction of the grid is favoured.
+func (w *WaTor) Step() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i := range w.grid {
+		for j := range w.grid[i] {
+			w.grid[i][j].moved = false
+		}
+	}
+	w.rng.Shuffle(len(w.order), func(i, j int) {
+		w.order[i], w.order[j] = w.order[j], w.order[i]
+	})
+
+	for _, idx := range w.order {
+		row, col := idx/w.cols, idx%w.cols
+		if w.grid[row][col].moved {
+			continue
+		}
+		switch w.grid[row][col].Creature {
+		case CreatureFish:
+			w.moveFish(row, col)
+		case CreatureShark:
+			w.moveShark(row, col)
+		}
+	}
+	w.chronon++
+}
+
+// moveFish moves the fish at (row, col) to a random empty neighbour, leaving
+// a newborn fish behind if it is old enough to breed. A fish with nowhere to
+// go stays, and breeds once it can move.
+func (w *WaTor) moveFish(row, col int) {
+	fish := w.grid[row][col]
+	fish.breed++
+	fish.moved = true
+
+	r, c, ok := w.randomNeighbor(row, col, CreatureNone)
+	if !ok {
+		w.grid[row][col] = fish
+		return
+	}
+	w.grid[row][col] = Cell{}
+	if fish.breed >= w.fishBreed {
+		fish.breed = 0
+		w.grid[row][col] = Cell{Creature: CreatureFish, moved: true}
+		w.fish++
+	}
+	w.grid[r][c] = fish
+}
+
+// moveShark moves the shark at (row, col) onto a random neighbouring fish,
+// eating it, or else to a random empty neighbour. A shark that has gone
+// SharkStarve chronons without eating dies; one old enough to breed leaves a
+// newborn shark behind when it moves.
+func (w *WaTor) moveShark(row, col int) {
+	shark := w.grid[row][col]
+	shark.breed++
+	shark.starve++
+	shark.moved = true
+
+	r, c, ok := w.randomNeighbor(row, col, CreatureFish)
+	if ok {
+		shark.starve = 0
+		w.fish--
+	} else if shark.starve >= w.sharkStarve {
+		w.grid[row][col] = Cell{}
+		w.sharks--
+		return
+	} else {
+		r, c, ok = w.randomNeighbor(row, col, CreatureNone)
+	}
+
+	if !ok {
+		w.grid[row][col] = shark
+		return
+	}
+	w.grid[row][col] = Cell{}
+	if shark.breed >= w.sharkBreed {
+		shark.breed = 0
+		w.grid[row][col] = Cell{Creature: CreatureShark, moved: true}
+		w.sharks++
+	}
+	w.grid[r][c] = shark
+}
+
+// randomNeighbor returns a random neighbour of (row, col) holding the given
+// creature, wrapping around the edges, or false if there is none
+func (w *WaTor) randomNeighbor(row, col int, creature Creature) (int, int, bool) {
+	var candidates [4][2]int
+	n := 0
+	for _, offset := range neighborOffsets {
+		r := (row + offset[0] + w.rows) % w.rows
+		c := (col + offset[1] + w.cols) % w.cols
+		if w.grid[r][c].Creature == creature {
+			candidates[n] = [2]int{r, c}
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, 0, false
+	}
+	pick := candidates[w.rng.IntN(n)]
+	return pick[0], pick[1], true
+}
+
+// SetFishBreed sets the chronons a fish lives before it breeds, clamped to
+// [1, MaxChronons]
+func (w *WaTor) SetFishBreed(chronons int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.fishBreed = max(min(chronons, MaxChronons), 1)
+}
+
+// SetSharkBreed sets the chronons a shark lives before it breeds, clamped to
+// [1, MaxChronons]
+func (w *WaTor) SetSharkBreed(chronons int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sharkBreed = max(min(chronons, MaxChronons), 1)
+}
+
+// SetSharkStarve sets the chronons a shark survives without eating, clamped
+// to [1, MaxChronons]
+func (w *WaTor) SetSharkStarve(chronons int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sharkStarve = max(min(chronons, MaxChronons), 1)
+}
+
+// GetFishBreed returns the chronons a fish lives before it breeds
+func (w *WaTor) GetFishBreed() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.fishBreed
+}
+
+// GetSharkBreed returns the chronons a shark lives before it breeds
+func (w *WaTor) GetSharkBreed() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sharkBreed
+}
+
+// GetSharkStarve returns the chronons a shark survives without eating
+func (w *WaTor) GetSharkStarve() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sharkStarve
+}
+
+// GetGrid returns the cells of the ocean
+func (w *WaTor) GetGrid() [][]Cell {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.grid
+}
+
+// GetChronon returns the number of chronons since the last reset
+func (w *WaTor) GetChronon() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.chronon
+}
+
+// GetFish returns the number of fish
+func (w *WaTor) GetFish() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.fish
+}
+
+// GetSharks returns the number of sharks
+func (w *WaTor) GetSharks() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sharks
+}
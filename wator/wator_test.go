@@ -0,0 +1,195 @@
+package main
+
+import "testing"
+
+// oceanWith returns a WaTor of the given size holding only the given
+// creatures, with the configuration's breed and starve times
+func oceanWith(rows, cols int, cfg Config, creatures map[[2]int]Creature) *WaTor {
+	w := NewWaTor(rows, cols, cfg)
+	cells := make([][]Creature, rows)
+	for i := range cells {
+		cells[i] = make([]Creature, cols)
+	}
+	for p, c := range creatures {
+		cells[p[0]][p[1]] = c
+	}
+	w.SetCells(cells)
+	return w
+}
+
+// findCreatures returns the positions of every cell holding the creature
+func findCreatures(w *WaTor, creature Creature) [][2]int {
+	var found [][2]int
+	for i, row := range w.GetGrid() {
+		for j, cell := range row {
+			if cell.Creature == creature {
+				found = append(found, [2]int{i, j})
+			}
+		}
+	}
+	return found
+}
+
+// Test that a fish moves to a neighbour and breeds after FishBreed chronons
+func TestFishMovesAndBreeds(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.FishBreed = 2
+	w := oceanWith(5, 5, cfg, map[[2]int]Creature{{2, 2}: CreatureFish})
+
+	w.Step()
+	fish := findCreatures(w, CreatureFish)
+	if len(fish) != 1 || w.GetFish() != 1 {
+		t.Fatalf("Expected 1 fish after one chronon, got %d (count %d)", len(fish), w.GetFish())
+	}
+	if d := abs(fish[0][0]-2) + abs(fish[0][1]-2); d != 1 {
+		t.Errorf("Expected the fish to move to a neighbour, got (%d,%d)", fish[0][0], fish[0][1])
+	}
+
+	w.Step()
+	if n := len(findCreatures(w, CreatureFish)); n != 2 || w.GetFish() != 2 {
+		t.Errorf("Expected the fish to breed into 2, got %d (count %d)", n, w.GetFish())
+	}
+	if w.GetChronon() != 2 {
+		t.Errorf("Expected chronon 2, got %d", w.GetChronon())
+	}
+}
+
+// Test that a fish with no empty neighbour stays put
+func TestFishBlocked(t *testing.T) {
+	w := oceanWith(1, 1, DefaultConfig, map[[2]int]Creature{{0, 0}: CreatureFish})
+	w.Step()
+	if w.GetGrid()[0][0].Creature != CreatureFish || w.GetFish() != 1 {
+		t.Error("Expected the lone fish to stay in a 1x1 ocean")
+	}
+}
+
+// Test that a shark eats a neighbouring fish. In a 1x2 ocean the fish has no
+// empty neighbour, so it stays put whichever creature moves first.
+func TestSharkEats(t *testing.T) {
+	w := oceanWith(1, 2, DefaultConfig, map[[2]int]Creature{
+		{0, 0}: CreatureShark,
+		{0, 1}: CreatureFish,
+	})
+	w.Step()
+
+	if w.GetFish() != 0 || len(findCreatures(w, CreatureFish)) != 0 {
+		t.Errorf("Expected the fish to be eaten, got %d fish", w.GetFish())
+	}
+	if cell := w.GetGrid()[0][1]; cell.Creature != CreatureShark || cell.starve != 0 {
+		t.Errorf("Expected a fed shark on the fish's cell, got creature %d starve %d", cell.Creature, cell.starve)
+	}
+}
+
+// Test that a shark starves after SharkStarve chronons without food
+func TestSharkStarves(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.SharkStarve = 3
+	w := oceanWith(5, 5, cfg, map[[2]int]Creature{{2, 2}: CreatureShark})
+
+	for i := 1; i < cfg.SharkStarve; i++ {
+		w.Step()
+		if w.GetSharks() != 1 {
+			t.Fatalf("Expected the shark alive after %d chronons, got %d sharks", i, w.GetSharks())
+		}
+	}
+	w.Step()
+	if w.GetSharks() != 0 || len(findCreatures(w, CreatureShark)) != 0 {
+		t.Errorf("Expected the shark to starve after %d chronons, got %d sharks", cfg.SharkStarve, w.GetSharks())
+	}
+}
+
+// Test that a shark breeds after SharkBreed chronons
+func TestSharkBreeds(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.SharkBreed = 1
+	w := oceanWith(5, 5, cfg, map[[2]int]Creature{{2, 2}: CreatureShark})
+	w.Step()
+	if n := len(findCreatures(w, CreatureShark)); n != 2 || w.GetSharks() != 2 {
+		t.Errorf("Expected the shark to breed into 2, got %d (count %d)", n, w.GetSharks())
+	}
+}
+
+// Test that neighbours wrap around the edges of the toroidal ocean
+func TestToroidalWrap(t *testing.T) {
+	for _, target := range [][2]int{{4, 0}, {0, 4}} {
+		w := oceanWith(5, 5, DefaultConfig, map[[2]int]Creature{
+			{0, 0}: CreatureShark,
+			target: CreatureFish,
+		})
+		r, c, ok := w.randomNeighbor(0, 0, CreatureFish)
+		if !ok || r != target[0] || c != target[1] {
+			t.Errorf("Expected (0,0) to see the fish at (%d,%d) across the edge, got (%d,%d) %v",
+				target[0], target[1], r, c, ok)
+		}
+	}
+}
+
+// Test that Reset resizes and reseeds the ocean
+func TestReset(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.FishDensity, cfg.SharkDensity = 0.5, 0.5
+	w := NewWaTor(10, 10, cfg)
+	if w.GetFish()+w.GetSharks() != 100 {
+		t.Errorf("Expected a full ocean at total density 1, got %d", w.GetFish()+w.GetSharks())
+	}
+	w.Step()
+
+	w.Reset(4, 6)
+	grid := w.GetGrid()
+	if len(grid) != 4 || len(grid[0]) != 6 {
+		t.Errorf("Expected a 4x6 ocean, got %dx%d", len(grid), len(grid[0]))
+	}
+	if w.GetChronon() != 0 {
+		t.Errorf("Expected chronon 0 after reset, got %d", w.GetChronon())
+	}
+	if fish, sharks := len(findCreatures(w, CreatureFish)), len(findCreatures(w, CreatureShark)); fish != w.GetFish() || sharks != w.GetSharks() {
+		t.Errorf("Expected counts %d and %d to match the grid, got %d and %d", fish, sharks, w.GetFish(), w.GetSharks())
+	}
+}
+
+// Test that the breed and starve setters clamp to [1, MaxChronons]
+func TestParameterClamping(t *testing.T) {
+	w := NewWaTor(4, 4, DefaultConfig)
+	w.SetFishBreed(0)
+	w.SetSharkBreed(MaxChronons + 1)
+	w.SetSharkStarve(-5)
+	if w.GetFishBreed() != 1 {
+		t.Errorf("Expected fish breed 1, got %d", w.GetFishBreed())
+	}
+	if w.GetSharkBreed() != MaxChronons {
+		t.Errorf("Expected shark breed %d, got %d", MaxChronons, w.GetSharkBreed())
+	}
+	if w.GetSharkStarve() != 1 {
+		t.Errorf("Expected shark starve 1, got %d", w.GetSharkStarve())
+	}
+}
+
+// Test configuration validation
+func TestConfigCheck(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.FishColor = "green"
+	cfg.SharkChar = "ab"
+	cfg.FishDensity, cfg.SharkDensity = 0.8, 0.5
+	cfg.SharkStarve = 0
+	cfg.FishBreed = MaxChronons + 1
+	cfg.Check()
+	if cfg.FishColor != DefaultFishColor {
+		t.Errorf("Expected default fish color, got %s", cfg.FishColor)
+	}
+	if cfg.SharkChar != DefaultSharkChar {
+		t.Errorf("Expected default shark char, got %s", cfg.SharkChar)
+	}
+	if cfg.FishDensity != DefaultFishDensity || cfg.SharkDensity != DefaultSharkDensity {
+		t.Errorf("Expected default densities, got %v and %v", cfg.FishDensity, cfg.SharkDensity)
+	}
+	if cfg.SharkStarve != DefaultSharkStarve || cfg.FishBreed != DefaultFishBreed {
+		t.Errorf("Expected default starve and breed, got %d and %d", cfg.SharkStarve, cfg.FishBreed)
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}